@@ -0,0 +1,92 @@
+package ga
+
+import "math"
+
+// Adaptation is the state of the adaptive mutation probability.
+type Adaptation struct {
+	// PM is the mutation probability.
+	PM float64
+	// Base is the standard deviation of the initial population, which the adaptation is relative to.
+	// The adaptation of a generation depends only on PM, Base and the standard deviation of the generation itself,
+	// so no other state is needed to resume it.
+	Base float64
+}
+
+// Adaptation returns the state of the adaptive mutation probability.
+func (m *GA) Adaptation() Adaptation {
+	return Adaptation{m.pm, m.base}
+}
+
+// WithAdaptation restores the state of the adaptive mutation probability, e.g. when resuming from a checkpoint.
+// Otherwise, the mutation probability restarts from 0.1, relative to the new initial population,
+// so it would mis-adapt for the first generations after resume.
+func WithAdaptation(a Adaptation) Option {
+	return func(m *GA) {
+		m.warm = &a
+	}
+}
+
+// MutationRate returns the current mutation probability.
+func (m *GA) MutationRate() float64 {
+	return m.pm
+}
+
+// WithMutationPolicy replaces the built-in adaptation of the mutation probability with f,
+// which is called with the statistics of each new generation, and returns the mutation probability for the next one.
+// Stats.PM is the current mutation probability, and the result is clamped to the bounds set by WithMutationBounds.
+// WithFixedMutationRate takes precedence over f.
+func WithMutationPolicy(f func(Stats) float64) Option {
+	return func(m *GA) {
+		m.policy = f
+	}
+}
+
+// Params is the state of the adaptation and the selection of a GA model.
+type Params struct {
+	Adaptation
+	// FSum is the sum of the selection weights of the current population.
+	FSum float64
+	// Generation is the number of generations produced since New.
+	Generation int
+}
+
+// Params returns the state of the adaptation and the selection.
+func (m *GA) Params() Params {
+	return Params{m.Adaptation(), m.pop.fsum, m.gen}
+}
+
+// Reset regenerates the population by the generator, e.g. to re-optimize a changed problem online,
+// reusing the model instead of creating a new one by New.
+// The mutation probability restarts from its initial value, relative to the new population.
+// The elite is kept if keep and it is fitter than the new population, or cleared otherwise.
+// The generations and the evaluations are still counted since New.
+func (m *GA) Reset(keep bool) {
+	if !keep {
+		m.elite, m.fitness = nil, math.Inf(-1)
+	}
+	if m.memo != nil {
+		m.memo.reset()
+	}
+	m.trajectory = m.trajectory[:0]
+	m.generate(nil)
+	m.reinitialize()
+}
+
+// reinitialize evaluates the new population, and restarts the mutation probability relative to it.
+func (m *GA) reinitialize() {
+	m.partial = nil
+	m.pm, m.base = m.pm0, 0
+	m.base = m.adjust()
+	if m.streaming != nil {
+		m.base = m.streamInitial()
+	}
+	if m.lineage != nil {
+		m.lineage.settle(m)
+	}
+	m.detect()
+	m.publish()
+}
+
+func (m *GA) restore(a Adaptation) {
+	m.pm, m.base = a.PM, a.Base
+}
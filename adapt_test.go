@@ -0,0 +1,133 @@
+package ga_test
+
+import (
+	"bytes"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestWarmAdaptation(t *testing.T) {
+	nc := ga.NC
+	ga.NC = 1
+	defer func() { ga.NC = nc }()
+
+	a := newDet(3)
+	pop := a.EvolveTo(2)
+	s := a.Adaptation()
+	if s.PM > 0.1 || s.Base <= 0 {
+		t.Fatal("adaptation:", s)
+	}
+
+	i := 0
+	b := ga.New(len(pop), func() ga.Entity {
+		i++
+		return pop[i-1]
+	}, ga.WithAdaptation(s), ga.WithSeed(3))
+	if b.Adaptation() != s {
+		t.Fatal("restored:", b.Adaptation(), s)
+	}
+	b.Next()
+	std := b.Stats().Std
+	pm := s.PM * (0.2*math.Exp(-5*std/s.Base) + 0.9)
+	pm = math.Max(0.0001, math.Min(0.1, pm))
+	if math.Abs(b.Adaptation().PM-pm) > 1e-15 || b.Adaptation().Base != s.Base {
+		t.Fatal("trajectory:", b.Adaptation(), pm)
+	}
+}
+
+func TestWarmAdaptationResume(t *testing.T) {
+	nc := ga.NC
+	ga.NC = 1
+	defer func() { ga.NC = nc }()
+
+	newSaved := func() *ga.GA {
+		i := 0
+		return ga.New(30, func() ga.Entity {
+			i++
+			return Saved(i%10 - 5)
+		}, ga.WithSeed(3))
+	}
+	trajectory := func(m *ga.GA) []float64 {
+		var pms []float64
+		for m.Generation() < 15 {
+			m.Next()
+			pms = append(pms, m.MutationRate())
+		}
+		return pms
+	}
+	m := newSaved()
+	m.EvolveTo(5)
+	var buf bytes.Buffer
+	if err := m.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	xs := trajectory(m)
+
+	r := newSaved()
+	r.EvolveTo(2)
+	if err := r.Load(&buf, decodeSaved); err != nil {
+		t.Fatal(err)
+	}
+	ys := trajectory(r)
+	if len(xs) != 10 || len(ys) != len(xs) {
+		t.Fatal("generations:", len(xs), len(ys))
+	}
+	for i := range xs {
+		if xs[i] != ys[i] {
+			t.Fatal("trajectory:", i, xs, ys)
+		}
+	}
+}
+
+func TestMutationPolicy(t *testing.T) {
+	var gens []int
+	m := ga.New(20, func() ga.Entity {
+		return Walk(rand.Float64())
+	}, ga.WithMutationPolicy(func(s ga.Stats) float64 {
+		gens = append(gens, s.Generation)
+		return s.PM * 2
+	}), ga.WithMutationBounds(0.01, 0.5))
+	if m.MutationRate() != 0.1 {
+		t.Fatal("initial:", m.MutationRate())
+	}
+	m.Next()
+	if m.MutationRate() != 0.2 {
+		t.Fatal("policy:", m.MutationRate())
+	}
+	m.Next()
+	m.Next()
+	if m.MutationRate() != 0.5 {
+		t.Fatal("clamped:", m.MutationRate())
+	}
+	if len(gens) != 3 || gens[0] != 1 || gens[2] != 3 {
+		t.Fatal("generations:", gens)
+	}
+}
+
+func TestReset(t *testing.T) {
+	m := ga.New(50, MIN{}.Mutate)
+	p0 := m.Params()
+	if p0.PM != 0.1 || p0.Base <= 0 || p0.FSum <= 0 || p0.Generation != 0 {
+		t.Fatal("initial:", p0)
+	}
+	e, f, _ := m.Evolve(30, 100)
+	p := m.Params()
+	if p.PM >= p0.PM || p.Generation != m.Generation() {
+		t.Fatal("evolved:", p)
+	}
+
+	m.Reset(true)
+	if r := m.Params(); r.PM != 0.1 || r.Base == p.Base || r.Generation != p.Generation {
+		t.Fatal("reset:", r)
+	}
+	if m.Elite() != e || m.Fitness() != f {
+		t.Fatal("kept:", m.Elite(), e)
+	}
+	m.Reset(false)
+	if best := m.Stats().Best; m.Fitness() != best || m.Fitness() >= f {
+		t.Fatal("cleared:", m.Fitness(), f)
+	}
+}
@@ -0,0 +1,179 @@
+package ga
+
+// WithALPS structures the population by the age-layered population structure, ALPS,
+// which divides the slots into the layers equally, from the youngest at the bottom to the oldest at the top.
+// The age of an offspring is the age of its older parent plus one, and the age of a fresh entity is 0.
+// The age limit of the layer l counted from 0 is gap*(l+1)², and the top layer is unlimited.
+// Before every generation, an entity older than the limit of its layer moves up to replace the worst of the next layer
+// if it is fitter, and its slot gets a fresh entity from the generator,
+// and every gap generations, the bottom layer is re-seeded with fresh entities.
+// The parents of an offspring are selected from its layer and the one below,
+// and the fittest entity of each layer is kept.
+// It works with the default generational replacement, and WithElitism is ignored.
+func WithALPS(layers, gap int) Option {
+	return func(m *GA) {
+		if layers < 1 {
+			layers = 1
+		}
+		if gap < 1 {
+			gap = 1
+		}
+		m.alps = &alps{layers: layers, gap: gap}
+	}
+}
+
+// Ages returns the ages of the entities of the current population by WithALPS, or nil without it.
+func (m *GA) Ages() []int {
+	if m.alps == nil {
+		return nil
+	}
+	return append([]int(nil), m.alps.ages...)
+}
+
+type alps struct {
+	layers, gap int
+	ages, next  []int
+}
+
+// bounds returns the slots [lo, hi) of the layer l of n slots.
+func (a *alps) bounds(l, n int) (int, int) {
+	return l * n / a.layers, (l + 1) * n / a.layers
+}
+
+// layer returns the layer of the slot i of n slots.
+func (a *alps) layer(i, n int) int {
+	l := i * a.layers / n
+	for l > 0 {
+		if lo, _ := a.bounds(l, n); lo <= i {
+			break
+		}
+		l--
+	}
+	for l < a.layers-1 {
+		if _, hi := a.bounds(l, n); i < hi {
+			break
+		}
+		l++
+	}
+	return l
+}
+
+func (a *alps) limit(l int) int {
+	return a.gap * (l + 1) * (l + 1)
+}
+
+// prepareALPS moves up the entities too old for their layers, and re-seeds the bottom layer periodically,
+// before the variation of the generation.
+func (m *GA) prepareALPS() {
+	a, p, n := m.alps, &m.pop, m.n
+	if len(a.ages) != n {
+		a.ages, a.next = make([]int, n), make([]int, n)
+	}
+	var fresh []int
+	for l := a.layers - 2; l >= 0; l-- {
+		lo, hi := a.bounds(l, n)
+		ulo, uhi := a.bounds(l+1, n)
+		for i := lo; i < hi; i++ {
+			if a.ages[i] <= a.limit(l) {
+				continue
+			}
+			if j := worst(p.fitnesses[ulo:uhi]) + ulo; uhi > ulo && p.fitnesses[i] > p.fitnesses[j] {
+				p.entities[j], p.fitnesses[j], a.ages[j] = p.entities[i], p.fitnesses[i], a.ages[i]
+			}
+			fresh = append(fresh, i)
+		}
+	}
+	if m.gen > 0 && m.gen%a.gap == 0 {
+		lo, hi := a.bounds(0, n)
+		for i := lo; i < hi; i++ {
+			fresh = append(fresh, i)
+		}
+	}
+	if len(fresh) == 0 {
+		return
+	}
+	for _, i := range fresh {
+		p.entities[i], a.ages[i] = m.g(), 0
+	}
+	es, fs := make([]Entity, len(fresh)), make([]float64, len(fresh))
+	for j, i := range fresh {
+		es[j] = p.entities[i]
+	}
+	p.evaluateInto(es, fs)
+	for j, i := range fresh {
+		p.fitnesses[i] = fs[j]
+	}
+	m.pop.summarize()
+	if e, f := m.pop.Best(); m.fitter(e, f) {
+		m.fitness, m.elite = f, e
+	}
+	m.reweigh()
+}
+
+// alpsBest returns the fittest entity of the layer of the slot i by WithALPS, if i is the first slot of its layer.
+func (m *GA) alpsBest(i int) (Entity, bool) {
+	if m.alps == nil {
+		return nil, false
+	}
+	return m.alps.best(&m.pop, i)
+}
+
+// best returns the fittest entity of the layer of the slot i, if i is the first slot of its layer.
+func (a *alps) best(p *Population, i int) (Entity, bool) {
+	n := len(p.entities)
+	l := a.layer(i, n)
+	lo, hi := a.bounds(l, n)
+	if i != lo {
+		return nil, false
+	}
+	j, _ := max(p.fitnesses[lo:hi])
+	a.next[i] = a.ages[lo+j] + 1
+	return p.entities[lo+j], true
+}
+
+// select2 selects two parents for the slot i from its layer and the one below, by the selection weights.
+func (a *alps) select2(p *Population, i int, u func() float64) (Entity, Entity, float64) {
+	n := len(p.entities)
+	l := a.layer(i, n)
+	lo, hi := a.bounds(l, n)
+	if l > 0 {
+		lo, _ = a.bounds(l-1, n)
+	}
+	sum := 0.0
+	for j := lo; j < hi; j++ {
+		sum += p.weights[j]
+	}
+	pick := func() int {
+		r := u() * sum
+		for j := lo; j < hi; j++ {
+			if r -= p.weights[j]; r < 0 {
+				return j
+			}
+		}
+		return hi - 1
+	}
+	x, y := pick(), pick()
+	age := a.ages[x]
+	if a.ages[y] > age {
+		age = a.ages[y]
+	}
+	if i < len(a.next) {
+		a.next[i] = age + 1
+	}
+	w := 0.5
+	if t := p.weights[x] + p.weights[y]; t > 0 {
+		w = p.weights[x] / t
+	}
+	return p.entities[x], p.entities[y], w
+}
+
+// worst returns the index of the least fitness of fs.
+func worst(fs []float64) int {
+	k := 0
+	for i, f := range fs {
+		if f < fs[k] {
+			k = i
+		}
+	}
+	return k
+}
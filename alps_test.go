@@ -0,0 +1,37 @@
+package ga_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestALPS(t *testing.T) {
+	const layers, gap = 4, 3
+	m := ga.New(40, func() ga.Entity {
+		return Walk(20*rand.Float64() - 10)
+	}, ga.WithALPS(layers, gap))
+	if m.Ages() != nil {
+		t.Fatal("ages before the first generation:", m.Ages())
+	}
+	for g := 1; g <= 30; g++ {
+		m.Next()
+		ages := m.Ages()
+		if len(ages) != 40 {
+			t.Fatal("ages:", ages)
+		}
+		for i, a := range ages {
+			l := i * layers / 40
+			if a < 0 || l < layers-1 && a > gap*(l+1)*(l+1)+1 || a > g {
+				t.Fatal("age:", g, i, a)
+			}
+		}
+	}
+	if f := m.Fitness(); f < -1e-3 {
+		t.Fatal("fitness:", f)
+	}
+	if ages := m.Ages(); ages[39] < gap {
+		t.Fatal("top layer is young:", ages)
+	}
+}
@@ -0,0 +1,75 @@
+package ga
+
+import "math"
+
+// WithAnnealing refines the elite by simulated annealing whenever it has not improved for k generations,
+// before the evolution resumes, instead of only stagnating.
+// The annealing makes steps moves by Mutate from the elite, accepting a less fit neighbor with the probability exp(Δ/T),
+// where the temperature T starts at t0 times the standard deviation of the initial population, and is multiplied by cooling every move.
+// The best entity found replaces the least fit entity of the population, and becomes the elite if it is fitter.
+// The moves are sequential, and each is an evaluation.
+func WithAnnealing(k, steps int, t0, cooling float64) Option {
+	return func(m *GA) {
+		m.anneal = &annealing{k: k, steps: steps, t0: t0, cooling: cooling}
+	}
+}
+
+// annealing is the state of WithAnnealing.
+type annealing struct {
+	k       int
+	steps   int
+	t0      float64
+	cooling float64
+	since   int
+}
+
+// stagnate counts the generations the elite has not improved since the fitness prev,
+// and anneals the elite when they reach k.
+func (m *GA) stagnate(prev float64) {
+	a := m.anneal
+	if m.fitness > prev {
+		a.since = 0
+		return
+	}
+	if a.since++; a.since < a.k || m.elite == nil {
+		return
+	}
+	a.since = 0
+	x, fx := m.elite, m.fitness
+	best, fbest, found := x, fx, false
+	t := a.t0 * m.base
+	for i := 0; i < a.steps; i++ {
+		y := m.neighbor(x)
+		fy := m.eval(y)
+		if fy >= fx || t > 0 && m.rnd.Float64() < math.Exp((fy-fx)/t) {
+			x, fx = y, fy
+			if fx > fbest {
+				best, fbest, found = x, fx, true
+			}
+		}
+		t *= a.cooling
+	}
+	if !found || m.pop.iworst < 0 {
+		return
+	}
+	m.dropAt([]int{m.pop.iworst})
+	for _, f := range m.pop.replace([]int{m.pop.iworst}, []Entity{best}) {
+		if m.fitter(best, f) {
+			m.fitness, m.elite = f, best
+		}
+	}
+	m.reweigh()
+}
+
+// neighbor returns a mutated e, or e if the mutation fails.
+func (m *GA) neighbor(e Entity) Entity {
+	if c, ok := e.(CheckedEntity); ok {
+		z, err := c.TryMutate()
+		if err != nil {
+			m.fail("mutate", err)
+			return e
+		}
+		return z
+	}
+	return e.Mutate()
+}
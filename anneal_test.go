@@ -0,0 +1,33 @@
+package ga_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestAnnealing(t *testing.T) {
+	g := func() ga.Entity {
+		return Walk(5*rand.Float64() - 10)
+	}
+	m := ga.New(20, g, ga.WithFixedMutationRate(0))
+	if _, f, _ := m.Evolve(10, 200); f > -30 {
+		t.Fatal("without annealing:", f)
+	}
+
+	m = ga.New(20, g, ga.WithFixedMutationRate(0), ga.WithAnnealing(5, 100, 1, 0.95))
+	e, f, _ := m.Evolve(10, 200)
+	if f < -0.1 || e.Fitness() != f {
+		t.Fatal("with annealing:", e, f)
+	}
+}
+
+func TestAnnealingUncomparable(t *testing.T) {
+	m := ga.New(20, func() ga.Entity {
+		return Vec{10*rand.Float64() - 5, 10*rand.Float64() - 5}
+	}, ga.WithAnnealing(2, 20, 1, 0.9))
+	if _, f, _ := m.Evolve(10, 100); f != m.Elite().Fitness() {
+		t.Fatal("fitness:", f, m.Elite())
+	}
+}
@@ -0,0 +1,161 @@
+package ga
+
+import (
+	"math/rand/v2"
+	"sort"
+)
+
+// Topology is the migration topology of an archipelago.
+type Topology int
+
+const (
+	// Ring migrates from each island to the next one.
+	Ring Topology = iota
+	// FullyConnected migrates from each island to all the others.
+	FullyConnected
+	// RandomTopology migrates from each island to another one chosen randomly every migration.
+	RandomTopology
+)
+
+// Archipelago is the island model, which runs several GA models independently,
+// and migrates the fittest entities between them periodically.
+type Archipelago struct {
+	islands  []*GA
+	topology Topology
+	interval int
+	k        int
+	gen      int
+	rnd      *rand.Rand
+}
+
+// NewArchipelago creates an archipelago of the islands, which must not be empty,
+// which migrates the k fittest entities of each island every interval generations along the topology t.
+// The migrants replace the least fit entities of the destination, without adapting its mutation probability.
+// The entities of all the islands must be compatible, which is the responsibility of the caller.
+// The random source of RandomTopology is forked from the one of the first island, so it is reproducible by its seed.
+func NewArchipelago(islands []*GA, t Topology, interval, k int) *Archipelago {
+	if len(islands) == 0 {
+		panic("ga: archipelago without islands")
+	}
+	if interval < 1 {
+		interval = 1
+	}
+	m := islands[0]
+	return &Archipelago{
+		islands:  islands,
+		topology: t,
+		interval: interval,
+		k:        k,
+		rnd:      rand.New(rand.NewPCG(m.rnd.Uint64(), m.rnd.Uint64())),
+	}
+}
+
+// Islands returns the GA models of the archipelago.
+func (a *Archipelago) Islands() []*GA {
+	return a.islands
+}
+
+// Elite returns the fittest elite of all the islands.
+func (a *Archipelago) Elite() (Entity, float64) {
+	var e Entity
+	f := 0.0
+	for i, m := range a.islands {
+		if i == 0 || f < m.fitness {
+			e, f = m.elite, m.fitness
+		}
+	}
+	return e, a.islands[0].objective(f)
+}
+
+// Next produces the next generation of all the islands in parallel, by the workers of the first island,
+// then migrates if it is time to, and returns the fittest elite.
+func (a *Archipelago) Next() (Entity, float64) {
+	a.islands[0].pop.parallel(len(a.islands), func(c, i int) {
+		a.islands[i].Next()
+	})
+	if a.gen++; a.gen%a.interval == 0 {
+		a.Migrate()
+	}
+	return a.Elite()
+}
+
+// Evolve runs the archipelago like GA.Evolve, until the fittest elite has not improved for k generations,
+// or max generations have been produced.
+func (a *Archipelago) Evolve(k int, max int) (Entity, float64, bool) {
+	i, j := 0, 0
+	_, fitness := a.Elite()
+	for ; i < k && j < max; i, j = i+1, j+1 {
+		if _, f := a.Next(); fitness < f {
+			i, fitness = 0, f
+		}
+	}
+	e, _ := a.Elite()
+	return e, fitness, i >= k
+}
+
+// Migrate migrates the k fittest entities of each island along the topology immediately.
+// The migrants are chosen from all the islands before any of them is replaced,
+// and the ones implementing MutableEntity are copied by CloneInto, since each island reuses its entities in place.
+func (a *Archipelago) Migrate() {
+	n := len(a.islands)
+	if n < 2 || a.k <= 0 {
+		return
+	}
+	migrants := make([][]Entity, n)
+	for i, m := range a.islands {
+		idx := order(m.pop.fitnesses)
+		for j := len(idx) - 1; j >= 0 && len(idx)-j <= a.k; j-- {
+			migrants[i] = append(migrants[i], m.pop.entities[idx[j]])
+		}
+	}
+	incoming := make([][]Entity, n)
+	for i := range a.islands {
+		for _, j := range a.destinations(i) {
+			for _, e := range migrants[i] {
+				incoming[j] = append(incoming[j], copyOf(e))
+			}
+		}
+	}
+	for i, m := range a.islands {
+		es := incoming[i]
+		if len(es) > m.n {
+			es = es[:m.n]
+		}
+		if len(es) > 0 {
+			m.admit(order(m.pop.fitnesses)[:len(es)], es)
+			m.reweigh()
+			m.expose()
+		}
+	}
+}
+
+// destinations returns the islands which the island i migrates to.
+func (a *Archipelago) destinations(i int) []int {
+	n := len(a.islands)
+	switch a.topology {
+	case FullyConnected:
+		ds := make([]int, 0, n-1)
+		for j := 0; j < n; j++ {
+			if j != i {
+				ds = append(ds, j)
+			}
+		}
+		return ds
+	case RandomTopology:
+		return []int{(i + 1 + a.rnd.IntN(n-1)) % n}
+	default:
+		return []int{(i + 1) % n}
+	}
+}
+
+// order returns the indices of fs in ascending order of fitness.
+func order(fs []float64) []int {
+	idx := make([]int, len(fs))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		return fs[idx[i]] < fs[idx[j]]
+	})
+	return idx
+}
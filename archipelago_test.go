@@ -0,0 +1,117 @@
+package ga_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestArchipelago(t *testing.T) {
+	islands := make([]*ga.GA, 4)
+	for i := range islands {
+		islands[i] = ga.New(30, MIN{}.Mutate)
+	}
+	a := ga.NewArchipelago(islands, ga.Ring, 5, 2)
+	e, f, ok := a.Evolve(30, 300)
+	if !ok {
+		t.Fatal("not converged")
+	}
+	if f < -1e-2 || e.Fitness() != f {
+		t.Fatal("fitness:", f)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	want := map[ga.Topology]int{ga.Ring: 6, ga.FullyConnected: 12, ga.RandomTopology: 6}
+	for topology, n := range want {
+		natives := []ga.Entity{Walk(1), Walk(0), Walk(-10)}
+		islands := make([]*ga.GA, len(natives))
+		for i, e := range natives {
+			e := e
+			islands[i] = ga.New(10, func() ga.Entity { return e })
+		}
+		ga.NewArchipelago(islands, topology, 1, 2).Migrate()
+		foreign := 0
+		for i, m := range islands {
+			for _, e := range m.EvolveTo(0) {
+				if e != natives[i] {
+					foreign++
+				}
+			}
+		}
+		if foreign != n {
+			t.Error(topology, "foreign:", foreign)
+		}
+		if topology != ga.RandomTopology && islands[1].Fitness() != 0 {
+			t.Error(topology, "elite:", islands[1].Fitness())
+		}
+	}
+}
+
+func TestMigrateMutable(t *testing.T) {
+	islands := make([]*ga.GA, 3)
+	for i := range islands {
+		islands[i] = ga.New(20, func() ga.Entity {
+			b := newBuffer(nil)
+			for i := range b.X {
+				b.X[i] = rand.Float64()
+			}
+			return b
+		}, ga.WithFixedMutationRate(1))
+	}
+	a := ga.NewArchipelago(islands, ga.Ring, 1, 3)
+	for i := 0; i < 10; i++ {
+		a.Next()
+		for j, m := range islands {
+			if f := m.Elite().Fitness(); f != m.Fitness() {
+				t.Fatal("elite overwritten:", i, j, f, m.Fitness())
+			}
+		}
+	}
+}
+
+func TestMigrateAdaptation(t *testing.T) {
+	islands := []*ga.GA{ga.New(10, MIN{}.Mutate), ga.New(10, MIN{}.Mutate)}
+	pms := []float64{islands[0].MutationRate(), islands[1].MutationRate()}
+	ga.NewArchipelago(islands, ga.Ring, 1, 2).Migrate()
+	for i, m := range islands {
+		if m.MutationRate() != pms[i] {
+			t.Fatal("adapted:", i, pms[i], m.MutationRate())
+		}
+	}
+}
+
+func TestRandomTopologySeed(t *testing.T) {
+	run := func() []ga.Entity {
+		islands := make([]*ga.GA, 4)
+		for i := range islands {
+			e := Walk(i)
+			islands[i] = ga.New(5, func() ga.Entity { return e }, ga.WithSeed(int64(i)))
+		}
+		a := ga.NewArchipelago(islands, ga.RandomTopology, 1, 1)
+		var es []ga.Entity
+		for i := 0; i < 5; i++ {
+			a.Migrate()
+			for _, m := range islands {
+				es = append(es, m.Population()...)
+			}
+		}
+		return es
+	}
+	x, y := run(), run()
+	for i := range x {
+		if x[i] != y[i] {
+			t.Fatal("not reproducible:", i, x[i], y[i])
+		}
+	}
+}
+
+func TestArchipelagoEmpty(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("no panic")
+		}
+	}()
+	ga.NewArchipelago(nil, ga.Ring, 1, 1)
+}
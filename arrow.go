@@ -0,0 +1,738 @@
+package ga
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// ArrowWriter writes the snapshots of the populations of a GA model as an Arrow IPC stream,
+// one record batch per snapshot, which can be read at scale by pyarrow, e.g. to convert them to Parquet,
+// or by ReadArrow. The columns are:
+//
+//	generation: int64, the generation of the snapshot
+//	index: int64, the index of the entity in the population
+//	id: int64, the id of the entity by WithLineage, or null
+//	parents: list<int64>, the ids of the parents of the entity by WithLineage, or null
+//	fitness: double, the fitness of the entity
+//	rank: int64, the rank of the fitness in the population, 1 for the fittest
+//	genome: binary, the entity encoded by the codec set by WithCodec, or by encoding.BinaryMarshaler
+//
+// The codec name is kept in the metadata "ga.codec" of the schema, so ReadArrow decodes by the same codec.
+// The ids are those written by WriteLineage.
+type ArrowWriter struct {
+	w      io.Writer
+	schema bool
+	err    error
+}
+
+// NewArrowWriter creates an Arrow IPC stream writer to w.
+// The schema is written with the first snapshot, and Close must be called to end the stream.
+func NewArrowWriter(w io.Writer) *ArrowWriter {
+	return &ArrowWriter{w: w}
+}
+
+// Write writes a snapshot of the current population of m as a record batch, e.g. in an observer by OnGeneration.
+func (a *ArrowWriter) Write(m *GA) error {
+	if a.err != nil {
+		return a.err
+	}
+	p := &m.pop
+	n := len(p.entities)
+	genomes := make([][]byte, n)
+	for i, e := range p.entities {
+		if genomes[i], a.err = m.marshal(e); a.err != nil {
+			return a.err
+		}
+	}
+	if !a.schema {
+		a.message(1, arrowSchema(m.cname), nil)
+		a.schema = true
+	}
+	ids, parents := m.lineageIDs()
+	ranks := make([]int64, n)
+	for r, i := range order(p.fitnesses) {
+		ranks[i] = int64(n - r)
+	}
+	var c arrowColumns
+	gens, idx, fs := make([]int64, n), make([]int64, n), make([]float64, n)
+	for i := range gens {
+		gens[i], idx[i], fs[i] = int64(m.gen), int64(i), m.objective(p.fitnesses[i])
+	}
+	c.int64s(gens, nil)
+	c.int64s(idx, nil)
+	c.int64s(ids, func(i int) bool { return parents == nil || parents[i] == nil })
+	if a.err = c.lists(n, parents); a.err != nil {
+		return a.err
+	}
+	c.float64s(fs)
+	c.int64s(ranks, nil)
+	if a.err = c.binaries(genomes); a.err != nil {
+		return a.err
+	}
+	a.message(3, c.batch(n), c.body)
+	return a.err
+}
+
+// Close writes the end of the stream.
+func (a *ArrowWriter) Close() error {
+	if a.err == nil {
+		_, a.err = a.w.Write([]byte{0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0})
+	}
+	return a.err
+}
+
+// message writes an encapsulated message with the header of the type typ, and the body.
+func (a *ArrowWriter) message(typ uint64, header fbNode, body []byte) {
+	if a.err != nil {
+		return
+	}
+	meta := fbBuild(fbTable{
+		fbScalar(2, 4), // MetadataVersion.V5
+		fbScalar(1, typ),
+		fbRef(header),
+		fbScalar(8, uint64(len(body))),
+	})
+	for len(meta)%8 != 0 {
+		meta = append(meta, 0)
+	}
+	var h [8]byte
+	binary.LittleEndian.PutUint32(h[:4], 0xffffffff)
+	binary.LittleEndian.PutUint32(h[4:], uint32(len(meta)))
+	for _, b := range [][]byte{h[:], meta, body} {
+		if _, a.err = a.w.Write(b); a.err != nil {
+			return
+		}
+	}
+}
+
+// lineageIDs returns the ids and the parent ids of the population by WithLineage, or nil without it.
+// The parent ids are nil for the entities without the records.
+func (m *GA) lineageIDs() ([]int64, [][]int64) {
+	if m.lineage == nil {
+		return make([]int64, len(m.pop.entities)), nil
+	}
+	l := m.lineage
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	ids, parents := make([]int64, len(m.pop.entities)), make([][]int64, len(m.pop.entities))
+	for i, e := range m.pop.entities {
+		if !comparable(e) {
+			continue
+		}
+		if o := l.origins[e]; o != nil {
+			ids[i], parents[i] = int64(o.id), []int64{}
+			for _, id := range o.ids() {
+				parents[i] = append(parents[i], int64(id))
+			}
+		}
+	}
+	return ids, parents
+}
+
+// The types of the Arrow columns.
+const (
+	arrowInt    = 2
+	arrowFloat  = 3
+	arrowBinary = 4
+	arrowList   = 12
+)
+
+var arrowFields = []struct {
+	name string
+	typ  uint64
+}{
+	{"generation", arrowInt},
+	{"index", arrowInt},
+	{"id", arrowInt},
+	{"parents", arrowList},
+	{"fitness", arrowFloat},
+	{"rank", arrowInt},
+	{"genome", arrowBinary},
+}
+
+// arrowSchema returns the schema of the snapshots, with the codec name in the metadata.
+func arrowSchema(codec string) fbNode {
+	fields := make(fbVector, len(arrowFields))
+	for i, f := range arrowFields {
+		fields[i] = arrowField(f.name, f.typ)
+	}
+	return fbTable{
+		fbScalar(2, 0), // Endianness.Little
+		fbRef(fields),
+		fbRef(fbVector{fbTable{fbRef(fbString("ga.codec")), fbRef(fbString(codec))}}),
+	}
+}
+
+// arrowField returns the field of the name and the type typ, nullable.
+func arrowField(name string, typ uint64) fbNode {
+	var t fbTable
+	children := fbVector{}
+	switch typ {
+	case arrowInt:
+		t = fbTable{fbScalar(4, 64), fbScalar(1, 1)}
+	case arrowFloat:
+		t = fbTable{fbScalar(2, 2)} // Precision.DOUBLE
+	case arrowList:
+		children = fbVector{arrowField("item", arrowInt)}
+	}
+	return fbTable{
+		fbRef(fbString(name)),
+		fbScalar(1, 1),
+		fbScalar(1, typ),
+		fbRef(t),
+		fbSlot{},
+		fbRef(children),
+	}
+}
+
+// arrowColumns accumulates the field nodes, the buffers and the body of a record batch.
+type arrowColumns struct {
+	nodes   []byte
+	buffers []byte
+	body    []byte
+}
+
+func (c *arrowColumns) node(length, nulls int) {
+	c.nodes = binary.LittleEndian.AppendUint64(c.nodes, uint64(length))
+	c.nodes = binary.LittleEndian.AppendUint64(c.nodes, uint64(nulls))
+}
+
+// buffer appends b to the body, padded to 8 bytes.
+func (c *arrowColumns) buffer(b []byte) {
+	c.buffers = binary.LittleEndian.AppendUint64(c.buffers, uint64(len(c.body)))
+	c.buffers = binary.LittleEndian.AppendUint64(c.buffers, uint64(len(b)))
+	c.body = append(c.body, b...)
+	for len(c.body)%8 != 0 {
+		c.body = append(c.body, 0)
+	}
+}
+
+// validity appends the validity bitmap of n values, where the value i is null if null(i).
+func (c *arrowColumns) validity(n int, null func(i int) bool) {
+	nulls := 0
+	bits := make([]byte, (n+7)/8)
+	for i := 0; i < n; i++ {
+		if null(i) {
+			nulls++
+		} else {
+			bits[i/8] |= 1 << (i % 8)
+		}
+	}
+	c.node(n, nulls)
+	if nulls == 0 {
+		bits = nil
+	}
+	c.buffer(bits)
+}
+
+// int64s appends a column of int64s, where the value i is null if null(i), or none is null if null is nil.
+func (c *arrowColumns) int64s(xs []int64, null func(i int) bool) {
+	if null == nil {
+		null = func(int) bool { return false }
+	}
+	c.validity(len(xs), null)
+	var b []byte
+	for _, x := range xs {
+		b = binary.LittleEndian.AppendUint64(b, uint64(x))
+	}
+	c.buffer(b)
+}
+
+func (c *arrowColumns) float64s(xs []float64) {
+	c.validity(len(xs), func(int) bool { return false })
+	var b []byte
+	for _, x := range xs {
+		b = binary.LittleEndian.AppendUint64(b, math.Float64bits(x))
+	}
+	c.buffer(b)
+}
+
+// lists appends a column of n lists of int64s, where the nil lists are null.
+// It fails if the items exceed the 32-bit offsets of the list type.
+func (c *arrowColumns) lists(n int, xss [][]int64) error {
+	var offsets []byte
+	var items []int64
+	for i := 0; i < n; i++ {
+		offsets = binary.LittleEndian.AppendUint32(offsets, uint32(len(items)))
+		if xss != nil {
+			items = append(items, xss[i]...)
+		}
+		if len(items) > math.MaxInt32 {
+			return fmt.Errorf("ga: arrow list column of more than %d items", math.MaxInt32)
+		}
+	}
+	c.validity(n, func(i int) bool { return xss == nil || xss[i] == nil })
+	c.buffer(binary.LittleEndian.AppendUint32(offsets, uint32(len(items))))
+	c.int64s(items, nil)
+	return nil
+}
+
+// binaries appends a column of binaries.
+// It fails if the data exceed the 32-bit offsets of the binary type, e.g. the genomes of a huge population.
+func (c *arrowColumns) binaries(bs [][]byte) error {
+	var offsets, data []byte
+	for _, b := range bs {
+		offsets = binary.LittleEndian.AppendUint32(offsets, uint32(len(data)))
+		if len(b) > math.MaxInt32-len(data) {
+			return fmt.Errorf("ga: arrow binary column of more than %d bytes", math.MaxInt32)
+		}
+		data = append(data, b...)
+	}
+	c.validity(len(bs), func(int) bool { return false })
+	c.buffer(binary.LittleEndian.AppendUint32(offsets, uint32(len(data))))
+	c.buffer(data)
+	return nil
+}
+
+// batch returns the record batch of n rows.
+func (c *arrowColumns) batch(n int) fbNode {
+	return fbTable{
+		fbScalar(8, uint64(n)),
+		fbRef(fbStructs(c.nodes)),
+		fbRef(fbStructs(c.buffers)),
+	}
+}
+
+// Snapshot is a snapshot of a population read by ReadArrow.
+type Snapshot struct {
+	// Generation is the generation of the snapshot.
+	Generation int
+	// Entities are the entities of the population.
+	Entities []Entity
+	// Fitnesses are the fitnesses of the entities.
+	Fitnesses []float64
+}
+
+// ReadArrow reads the snapshots written by ArrowWriter from the Arrow IPC stream r,
+// decoding the entities by decode, or by the codec in the metadata of the schema if decode is nil.
+// Only the uncompressed streams of the same columns are supported, e.g. as filtered and rewritten by pyarrow.
+func ReadArrow(r io.Reader, decode func([]byte) Entity) (ss []Snapshot, err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			e, ok := v.(error)
+			if !ok {
+				panic(v)
+			}
+			ss, err = nil, e
+		}
+	}()
+	var fields []arrowColumn
+	for {
+		meta, body, err := readArrowMessage(r)
+		if err != nil {
+			return nil, err
+		}
+		if meta == nil {
+			return ss, nil
+		}
+		msg := fbRoot(meta)
+		switch msg.uint(1, 1) {
+		case 1:
+			codec := ""
+			if fields, codec, err = readArrowSchema(msg.table(2)); err != nil {
+				return nil, err
+			}
+			if decode == nil {
+				c, err := lookupCodec(codec)
+				if err != nil {
+					return nil, err
+				}
+				decode = func(b []byte) Entity {
+					e, err := c.Decode(b)
+					if err != nil {
+						panic(err)
+					}
+					return e
+				}
+			}
+		case 3:
+			if fields == nil {
+				return nil, errors.New("ga: arrow record batch before schema")
+			}
+			s, err := readArrowBatch(msg.table(2), body, fields, decode)
+			if err != nil {
+				return nil, err
+			}
+			ss = append(ss, s)
+		default:
+			return nil, fmt.Errorf("ga: unsupported arrow message %d", msg.uint(1, 1))
+		}
+	}
+}
+
+// readArrowMessage reads an encapsulated message, and returns nil metadata at the end of the stream.
+func readArrowMessage(r io.Reader) ([]byte, []byte, error) {
+	var h [4]byte
+	if _, err := io.ReadFull(r, h[:]); err == io.EOF {
+		return nil, nil, nil
+	} else if err != nil {
+		return nil, nil, err
+	}
+	n := binary.LittleEndian.Uint32(h[:])
+	if n == 0xffffffff {
+		if _, err := io.ReadFull(r, h[:]); err != nil {
+			return nil, nil, err
+		}
+		n = binary.LittleEndian.Uint32(h[:])
+	}
+	if n == 0 {
+		return nil, nil, nil
+	}
+	meta, err := readArrowBytes(r, uint64(n))
+	if err != nil {
+		return nil, nil, err
+	}
+	body, err := readArrowBytes(r, fbRoot(meta).uint(3, 8))
+	if err != nil {
+		return nil, nil, err
+	}
+	return meta, body, nil
+}
+
+// readArrowBytes reads n bytes from r, growing the buffer by the bytes actually read instead of allocating n upfront,
+// so a corrupted length fails at the end of r rather than exhausting the memory.
+func readArrowBytes(r io.Reader, n uint64) ([]byte, error) {
+	if n > math.MaxInt64 {
+		return nil, fmt.Errorf("ga: arrow message of %d bytes", n)
+	}
+	var b bytes.Buffer
+	if _, err := io.CopyN(&b, r, int64(n)); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// arrowColumn is a field of the schema, where item is the type of the items of a list.
+type arrowColumn struct {
+	name      string
+	typ, item uint64
+}
+
+func readArrowSchema(s fbReader) ([]arrowColumn, string, error) {
+	var cs []arrowColumn
+	for _, f := range s.tables(1) {
+		c := arrowColumn{name: f.string(0), typ: f.uint(2, 1)}
+		switch c.typ {
+		case arrowInt, arrowFloat, arrowBinary:
+		case arrowList:
+			if ch := f.tables(5); len(ch) == 1 {
+				c.item = ch[0].uint(2, 1)
+			}
+			if c.item != arrowInt {
+				return nil, "", fmt.Errorf("ga: unsupported arrow list of %q", c.name)
+			}
+		default:
+			return nil, "", fmt.Errorf("ga: unsupported arrow type of %q", c.name)
+		}
+		cs = append(cs, c)
+	}
+	codec := ""
+	for _, kv := range s.tables(2) {
+		if kv.string(0) == "ga.codec" {
+			codec = kv.string(1)
+		}
+	}
+	return cs, codec, nil
+}
+
+// readArrowBatch reads the snapshot of the record batch rb. The corrupted batches panic with an error.
+func readArrowBatch(rb fbReader, body []byte, fields []arrowColumn, decode func([]byte) Entity) (s Snapshot, err error) {
+	if rb.has(3) {
+		return s, errors.New("ga: compressed arrow record batch")
+	}
+	length := rb.uint(0, 8)
+	if length > uint64(len(body))/8 {
+		return s, errors.New("ga: corrupted arrow record batch")
+	}
+	n := int(length)
+	buffers := rb.structs(2)
+	next := func() []byte {
+		if len(buffers) < 16 {
+			panic(errors.New("ga: corrupted arrow record batch"))
+		}
+		off, size := binary.LittleEndian.Uint64(buffers), binary.LittleEndian.Uint64(buffers[8:])
+		buffers = buffers[16:]
+		if off > uint64(len(body)) || size > uint64(len(body))-off {
+			panic(errors.New("ga: corrupted arrow record batch"))
+		}
+		return body[off : off+size]
+	}
+	s.Fitnesses = make([]float64, n)
+	s.Entities = make([]Entity, n)
+	for _, f := range fields {
+		next()
+		switch f.typ {
+		case arrowInt, arrowFloat:
+			data := next()
+			if len(data) < 8*n {
+				panic(errors.New("ga: corrupted arrow record batch"))
+			}
+			if f.name == "generation" && n > 0 {
+				s.Generation = int(binary.LittleEndian.Uint64(data))
+			} else if f.name == "fitness" && f.typ == arrowFloat {
+				for i := range s.Fitnesses {
+					s.Fitnesses[i] = math.Float64frombits(binary.LittleEndian.Uint64(data[8*i:]))
+				}
+			}
+		case arrowList:
+			next()
+			next()
+			next()
+		case arrowBinary:
+			offsets, data := next(), next()
+			if f.name != "genome" {
+				continue
+			}
+			if len(offsets) < 4*(n+1) {
+				panic(errors.New("ga: corrupted arrow record batch"))
+			}
+			for i := range s.Entities {
+				lo, hi := binary.LittleEndian.Uint32(offsets[4*i:]), binary.LittleEndian.Uint32(offsets[4*i+4:])
+				if lo > hi || int(hi) > len(data) {
+					panic(errors.New("ga: corrupted arrow record batch"))
+				}
+				s.Entities[i] = decode(data[lo:hi])
+			}
+		}
+	}
+	return s, nil
+}
+
+// fbNode is an object of a flatbuffer, which is written after the object referring to it.
+type fbNode interface {
+	write(b []byte) ([]byte, int)
+}
+
+// fbSlot is a field of a table, a scalar of size bytes, or a reference to an object, or absent if both are zero.
+type fbSlot struct {
+	size int
+	v    uint64
+	ref  fbNode
+}
+
+func fbScalar(size int, v uint64) fbSlot {
+	return fbSlot{size: size, v: v}
+}
+
+func fbRef(n fbNode) fbSlot {
+	return fbSlot{size: 4, ref: n}
+}
+
+// fbBuild returns the flatbuffer of the root table t.
+func fbBuild(t fbTable) []byte {
+	b, p := t.write(make([]byte, 4))
+	binary.LittleEndian.PutUint32(b, uint32(p))
+	return b
+}
+
+func fbPad(b []byte, align int) []byte {
+	for len(b)%align != 0 {
+		b = append(b, 0)
+	}
+	return b
+}
+
+type fbTable []fbSlot
+
+func (t fbTable) write(b []byte) ([]byte, int) {
+	offsets := make([]int, len(t))
+	size, align := 4, 4
+	for _, s := range []int{8, 4, 2, 1} {
+		for i, f := range t {
+			if f.size == s {
+				size = (size + s - 1) / s * s
+				offsets[i], size = size, size+s
+				if s > align {
+					align = s
+				}
+			}
+		}
+	}
+	b = fbPad(b, 2)
+	vt := len(b)
+	b = binary.LittleEndian.AppendUint16(b, uint16(4+2*len(t)))
+	b = binary.LittleEndian.AppendUint16(b, uint16(size))
+	for _, o := range offsets {
+		b = binary.LittleEndian.AppendUint16(b, uint16(o))
+	}
+	b = fbPad(b, align)
+	p := len(b)
+	b = append(b, make([]byte, size)...)
+	binary.LittleEndian.PutUint32(b[p:], uint32(p-vt))
+	for i, f := range t {
+		switch {
+		case f.ref != nil:
+		case f.size == 1:
+			b[p+offsets[i]] = byte(f.v)
+		case f.size == 2:
+			binary.LittleEndian.PutUint16(b[p+offsets[i]:], uint16(f.v))
+		case f.size == 4:
+			binary.LittleEndian.PutUint32(b[p+offsets[i]:], uint32(f.v))
+		case f.size == 8:
+			binary.LittleEndian.PutUint64(b[p+offsets[i]:], f.v)
+		}
+	}
+	for i, f := range t {
+		if f.ref != nil {
+			var q int
+			b, q = f.ref.write(b)
+			binary.LittleEndian.PutUint32(b[p+offsets[i]:], uint32(q-p-offsets[i]))
+		}
+	}
+	return b, p
+}
+
+type fbString string
+
+func (s fbString) write(b []byte) ([]byte, int) {
+	b = fbPad(b, 4)
+	p := len(b)
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(s)))
+	return append(append(b, s...), 0), p
+}
+
+// fbVector is a vector of references to objects.
+type fbVector []fbNode
+
+func (v fbVector) write(b []byte) ([]byte, int) {
+	b = fbPad(b, 4)
+	p := len(b)
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(v)))
+	b = append(b, make([]byte, 4*len(v))...)
+	for i, n := range v {
+		var q int
+		b, q = n.write(b)
+		binary.LittleEndian.PutUint32(b[p+4+4*i:], uint32(q-p-4-4*i))
+	}
+	return b, p
+}
+
+// fbStructs is a vector of the structs of two longs, e.g. FieldNode and Buffer.
+type fbStructs []byte
+
+func (v fbStructs) write(b []byte) ([]byte, int) {
+	for len(b)%8 != 4 {
+		b = append(b, 0)
+	}
+	p := len(b)
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(v)/16))
+	return append(b, v...), p
+}
+
+// fbReader reads a table of a flatbuffer. The reads out of the buffer panic with an error.
+type fbReader struct {
+	b []byte
+	p int
+}
+
+func fbRoot(b []byte) fbReader {
+	return fbReader{b, int(fbUint32(b, 0))}
+}
+
+func fbUint32(b []byte, p int) uint32 {
+	if p < 0 || p+4 > len(b) {
+		panic(errors.New("ga: corrupted flatbuffer"))
+	}
+	return binary.LittleEndian.Uint32(b[p:])
+}
+
+// field returns the position of the field of the slot, or 0 if it is absent.
+func (t fbReader) field(slot int) int {
+	vt := t.p - int(int32(fbUint32(t.b, t.p)))
+	if vt < 0 || vt+4 > len(t.b) {
+		panic(errors.New("ga: corrupted flatbuffer"))
+	}
+	if 4+2*slot+2 > int(binary.LittleEndian.Uint16(t.b[vt:])) {
+		return 0
+	}
+	if o := int(binary.LittleEndian.Uint16(t.b[vt+4+2*slot:])); o != 0 {
+		return t.p + o
+	}
+	return 0
+}
+
+func (t fbReader) has(slot int) bool {
+	return t.field(slot) != 0
+}
+
+// uint returns the unsigned scalar of size bytes of the slot, or 0 if it is absent.
+func (t fbReader) uint(slot, size int) uint64 {
+	p := t.field(slot)
+	if p == 0 {
+		return 0
+	}
+	if p+size > len(t.b) {
+		panic(errors.New("ga: corrupted flatbuffer"))
+	}
+	switch size {
+	case 1:
+		return uint64(t.b[p])
+	case 2:
+		return uint64(binary.LittleEndian.Uint16(t.b[p:]))
+	case 4:
+		return uint64(binary.LittleEndian.Uint32(t.b[p:]))
+	}
+	return binary.LittleEndian.Uint64(t.b[p:])
+}
+
+// ref returns the position of the object referred by the slot, or 0 if it is absent.
+func (t fbReader) ref(slot int) int {
+	p := t.field(slot)
+	if p == 0 {
+		return 0
+	}
+	return p + int(fbUint32(t.b, p))
+}
+
+func (t fbReader) table(slot int) fbReader {
+	p := t.ref(slot)
+	if p == 0 {
+		panic(errors.New("ga: missing flatbuffer table"))
+	}
+	return fbReader{t.b, p}
+}
+
+func (t fbReader) string(slot int) string {
+	p := t.ref(slot)
+	if p == 0 {
+		return ""
+	}
+	n := int(fbUint32(t.b, p))
+	if p+4+n > len(t.b) {
+		panic(errors.New("ga: corrupted flatbuffer"))
+	}
+	return string(t.b[p+4 : p+4+n])
+}
+
+func (t fbReader) tables(slot int) []fbReader {
+	p := t.ref(slot)
+	if p == 0 {
+		return nil
+	}
+	ts := make([]fbReader, fbUint32(t.b, p))
+	for i := range ts {
+		q := p + 4 + 4*i
+		ts[i] = fbReader{t.b, q + int(fbUint32(t.b, q))}
+	}
+	return ts
+}
+
+// structs returns the raw bytes of the vector of 16-byte structs of the slot.
+func (t fbReader) structs(slot int) []byte {
+	p := t.ref(slot)
+	if p == 0 {
+		return nil
+	}
+	n := 16 * int(fbUint32(t.b, p))
+	if p+4+n > len(t.b) {
+		panic(errors.New("ga: corrupted flatbuffer"))
+	}
+	return t.b[p+4 : p+4+n]
+}
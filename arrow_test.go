@@ -0,0 +1,74 @@
+package ga_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestArrow(t *testing.T) {
+	g := func() ga.Entity {
+		return Walk(20*rand.Float64() - 10)
+	}
+	m := ga.New(20, g, ga.WithCodec("walk"), ga.WithLineage())
+	var b bytes.Buffer
+	w := ga.NewArrowWriter(&b)
+	var pops [][]ga.Entity
+	for i := 0; i < 3; i++ {
+		m.Next()
+		if err := w.Write(m); err != nil {
+			t.Fatal(err)
+		}
+		pops = append(pops, m.Population())
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	data := b.Bytes()
+	if binary.LittleEndian.Uint32(data) != 0xffffffff || !bytes.HasSuffix(data, []byte{0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0}) {
+		t.Fatal("stream:", data[:8], data[len(data)-8:])
+	}
+
+	ss, err := ga.ReadArrow(bytes.NewReader(data), nil)
+	if err != nil || len(ss) != 3 {
+		t.Fatal("read:", len(ss), err)
+	}
+	for i, s := range ss {
+		if s.Generation != i+1 || len(s.Entities) != 20 {
+			t.Fatal("snapshot:", s.Generation, len(s.Entities))
+		}
+		for j, e := range s.Entities {
+			if e != pops[i][j] || s.Fitnesses[j] != e.Fitness() {
+				t.Fatal("entity:", i, j, e, pops[i][j], s.Fitnesses[j])
+			}
+		}
+	}
+
+	if _, err := ga.ReadArrow(bytes.NewReader(data[:len(data)/2]), nil); err == nil {
+		t.Fatal("truncated stream read")
+	}
+	corrupt := append([]byte(nil), data...)
+	p := 8 + int(binary.LittleEndian.Uint32(data[4:]))
+	meta := corrupt[p+8 : p+8+int(binary.LittleEndian.Uint32(data[p+4:]))]
+	body := p + 8 + len(meta)
+	patched := false
+	for i := 0; i+8 <= len(meta) && !patched; i++ {
+		l := binary.LittleEndian.Uint64(meta[i:])
+		if l > 0 && l < uint64(len(data)-body-4) && binary.LittleEndian.Uint32(data[body+int(l):]) == 0xffffffff {
+			binary.LittleEndian.PutUint64(meta[i:], 1<<62)
+			patched = true
+		}
+	}
+	if _, err := ga.ReadArrow(bytes.NewReader(corrupt), nil); !patched || err == nil {
+		t.Fatal("corrupted body length read")
+	}
+	x := ga.New(10, func() ga.Entity {
+		return &Node{rand.Float64()}
+	})
+	if err := ga.NewArrowWriter(&b).Write(x); err == nil {
+		t.Fatal("entity without codec written")
+	}
+}
@@ -0,0 +1,28 @@
+package ga
+
+// WithAutoRestart restarts the evolution by Reset, up to max times in a run of Evolve, EvolveContext or Generations,
+// whenever the elite has not changed for k generations, instead of stopping the run.
+// The elite is always remembered across the restarts, and if keep, it is also seeded into the new population,
+// replacing its least fit entity. The restarts share the max generations of the run,
+// and the run stops by the stagnation only after the last restart.
+func WithAutoRestart(max int, keep bool) Option {
+	return func(m *GA) {
+		m.restarts = &autorestart{max, keep}
+	}
+}
+
+type autorestart struct {
+	max  int
+	keep bool
+}
+
+// restart regenerates the population, seeded with the elite if keep.
+func (m *GA) restart() {
+	m.Reset(true)
+	if !m.restarts.keep || m.elite == nil || m.pop.iworst < 0 {
+		return
+	}
+	m.dropAt([]int{m.pop.iworst})
+	m.pop.replace([]int{m.pop.iworst}, []Entity{m.elite})
+	m.reweigh()
+}
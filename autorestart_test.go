@@ -0,0 +1,28 @@
+package ga_test
+
+import (
+	"context"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestAutoRestart(t *testing.T) {
+	g := func() ga.Entity {
+		return Walk(rand.Float64()*4 - 2)
+	}
+	for _, keep := range []bool{false, true} {
+		m := ga.New(20, g, ga.WithAutoRestart(3, keep))
+		r := m.Generations(context.Background(), 5, 10000)
+		for r.Next() {
+		}
+		e, f, ok := r.Result()
+		if r.Restarts() != 3 || !ok || r.Generation() >= 10000 {
+			t.Fatal("restarts:", keep, r.Restarts(), ok, r.Generation())
+		}
+		if e != m.Elite() || f != m.Fitness() {
+			t.Fatal("elite:", keep, e, f)
+		}
+	}
+}
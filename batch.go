@@ -0,0 +1,72 @@
+package ga
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// BatchEvaluator evaluates the fitnesses of a whole generation at once,
+// e.g. to pipeline the evaluations on a GPU or a remote service.
+type BatchEvaluator interface {
+	// Evaluate evaluates the fitnesses of es into fs, which have the same length.
+	Evaluate(es []Entity, fs []float64)
+}
+
+// WithBatchEvaluator evaluates the populations by b instead of calling Fitness of each entity.
+// The entities are handed to b in batches, the whole generation or the new entities of an incremental update,
+// and the oracle, the fitness cache and the generation deadline are not applied to them, while the penalty of WithPenalty is.
+// Only one evaluator of the batches can be set, e.g. by WithBatchEvaluator, WithShards or WithWeighted,
+// and the later ones are ignored and reported by Err.
+func WithBatchEvaluator(b BatchEvaluator) Option {
+	return func(m *GA) {
+		m.evaluator("WithBatchEvaluator", func(es []Entity, fs []float64) {
+			atomic.AddInt64(&m.evals, int64(len(es)))
+			b.Evaluate(es, fs)
+			if m.minimize {
+				for i, f := range fs {
+					fs[i] = -f
+				}
+			}
+		})
+	}
+}
+
+// evaluator sets the evaluator f of the batches of the option name, unless one is set already.
+func (m *GA) evaluator(name string, f func(es []Entity, fs []float64)) {
+	if m.batch != nil {
+		m.fail("option", fmt.Errorf("ga: %s conflicts with %s", name, m.batcher))
+		return
+	}
+	m.batch, m.batcher = f, name
+}
+
+// wire sets the evaluation of the batches of the population by the evaluator with the penalty, and the surrogate, if any.
+// The penalty is applied after the batch returns, so the evaluations abandoned by the deadline never read the model.
+func (m *GA) wire() {
+	b, p := m.batch, &m.pop
+	direct := func(es []Entity, fs []float64) {
+		evaluate(es, fs, p.eval, p.ctx, p.deadline, p.run)
+	}
+	if m.penalty != nil {
+		inner := b
+		if inner == nil {
+			inner = direct
+		}
+		b = func(es []Entity, fs []float64) {
+			inner(es, fs)
+			for i, e := range es {
+				fs[i] = m.penalize(e, fs[i])
+			}
+		}
+	}
+	if m.screen != nil {
+		inner := b
+		if inner == nil {
+			inner = direct
+		}
+		b = func(es []Entity, fs []float64) {
+			m.screen(es, fs, inner)
+		}
+	}
+	m.pop.batch = b
+}
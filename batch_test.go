@@ -0,0 +1,64 @@
+package ga_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+type Batch struct {
+	sizes []int
+}
+
+func (b *Batch) Evaluate(es []ga.Entity, fs []float64) {
+	b.sizes = append(b.sizes, len(es))
+	for i, e := range es {
+		fs[i] = e.(MIN).Fitness()
+	}
+}
+
+func TestBatchEvaluator(t *testing.T) {
+	b := &Batch{}
+	m := ga.New(30, MIN{}.Mutate, ga.WithBatchEvaluator(b))
+	if _, f, _ := m.Evolve(30, 100); f < -0.1 {
+		t.Fatal("fitness:", f)
+	}
+	for _, n := range b.sizes {
+		if n != 30 {
+			t.Fatal("batch size:", n)
+		}
+	}
+	if e := m.SnapshotStats().Evaluations; e != int64(30*len(b.sizes)) {
+		t.Fatal("evaluations:", e, len(b.sizes))
+	}
+}
+
+// Plain evaluates the fitnesses of any entities by Fitness.
+type Plain struct{}
+
+func (Plain) Evaluate(es []ga.Entity, fs []float64) {
+	for i, e := range es {
+		fs[i] = e.Fitness()
+	}
+}
+
+func TestBatchPenalty(t *testing.T) {
+	m := ga.New(100, func() ga.Entity {
+		return Far(20 * rand.Float64())
+	}, ga.WithBatchEvaluator(Plain{}), ga.WithPenalty(ga.StaticPenalty(100)))
+	m.EvolveTo(100)
+	if f := m.SnapshotStats().Best; f < -10.5 || f > -10 {
+		t.Fatal("best:", f)
+	}
+}
+
+func TestBatchConflict(t *testing.T) {
+	b := &Batch{}
+	m := ga.New(30, MIN{}.Mutate, ga.WithBatchEvaluator(b), ga.WithPrecompute(func(int, []ga.Entity) {
+		t.Fatal("conflicting evaluator called")
+	}))
+	if m.Err() == nil || len(b.sizes) != 1 {
+		t.Fatal("conflict:", m.Err(), b.sizes)
+	}
+}
@@ -0,0 +1,195 @@
+// Package bench implements the standard test problems of GA model, with known optima,
+// to validate the parameters of a model, and to test the package itself.
+package bench
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/ofunc/ga"
+	"github.com/ofunc/ga/bits"
+	"github.com/ofunc/ga/vector"
+)
+
+// Problem is a test problem with a known optimum.
+type Problem struct {
+	// Name is the name of the problem.
+	Name string
+	// Optimum is the maximum fitness of the problem.
+	Optimum float64
+	// Random returns a random entity of the problem, which can be the generator of GA model.
+	Random func() ga.Entity
+	// RandomCtx is Random with the random numbers of the context, which can be the generator of ga.NewCtx,
+	// so that the runs are reproducible by ga.WithSeed.
+	RandomCtx func(ga.GenCtx) ga.Entity
+}
+
+// Gap returns the distance of the fitness f to the optimum.
+func (p Problem) Gap(f float64) float64 {
+	return p.Optimum - f
+}
+
+// Function is a real-valued test function to minimize, with its standard domain and minimum.
+type Function struct {
+	// Name is the name of the function.
+	Name string
+	// F is the function.
+	F func([]float64) float64
+	// Lower and Upper are the bounds of each dimension.
+	Lower, Upper float64
+	// Min is the minimum of F in the domain.
+	Min float64
+}
+
+// Problem returns the problem of the function in d dimensions, whose fitness is -F, and the optimum is -Min.
+func (f Function) Problem(d int, opts ...vector.Option) Problem {
+	lower, upper := make([]float64, d), make([]float64, d)
+	for i := range lower {
+		lower[i], upper[i] = f.Lower, f.Upper
+	}
+	s := vector.NewSpace(lower, upper, func(x []float64) float64 {
+		return -f.F(x)
+	}, opts...)
+	return Problem{f.Name, -f.Min, s.Random, s.RandomCtx}
+}
+
+// The real-valued test functions, all with the minimum 0.
+var (
+	// Sphere is the unimodal sum of squares, with the minimum at the origin.
+	Sphere = Function{"sphere", sphere, -5.12, 5.12, 0}
+	// Rastrigin is highly multimodal, with a regular grid of local minima, and the minimum at the origin.
+	Rastrigin = Function{"rastrigin", rastrigin, -5.12, 5.12, 0}
+	// Rosenbrock has a narrow curved valley, with the minimum at (1, ..., 1).
+	Rosenbrock = Function{"rosenbrock", rosenbrock, -2.048, 2.048, 0}
+	// Ackley is multimodal, with a nearly flat outer region, and the minimum at the origin.
+	Ackley = Function{"ackley", ackley, -32.768, 32.768, 0}
+	// Schwefel is deceptive, with the minimum near the bounds, at (420.9687, ..., 420.9687).
+	Schwefel = Function{"schwefel", schwefel, -500, 500, 0}
+)
+
+func sphere(x []float64) float64 {
+	s := 0.0
+	for _, v := range x {
+		s += v * v
+	}
+	return s
+}
+
+func rastrigin(x []float64) float64 {
+	s := 10 * float64(len(x))
+	for _, v := range x {
+		s += v*v - 10*math.Cos(2*math.Pi*v)
+	}
+	return s
+}
+
+func rosenbrock(x []float64) float64 {
+	s := 0.0
+	for i := 0; i+1 < len(x); i++ {
+		a, b := x[i+1]-x[i]*x[i], 1-x[i]
+		s += 100*a*a + b*b
+	}
+	return s
+}
+
+func ackley(x []float64) float64 {
+	n := float64(len(x))
+	s, c := 0.0, 0.0
+	for _, v := range x {
+		s += v * v
+		c += math.Cos(2 * math.Pi * v)
+	}
+	return -20*math.Exp(-0.2*math.Sqrt(s/n)) - math.Exp(c/n) + 20 + math.E
+}
+
+func schwefel(x []float64) float64 {
+	s := 418.9828872724338 * float64(len(x))
+	for _, v := range x {
+		s -= v * math.Sin(math.Sqrt(math.Abs(v)))
+	}
+	return s
+}
+
+// OneMax returns the problem of maximizing the number of ones in a bitstring of length n.
+func OneMax(n int, opts ...bits.Option) Problem {
+	s := bits.NewSpace(n, func(b *bits.Bits) float64 {
+		return float64(b.Count())
+	}, opts...)
+	return Problem{"onemax", float64(n), s.Random, s.RandomCtx}
+}
+
+// Knapsack is an instance of the 0-1 knapsack problem.
+type Knapsack struct {
+	// Weights are the weights of the items.
+	Weights []int
+	// Values are the values of the items.
+	Values []float64
+	// Capacity is the capacity of the knapsack.
+	Capacity int
+}
+
+// The knapsack instances of Kreher and Stinson, with known optima.
+var (
+	// P01 has 10 items, and the optimum 309.
+	P01 = Knapsack{
+		[]int{23, 31, 29, 44, 53, 38, 63, 85, 89, 82},
+		[]float64{92, 57, 49, 68, 60, 43, 67, 84, 87, 72},
+		165,
+	}
+	// P07 has 15 items, and the optimum 1458.
+	P07 = Knapsack{
+		[]int{70, 73, 77, 80, 82, 87, 90, 94, 98, 106, 110, 113, 115, 118, 120},
+		[]float64{135, 139, 149, 150, 156, 163, 173, 184, 192, 201, 210, 214, 221, 229, 240},
+		750,
+	}
+)
+
+// RandomKnapsack returns a random instance of n items, with the weights in [1, 100],
+// the values correlated with the weights, and the capacity of half the total weight.
+func RandomKnapsack(n int, seed int64) Knapsack {
+	r := rand.New(rand.NewPCG(uint64(seed), 0x9e3779b97f4a7c15))
+	k := Knapsack{make([]int, n), make([]float64, n), 0}
+	total := 0
+	for i := range k.Weights {
+		k.Weights[i] = 1 + r.IntN(100)
+		k.Values[i] = float64(k.Weights[i]) + float64(r.IntN(20))
+		total += k.Weights[i]
+	}
+	k.Capacity = total / 2
+	return k
+}
+
+// Optimum returns the maximum value of the instance, by dynamic programming.
+func (k Knapsack) Optimum() float64 {
+	best := make([]float64, k.Capacity+1)
+	for i, w := range k.Weights {
+		for c := k.Capacity; c >= w; c-- {
+			best[c] = math.Max(best[c], best[c-w]+k.Values[i])
+		}
+	}
+	return best[k.Capacity]
+}
+
+// Value returns the total weight and value of the items selected by b.
+func (k Knapsack) Value(b *bits.Bits) (int, float64) {
+	w, v := 0, 0.0
+	for i := range k.Weights {
+		if b.Bit(i) {
+			w, v = w+k.Weights[i], v+k.Values[i]
+		}
+	}
+	return w, v
+}
+
+// Problem returns the problem of the instance, whose fitness is the total value,
+// or the negative overweight if the capacity is exceeded, so that any feasible selection is better.
+func (k Knapsack) Problem(opts ...bits.Option) Problem {
+	s := bits.NewSpace(len(k.Weights), func(b *bits.Bits) float64 {
+		w, v := k.Value(b)
+		if w > k.Capacity {
+			return float64(k.Capacity - w)
+		}
+		return v
+	}, opts...)
+	return Problem{"knapsack", k.Optimum(), s.Random, s.RandomCtx}
+}
@@ -0,0 +1,60 @@
+package bench_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ofunc/ga"
+	"github.com/ofunc/ga/bench"
+)
+
+func TestFunctions(t *testing.T) {
+	for _, c := range []struct {
+		f bench.Function
+		x float64
+	}{
+		{bench.Sphere, 0},
+		{bench.Rastrigin, 0},
+		{bench.Rosenbrock, 1},
+		{bench.Ackley, 0},
+		{bench.Schwefel, 420.9687},
+	} {
+		if y := c.f.F([]float64{c.x, c.x, c.x}); math.Abs(y-c.f.Min) > 1e-4 {
+			t.Error(c.f.Name, "minimum:", y)
+		}
+		if y := c.f.F([]float64{c.f.Lower / 3, c.f.Upper / 5, 0.5}); y <= c.f.Min {
+			t.Error(c.f.Name, "off the minimum:", y)
+		}
+	}
+}
+
+func TestKnapsack(t *testing.T) {
+	if f := bench.P01.Optimum(); f != 309 {
+		t.Fatal("P01:", f)
+	}
+	if f := bench.P07.Optimum(); f != 1458 {
+		t.Fatal("P07:", f)
+	}
+	p := bench.P01.Problem()
+	m := ga.New(100, p.Random, ga.WithRandomImmigrants(0.1))
+	if _, f, _ := m.Evolve(100, 1000); p.Gap(f) > 0 {
+		t.Error("P01 fitness:", f)
+	}
+}
+
+func TestRegression(t *testing.T) {
+	for _, c := range []struct {
+		p   bench.Problem
+		gap float64
+	}{
+		{bench.Sphere.Problem(5), 0.1},
+		{bench.Ackley.Problem(2), 0.5},
+		{bench.OneMax(64), 4},
+		{bench.RandomKnapsack(30, 1).Problem(), 60},
+	} {
+		m := ga.New(100, c.p.Random)
+		if _, f, _ := m.Evolve(50, 1000); c.p.Gap(f) > c.gap {
+			t.Error(c.p.Name, "gap:", c.p.Gap(f))
+		}
+	}
+}
@@ -0,0 +1,241 @@
+// Package bits implements the bitstring genome of GA model, backed by a compact bitset,
+// with the standard crossover and mutation operators, the helpers to decode bit ranges,
+// and the estimation of distribution by a probability vector of the bits.
+// It is also a reference implementation of ga.Entity.
+package bits
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/ofunc/ga"
+)
+
+// Crossover is a crossover operator of bitstrings, which produces a child of x and y into z with the random numbers of r.
+type Crossover func(r *rand.Rand, z, x, y *Bits)
+
+// Space is the space of the bitstrings of length n.
+type Space struct {
+	n         int
+	fitness   func(*Bits) float64
+	crossover Crossover
+	rate      float64
+}
+
+// Option is an option of Space.
+type Option func(*Space)
+
+// WithCrossover sets the crossover operator, default to Uniform.
+func WithCrossover(c Crossover) Option {
+	return func(s *Space) {
+		s.crossover = c
+	}
+}
+
+// WithFlipRate sets the probability that each bit is flipped, when the bitstring is mutated, default to 1/n.
+// At least one bit is flipped anyway.
+func WithFlipRate(p float64) Option {
+	return func(s *Space) {
+		s.rate = p
+	}
+}
+
+// NewSpace creates the space of the bitstrings of length n with the fitness function f.
+func NewSpace(n int, f func(*Bits) float64, opts ...Option) *Space {
+	s := &Space{
+		n:         n,
+		fitness:   f,
+		crossover: Uniform,
+		rate:      1 / float64(n),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Len returns the length of the bitstrings.
+func (s *Space) Len() int {
+	return s.n
+}
+
+// New returns the bitstring of all zeros.
+func (s *Space) New() *Bits {
+	return &Bits{make([]uint64, (s.n+63)/64), s}
+}
+
+// Random returns a uniformly random bitstring, which can be the generator of GA model.
+func (s *Space) Random() ga.Entity {
+	return s.RandomCtx(ga.GenCtx{})
+}
+
+// RandomCtx is Random with the random numbers of ctx, which can be the generator of ga.NewCtx,
+// so that the bitstrings are reproducible by ga.WithSeed.
+func (s *Space) RandomCtx(ctx ga.GenCtx) ga.Entity {
+	r, b := ctx.Random(), s.New()
+	for i := range b.w {
+		b.w[i] = r.Uint64()
+	}
+	b.trim()
+	return b
+}
+
+// Bits is a bitstring of a space, which implements ga.Entity,
+// and ga.ContextMutator and ga.ContextCrossover to draw the random numbers of the model.
+type Bits struct {
+	w []uint64
+	s *Space
+}
+
+// Len returns the length of the bitstring.
+func (b *Bits) Len() int {
+	return b.s.n
+}
+
+// Bit reports whether the bit i is set.
+func (b *Bits) Bit(i int) bool {
+	return b.w[i/64]&(1<<uint(i%64)) != 0
+}
+
+// Set sets the bit i to v. It should be called only on a new bitstring, since the entities are shared.
+func (b *Bits) Set(i int, v bool) {
+	if v {
+		b.w[i/64] |= 1 << uint(i%64)
+	} else {
+		b.w[i/64] &^= 1 << uint(i%64)
+	}
+}
+
+// Flip flips the bit i. It should be called only on a new bitstring, since the entities are shared.
+func (b *Bits) Flip(i int) {
+	b.w[i/64] ^= 1 << uint(i%64)
+}
+
+// Uint decodes the n bits from the bit i as an unsigned integer, with the bit i as the least significant one.
+// n is at most 64.
+func (b *Bits) Uint(i, n int) uint64 {
+	var x uint64
+	for j := n - 1; j >= 0; j-- {
+		x <<= 1
+		if b.Bit(i + j) {
+			x |= 1
+		}
+	}
+	return x
+}
+
+// Float decodes the n bits from the bit i linearly into [min, max].
+func (b *Bits) Float(i, n int, min, max float64) float64 {
+	return min + (max-min)*float64(b.Uint(i, n))/(math.Pow(2, float64(n))-1)
+}
+
+// Count returns the number of set bits.
+func (b *Bits) Count() int {
+	c := 0
+	for _, w := range b.w {
+		for ; w != 0; w &= w - 1 {
+			c++
+		}
+	}
+	return c
+}
+
+// String returns the bitstring as 0s and 1s, from the bit 0.
+func (b *Bits) String() string {
+	bs := make([]byte, b.s.n)
+	for i := range bs {
+		bs[i] = '0'
+		if b.Bit(i) {
+			bs[i] = '1'
+		}
+	}
+	return string(bs)
+}
+
+// Key returns the key of the bitstring, which implements ga.Keyer.
+func (b *Bits) Key() string {
+	bs := make([]byte, 8*len(b.w))
+	for i, w := range b.w {
+		for j := 0; j < 8; j++ {
+			bs[8*i+j] = byte(w >> uint(8*j))
+		}
+	}
+	return string(bs)
+}
+
+// Fitness returns the fitness of the bitstring.
+func (b *Bits) Fitness() float64 {
+	return b.s.fitness(b)
+}
+
+// Mutate flips the bits of a copy of the bitstring, each with the flip rate.
+func (b *Bits) Mutate() ga.Entity {
+	return b.MutateCtx(ga.GenCtx{})
+}
+
+// MutateCtx is Mutate with the random numbers of ctx.
+func (b *Bits) MutateCtx(ctx ga.GenCtx) ga.Entity {
+	r, z := ctx.Random(), b.clone()
+	flipped := false
+	for i := 0; i < b.s.n; i++ {
+		if r.Float64() < b.s.rate {
+			z.Flip(i)
+			flipped = true
+		}
+	}
+	if !flipped && b.s.n > 0 {
+		z.Flip(r.IntN(b.s.n))
+	}
+	return z
+}
+
+// Crossover produces a child of b and e by the crossover operator of the space.
+// The weight is ignored.
+func (b *Bits) Crossover(e ga.Entity, w float64) ga.Entity {
+	return b.CrossoverCtx(ga.GenCtx{}, e, w)
+}
+
+// CrossoverCtx is Crossover with the random numbers of ctx.
+func (b *Bits) CrossoverCtx(ctx ga.GenCtx, e ga.Entity, w float64) ga.Entity {
+	z := b.s.New()
+	b.s.crossover(ctx.Random(), z, b, e.(*Bits))
+	return z
+}
+
+func (b *Bits) clone() *Bits {
+	return &Bits{append([]uint64(nil), b.w...), b.s}
+}
+
+// trim clears the unused bits of the last word.
+func (b *Bits) trim() {
+	if r := b.s.n % 64; r != 0 {
+		b.w[len(b.w)-1] &= 1<<uint(r) - 1
+	}
+}
+
+// Uniform takes each bit from either parent with the equal probability.
+func Uniform(r *rand.Rand, z, x, y *Bits) {
+	for i := range z.w {
+		m := r.Uint64()
+		z.w[i] = x.w[i]&m | y.w[i]&^m
+	}
+	z.trim()
+}
+
+// KPoint returns the k-point crossover, which alternates the parents between k random cut points.
+func KPoint(k int) Crossover {
+	return func(r *rand.Rand, z, x, y *Bits) {
+		n := z.s.n
+		cuts := make([]bool, n+1)
+		for j := 0; j < k; j++ {
+			cuts[r.IntN(n+1)] = true
+		}
+		p, q := x, y
+		for i := 0; i < n; i++ {
+			if cuts[i] {
+				p, q = q, p
+			}
+			z.Set(i, p.Bit(i))
+		}
+	}
+}
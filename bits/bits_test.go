@@ -0,0 +1,52 @@
+package bits_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ofunc/ga"
+	"github.com/ofunc/ga/bits"
+)
+
+func TestOneMax(t *testing.T) {
+	for name, c := range map[string]bits.Crossover{"uniform": bits.Uniform, "2-point": bits.KPoint(2)} {
+		s := bits.NewSpace(100, func(b *bits.Bits) float64 {
+			return float64(b.Count())
+		}, bits.WithCrossover(c))
+		m := ga.New(100, s.Random)
+		if _, f, _ := m.Evolve(50, 1000); f < 90 {
+			t.Error(name, "fitness:", f)
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	s := bits.NewSpace(70, nil)
+	b := s.New()
+	for _, i := range []int{0, 2, 3, 64, 69} {
+		b.Set(i, true)
+	}
+	if x := b.Uint(0, 4); x != 13 {
+		t.Fatal("uint:", x)
+	}
+	if x := b.Uint(62, 8); x != 0x84 {
+		t.Fatal("uint across words:", x)
+	}
+	if x := b.Float(0, 4, -1, 2); math.Abs(x-1.6) > 1e-12 {
+		t.Fatal("float:", x)
+	}
+	if c := b.Count(); c != 5 {
+		t.Fatal("count:", c)
+	}
+	if r := s.Random().(*bits.Bits); len(r.String()) != 70 || r.Key() == b.Key() && r.String() != b.String() {
+		t.Fatal("random:", r)
+	}
+	for i := 0; i < 100; i++ {
+		z := b.Crossover(s.New(), 0.5).(*bits.Bits)
+		for j := 0; j < 70; j++ {
+			if z.Bit(j) && !b.Bit(j) {
+				t.Fatal("crossover:", z)
+			}
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package bits
+
+import (
+	"math"
+	"math/rand/v2"
+	"sort"
+	"time"
+
+	"github.com/ofunc/ga"
+)
+
+// Model is an estimation of distribution algorithm over the bitstrings of a space,
+// which keeps a probability vector of the bits instead of a population with explicit crossover,
+// e.g. the compact GA or PBIL. Each generation samples the bitstrings from the vector,
+// evaluates them concurrently as a ga.Population, and moves the vector towards the fittest ones.
+// It has the same driver surface as GA model, and is much cheaper for very large populations,
+// since only the samples of a generation are kept.
+type Model struct {
+	space   *Space
+	n       int
+	p       []float64
+	rate    float64
+	frac    float64
+	elite   ga.Entity
+	fitness float64
+	seed    int64
+	rnd     *rand.Rand
+}
+
+// ModelOption is an option of Model.
+type ModelOption func(*Model)
+
+// WithModelSeed sets the seed of the random source of the model, default to the current time.
+func WithModelSeed(seed int64) ModelOption {
+	return func(m *Model) {
+		m.seed = seed
+	}
+}
+
+// WithLearningRate sets the rate at which the probability vector moves towards the selected bitstrings, default to 0.1.
+// The rate 1 is the univariate marginal distribution algorithm, UMDA.
+func WithLearningRate(r float64) ModelOption {
+	return func(m *Model) {
+		m.rate = r
+	}
+}
+
+// WithTruncation sets the fraction of the fittest samples selected in each generation, default to 0.5.
+// At least one sample is selected.
+func WithTruncation(frac float64) ModelOption {
+	return func(m *Model) {
+		m.frac = frac
+	}
+}
+
+// NewModel creates a model of the space s, which samples n bitstrings in each generation,
+// with all the probabilities of the bits being 0.5.
+func NewModel(s *Space, n int, opts ...ModelOption) *Model {
+	m := &Model{
+		space:   s,
+		n:       n,
+		p:       make([]float64, s.n),
+		rate:    0.1,
+		frac:    0.5,
+		fitness: math.Inf(-1),
+		seed:    time.Now().UnixNano(),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	m.rnd = rand.New(rand.NewPCG(uint64(m.seed), 0x9e3779b97f4a7c15))
+	for i := range m.p {
+		m.p[i] = 0.5
+	}
+	return m
+}
+
+// Probabilities returns the probabilities that the bits are set, which must not be modified.
+func (m *Model) Probabilities() []float64 {
+	return m.p
+}
+
+// Fitness returns the fitness of the current elite.
+func (m *Model) Fitness() float64 {
+	return m.fitness
+}
+
+// Elite returns the current elite, which is a *Bits, or nil before the first generation.
+func (m *Model) Elite() ga.Entity {
+	return m.elite
+}
+
+// Converged reports whether all the probabilities are within 1/n of 0 or 1,
+// so the model samples almost only one bitstring.
+func (m *Model) Converged() bool {
+	d := 1 / float64(m.n)
+	for _, p := range m.p {
+		if p > d && p < 1-d {
+			return false
+		}
+	}
+	return true
+}
+
+// Next samples and evaluates a generation, updates the probability vector, and returns the current elite and fitness.
+func (m *Model) Next() (ga.Entity, float64) {
+	es := make([]ga.Entity, m.n)
+	for i := range es {
+		b := m.space.New()
+		for j, p := range m.p {
+			if m.rnd.Float64() < p {
+				b.Set(j, true)
+			}
+		}
+		es[i] = b
+	}
+	pop := ga.NewPopulation(es)
+	pop.Evaluate()
+	if e, f := pop.Best(); m.fitness < f {
+		m.elite, m.fitness = e, f
+	}
+
+	fs := make([]float64, m.n)
+	idx := make([]int, m.n)
+	for i := range idx {
+		_, fs[i] = pop.At(i)
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		return fs[idx[i]] > fs[idx[j]]
+	})
+	k := int(m.frac * float64(m.n))
+	if k < 1 {
+		k = 1
+	}
+	if k > m.n {
+		k = m.n
+	}
+	lo, hi := 1/float64(m.n), 1-1/float64(m.n)
+	for j := range m.p {
+		c := 0
+		for _, i := range idx[:k] {
+			if es[i].(*Bits).Bit(j) {
+				c++
+			}
+		}
+		p := (1-m.rate)*m.p[j] + m.rate*float64(c)/float64(k)
+		m.p[j] = math.Max(lo, math.Min(hi, p))
+	}
+	return m.elite, m.fitness
+}
+
+// Evolve runs the model until the elite k generations have not changed,
+// or the max of iterations has been reached.
+func (m *Model) Evolve(k int, max int) (ga.Entity, float64, bool) {
+	i, fitness := 0, m.fitness
+	for j := 0; i < k && j < max; i, j = i+1, j+1 {
+		if _, f := m.Next(); fitness < f {
+			i, fitness = 0, f
+		}
+	}
+	return m.elite, fitness, i >= k
+}
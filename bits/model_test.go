@@ -0,0 +1,29 @@
+package bits_test
+
+import (
+	"testing"
+
+	"github.com/ofunc/ga/bits"
+)
+
+func TestModel(t *testing.T) {
+	s := bits.NewSpace(100, func(b *bits.Bits) float64 {
+		return float64(b.Count())
+	})
+	for name, opts := range map[string][]bits.ModelOption{
+		"pbil": nil,
+		"umda": {bits.WithLearningRate(1), bits.WithTruncation(0.3)},
+	} {
+		m := bits.NewModel(s, 200, append(opts, bits.WithModelSeed(1))...)
+		if m.Elite() != nil || m.Probabilities()[0] != 0.5 {
+			t.Fatal(name, "initial:", m.Elite(), m.Probabilities()[0])
+		}
+		e, f, _ := m.Evolve(100, 1000)
+		if f < 98 || e.Fitness() != f {
+			t.Error(name, "fitness:", f)
+		}
+		if !m.Converged() {
+			t.Error(name, "not converged:", m.Probabilities())
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package ga
+
+import "math"
+
+// Sized is an optional interface of Entity of variable size, e.g. the number of nodes of a tree,
+// by which the bloat is controlled, see WithParsimony and WithMaxSize.
+type Sized interface {
+	Size() int
+}
+
+// WithParsimony penalizes the larger entities in selection by the parsimony pressure,
+// which subtracts c times the size from the fitnesses when computing the selection weights.
+// If c is 0, the coefficient is the covariant parsimony pressure of Poli and McPhee,
+// the covariance of the size and the fitness divided by the variance of the size, of each generation,
+// which keeps the mean size about constant.
+// The fitnesses and the elite are not penalized, and the selectors set by WithSelector select as usual.
+// The entities not implementing Sized have the size 0.
+func WithParsimony(c float64) Option {
+	return func(m *GA) {
+		if m.bloat == nil {
+			m.bloat = new(bloat)
+		}
+		m.bloat.c, m.bloat.parsimony = c, true
+	}
+}
+
+// WithMaxSize replaces the offspring larger than max by its first parent, the usual size limit of the genetic programming.
+func WithMaxSize(max int) Option {
+	return func(m *GA) {
+		if m.bloat == nil {
+			m.bloat = new(bloat)
+		}
+		m.bloat.max = max
+	}
+}
+
+type bloat struct {
+	c         float64
+	parsimony bool
+	max       int
+	sizes     []float64
+	fs        []float64
+}
+
+func size(e Entity) int {
+	if s, ok := e.(Sized); ok {
+		return s.Size()
+	}
+	return 0
+}
+
+// oversized reports whether e is larger than the size limit.
+func (b *bloat) oversized(e Entity) bool {
+	return b.max > 0 && size(e) > b.max
+}
+
+// weigh computes the selection weights of p by the fitnesses penalized by the sizes.
+func (b *bloat) weigh(p *Population) {
+	n := len(p.entities)
+	if len(b.sizes) != n {
+		b.sizes, b.fs = make([]float64, n), make([]float64, n)
+	}
+	k, ms, mf := 0, 0.0, 0.0
+	for i, e := range p.entities {
+		b.sizes[i] = float64(size(e))
+		if f := p.fitnesses[i]; !math.IsInf(f, 0) {
+			k, ms, mf = k+1, ms+b.sizes[i], mf+f
+		}
+	}
+	if k == 0 {
+		p.weigh()
+		return
+	}
+	ms, mf = ms/float64(k), mf/float64(k)
+	c := b.c
+	if c == 0 {
+		cov, vs := 0.0, 0.0
+		for i, f := range p.fitnesses {
+			if !math.IsInf(f, 0) {
+				cov += (b.sizes[i] - ms) * (f - mf)
+				vs += (b.sizes[i] - ms) * (b.sizes[i] - ms)
+			}
+		}
+		if vs > 0 {
+			c = cov / vs
+		}
+	}
+	mean, m2 := mf-c*ms, 0.0
+	for i, f := range p.fitnesses {
+		b.fs[i] = f - c*b.sizes[i]
+		if !math.IsInf(f, 0) {
+			m2 += (b.fs[i] - mean) * (b.fs[i] - mean)
+		}
+	}
+	p.scale(b.fs, mean, math.Sqrt(m2/float64(k)))
+}
@@ -0,0 +1,64 @@
+package ga_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+type Blob struct {
+	n int
+	x float64
+}
+
+func (b Blob) Fitness() float64 {
+	return -sqr(b.x-1) + 0.01*float64(b.n)
+}
+
+func (b Blob) Mutate() ga.Entity {
+	b.n += 2*rand.Intn(2) - 1
+	if b.n < 1 {
+		b.n = 1
+	}
+	b.x += 0.1 * rand.NormFloat64()
+	return b
+}
+
+func (b Blob) Crossover(e ga.Entity, w float64) ga.Entity {
+	o := e.(Blob)
+	if rand.Float64() >= w {
+		b.n = o.n
+	}
+	b.x = w*b.x + (1-w)*o.x
+	return b
+}
+
+func (b Blob) Size() int {
+	return b.n
+}
+
+func TestParsimony(t *testing.T) {
+	mean := func(opts ...ga.Option) (float64, int) {
+		m := ga.New(100, func() ga.Entity {
+			return Blob{10, rand.Float64()}
+		}, append(opts, ga.WithFixedMutationRate(0.5))...)
+		s, max := 0.0, 0
+		for _, e := range m.EvolveTo(100) {
+			s += float64(e.(Blob).n)
+			if e.(Blob).n > max {
+				max = e.(Blob).n
+			}
+		}
+		return s / 100, max
+	}
+	free, _ := mean()
+	static, _ := mean(ga.WithParsimony(1))
+	covariant, _ := mean(ga.WithParsimony(0))
+	if static >= free || covariant >= free || static > 10 {
+		t.Fatal("mean sizes:", free, static, covariant)
+	}
+	if _, max := mean(ga.WithMaxSize(12)); max > 12 {
+		t.Fatal("max size:", max)
+	}
+}
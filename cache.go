@@ -0,0 +1,117 @@
+package ga
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Keyer is an optional interface of Entity, which identifies the genotype of the entity.
+// Entities with the same key must have the same fitness.
+type Keyer interface {
+	Key() string
+}
+
+// Hasher is an optional interface of Entity, which identifies the genotype of the entity by a hash.
+// It is cheaper than Keyer, but entities with the same hash must have the same fitness,
+// so the hash should be collision free in practice, e.g. 64 bits of a good hash of the genotype.
+// Keyer takes precedence if both are implemented.
+type Hasher interface {
+	Hash() uint64
+}
+
+// Cache is a thread-safe cache of fitnesses, keyed by Keyer or Hasher.
+// It can be shared by many GA models solving the same problem.
+type Cache struct {
+	hits   int64
+	misses int64
+	mutex  sync.RWMutex
+	values map[string]float64
+	hashes map[uint64]float64
+}
+
+// NewCache creates a fitness cache.
+func NewCache() *Cache {
+	return &Cache{values: make(map[string]float64), hashes: make(map[uint64]float64)}
+}
+
+// WithCache sets a private fitness cache, see WithSharedCache.
+func WithCache() Option {
+	return WithSharedCache(NewCache())
+}
+
+// Cache returns the fitness cache set by WithCache or WithSharedCache, or nil.
+func (m *GA) Cache() *Cache {
+	return m.cache
+}
+
+// WithSharedCache sets the fitness cache c, which may be shared with other GA models.
+// Entities implementing neither Keyer nor Hasher are always evaluated.
+func WithSharedCache(c *Cache) Option {
+	return func(m *GA) {
+		m.cache = c
+	}
+}
+
+// Fitness returns the fitness of e, evaluating it only if its key is not cached.
+// Concurrent misses of the same key may evaluate it more than once.
+func (c *Cache) Fitness(e Entity) float64 {
+	f, _ := c.fitness(e)
+	return f
+}
+
+// fitness returns the fitness of e, and whether it is evaluated.
+func (c *Cache) fitness(e Entity) (float64, bool) {
+	k, keyed := e.(Keyer)
+	h, hashed := e.(Hasher)
+	if !keyed && !hashed {
+		return fitness(e), true
+	}
+	var key string
+	var hash uint64
+	var f float64
+	var ok bool
+	c.mutex.RLock()
+	if keyed {
+		key = k.Key()
+		f, ok = c.values[key]
+	} else {
+		hash = h.Hash()
+		f, ok = c.hashes[hash]
+	}
+	c.mutex.RUnlock()
+	if ok {
+		atomic.AddInt64(&c.hits, 1)
+		return f, false
+	}
+	atomic.AddInt64(&c.misses, 1)
+	f = fitness(e)
+	c.mutex.Lock()
+	if keyed {
+		c.values[key] = f
+	} else {
+		c.hashes[hash] = f
+	}
+	c.mutex.Unlock()
+	return f, true
+}
+
+// Len returns the number of cached fitnesses.
+func (c *Cache) Len() int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return len(c.values) + len(c.hashes)
+}
+
+// Hits returns the aggregate numbers of hits and misses of all users.
+func (c *Cache) Hits() (hits, misses int64) {
+	return atomic.LoadInt64(&c.hits), atomic.LoadInt64(&c.misses)
+}
+
+// HitRate returns the aggregate hit rate of all users.
+func (c *Cache) HitRate() float64 {
+	h, m := c.Hits()
+	if h+m == 0 {
+		return 0
+	}
+	return float64(h) / float64(h+m)
+}
@@ -0,0 +1,100 @@
+package ga_test
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+var evaluations int64
+
+type Keyed struct {
+	ILP
+}
+
+func (x Keyed) Fitness() float64 {
+	atomic.AddInt64(&evaluations, 1)
+	return x.ILP.Fitness()
+}
+
+func (x Keyed) Key() string {
+	return strconv.Itoa(int(x.ILP[0])) + "," + strconv.Itoa(int(x.ILP[1]))
+}
+
+func (x Keyed) Mutate() ga.Entity {
+	return Keyed{x.ILP.Mutate().(ILP)}
+}
+
+func (x Keyed) Crossover(e ga.Entity, w float64) ga.Entity {
+	return Keyed{x.ILP.Crossover(e.(Keyed).ILP, w).(ILP)}
+}
+
+func TestSharedCache(t *testing.T) {
+	atomic.StoreInt64(&evaluations, 0)
+	c := ga.NewCache()
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m := ga.New(20, Keyed{}.Mutate, ga.WithSharedCache(c))
+			m.Evolve(30, 100)
+		}()
+	}
+	wg.Wait()
+
+	hits, misses := c.Hits()
+	if hits == 0 || misses < int64(c.Len()) {
+		t.Fatal("hits, misses, len:", hits, misses, c.Len())
+	}
+	if c.Len() > 100 {
+		t.Fatal("genotypes:", c.Len())
+	}
+	if n := atomic.LoadInt64(&evaluations); n != misses {
+		t.Fatal("evaluations:", n, misses)
+	}
+	if r := c.HitRate(); r < 0.5 {
+		t.Fatal("hit rate:", r)
+	}
+}
+
+type Hashed struct {
+	ILP
+}
+
+func (x Hashed) Fitness() float64 {
+	atomic.AddInt64(&evaluations, 1)
+	return x.ILP.Fitness()
+}
+
+func (x Hashed) Hash() uint64 {
+	return uint64(uint32(x.ILP[0]))<<32 | uint64(uint32(x.ILP[1]))
+}
+
+func (x Hashed) Mutate() ga.Entity {
+	return Hashed{x.ILP.Mutate().(ILP)}
+}
+
+func (x Hashed) Crossover(e ga.Entity, w float64) ga.Entity {
+	return Hashed{x.ILP.Crossover(e.(Hashed).ILP, w).(ILP)}
+}
+
+func TestHashedCache(t *testing.T) {
+	atomic.StoreInt64(&evaluations, 0)
+	m := ga.New(20, Hashed{}.Mutate, ga.WithCache())
+	m.Evolve(30, 100)
+	c := m.Cache()
+	hits, misses := c.Hits()
+	if hits == 0 || misses < int64(c.Len()) {
+		t.Fatal("hits, misses, len:", hits, misses, c.Len())
+	}
+	if n := atomic.LoadInt64(&evaluations); n != misses {
+		t.Fatal("evaluations:", n, misses)
+	}
+	if ga.New(5, MIN{}.Mutate).Cache() != nil {
+		t.Fatal("cache without option")
+	}
+}
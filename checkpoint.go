@@ -0,0 +1,144 @@
+package ga
+
+import (
+	"encoding"
+	"encoding/gob"
+	"fmt"
+	"io"
+)
+
+type checkpoint struct {
+	Generation int
+	Seed       int64
+	Source     []byte
+	Evals      int64
+	Adaptation Adaptation
+	Elite      []byte
+	Fitness    float64
+	Entities   [][]byte
+	Fitnesses  []float64
+	Trajectory []float64
+	Cache      map[string]float64
+	Hashes     map[uint64]float64
+	Codec      string
+	// Generated is the number of the calls of the generator of NewCtx, which index their random numbers.
+	Generated uint64
+}
+
+// Save writes a checkpoint of the GA model to w, which can be restored by Load.
+// The population, the elite, the adaptive mutation probability, the random state of the model
+// if its source implements encoding.BinaryMarshaler, as the default PCG does, see WithSource, and of the generator of NewCtx,
+// and the fitness cache set by WithSharedCache are saved.
+// The entities are encoded by the codec set by WithCodec, or must implement encoding.BinaryMarshaler.
+// The random numbers drawn by the entities themselves, e.g. from math/rand, are not saved.
+func (m *GA) Save(w io.Writer) error {
+	c := checkpoint{
+		Generation: m.gen,
+		Seed:       m.seed,
+		Generated:  m.draws.Load(),
+		Evals:      m.evals,
+		Adaptation: m.Adaptation(),
+		Fitness:    m.fitness,
+		Entities:   make([][]byte, m.n),
+		Fitnesses:  m.pop.fitnesses,
+		Trajectory: m.trajectory,
+		Codec:      m.cname,
+	}
+	var err error
+	if b, ok := m.src.(encoding.BinaryMarshaler); ok {
+		if c.Source, err = b.MarshalBinary(); err != nil {
+			return err
+		}
+	}
+	if m.elite != nil {
+		if c.Elite, err = m.marshal(m.elite); err != nil {
+			return err
+		}
+	}
+	for i, e := range m.pop.entities {
+		if c.Entities[i], err = m.marshal(e); err != nil {
+			return err
+		}
+	}
+	if m.cache != nil {
+		m.cache.mutex.RLock()
+		defer m.cache.mutex.RUnlock()
+		c.Cache, c.Hashes = m.cache.values, m.cache.hashes
+	}
+	return gob.NewEncoder(w).Encode(c)
+}
+
+// Load restores the GA model from the checkpoint written by Save to r, decoding the entities by decode,
+// or by the codec saved in the checkpoint if decode is nil, see WithCodec.
+// The model should be created by New with the same options as the saved one,
+// and its population is replaced, so the size of the population becomes the saved one.
+// The saved fitness cache is merged into the cache set by WithSharedCache, if any.
+// Nothing is re-evaluated.
+func (m *GA) Load(r io.Reader, decode func([]byte) Entity) error {
+	var c checkpoint
+	if err := gob.NewDecoder(r).Decode(&c); err != nil {
+		return err
+	}
+	if len(c.Entities) != len(c.Fitnesses) {
+		return fmt.Errorf("ga: corrupted checkpoint: %d entities, %d fitnesses", len(c.Entities), len(c.Fitnesses))
+	}
+	dec := func(b []byte) (Entity, error) {
+		return decode(b), nil
+	}
+	if decode == nil {
+		d, err := lookupCodec(c.Codec)
+		if err != nil {
+			return err
+		}
+		dec = d.Decode
+	}
+	es := make([]Entity, len(c.Entities))
+	var elite Entity
+	var err error
+	for i, b := range c.Entities {
+		if es[i], err = dec(b); err != nil {
+			return err
+		}
+	}
+	if c.Elite != nil {
+		if elite, err = dec(c.Elite); err != nil {
+			return err
+		}
+	}
+	m.resize(len(es))
+	copy(m.pop.entities, es)
+	copy(m.pop.fitnesses, c.Fitnesses)
+	m.gen, m.seed, m.evals = c.Generation, c.Seed, c.Evals
+	m.draws.Store(c.Generated)
+	if b, ok := m.src.(encoding.BinaryUnmarshaler); ok && c.Source != nil {
+		if err := b.UnmarshalBinary(c.Source); err != nil {
+			return err
+		}
+	}
+	m.elite, m.fitness = elite, c.Fitness
+	m.restore(c.Adaptation)
+	m.trajectory = c.Trajectory
+	if m.cache != nil {
+		m.cache.mutex.Lock()
+		for k, f := range c.Cache {
+			m.cache.values[k] = f
+		}
+		for k, f := range c.Hashes {
+			m.cache.hashes[k] = f
+		}
+		m.cache.mutex.Unlock()
+	}
+	m.refresh()
+	return nil
+}
+
+func (m *GA) marshal(e Entity) ([]byte, error) {
+	if m.codec != nil {
+		return m.codec.Encode(e)
+	}
+	b, ok := e.(encoding.BinaryMarshaler)
+	if !ok {
+		return nil, fmt.Errorf("ga: entity %T does not implement encoding.BinaryMarshaler", e)
+	}
+	return b.MarshalBinary()
+}
@@ -0,0 +1,114 @@
+package ga_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+type Saved float64
+
+func (s Saved) Fitness() float64 {
+	return -sqr(float64(s) - 3)
+}
+
+func (s Saved) Mutate() ga.Entity {
+	return s + Saved(math.Sin(1e3*float64(s)))/2
+}
+
+func (s Saved) Crossover(e ga.Entity, w float64) ga.Entity {
+	return Saved(w*float64(s) + (1-w)*float64(e.(Saved)))
+}
+
+func (s Saved) Key() string {
+	return strconv.FormatFloat(float64(s), 'g', -1, 64)
+}
+
+func (s Saved) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, math.Float64bits(float64(s)))
+	return b, nil
+}
+
+func decodeSaved(b []byte) ga.Entity {
+	return Saved(math.Float64frombits(binary.LittleEndian.Uint64(b)))
+}
+
+func TestCheckpoint(t *testing.T) {
+	nc := ga.NC
+	ga.NC = 1
+	defer func() { ga.NC = nc }()
+
+	newSaved := func(seed int64, c *ga.Cache) *ga.GA {
+		i := 0
+		return ga.New(30, func() ga.Entity {
+			i++
+			return Saved(i%10 - 5)
+		}, ga.WithSeed(seed), ga.WithSharedCache(c))
+	}
+	m := newSaved(3, ga.NewCache())
+	m.EvolveTo(5)
+	var buf bytes.Buffer
+	if err := m.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	xs := m.EvolveTo(15)
+
+	c := ga.NewCache()
+	r := newSaved(4, c)
+	if err := r.Load(&buf, decodeSaved); err != nil {
+		t.Fatal(err)
+	}
+	if s := r.SnapshotStats(); s.Generation != 5 || c.Len() == 0 {
+		t.Fatal("restore:", s.Generation, c.Len())
+	}
+	ys := r.EvolveTo(15)
+	for i := range xs {
+		if xs[i] != ys[i] {
+			t.Fatal("resume:", i, xs[i], ys[i])
+		}
+	}
+	if m.Fitness() != r.Fitness() || m.Adaptation() != r.Adaptation() {
+		t.Fatal("state:", m.Fitness(), r.Fitness(), m.Adaptation(), r.Adaptation())
+	}
+
+	if err := ga.New(5, MIN{}.Mutate).Save(&buf); err == nil {
+		t.Fatal("unmarshalable entity saved")
+	}
+}
+
+func TestCheckpointSource(t *testing.T) {
+	nc := ga.NC
+	ga.NC = 1
+	defer func() { ga.NC = nc }()
+
+	newSaved := func(seed byte) *ga.GA {
+		i := 0
+		return ga.New(30, func() ga.Entity {
+			i++
+			return Saved(i%10 - 5)
+		}, ga.WithSource(rand.NewChaCha8([32]byte{seed})))
+	}
+	m := newSaved(1)
+	m.EvolveTo(5)
+	var buf bytes.Buffer
+	if err := m.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	xs := m.EvolveTo(15)
+	r := newSaved(2)
+	if err := r.Load(&buf, decodeSaved); err != nil {
+		t.Fatal(err)
+	}
+	ys := r.EvolveTo(15)
+	for i := range xs {
+		if xs[i] != ys[i] {
+			t.Fatal("resume:", i, xs[i], ys[i])
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package ga
+
+import "math/rand/v2"
+
+// Clone returns an independent copy of the GA model with the options of the model followed by opts,
+// e.g. to branch a promising search and explore it with other parameters in parallel.
+// The population, the elite, the statistics, the adaptive mutation probability and the parameters set by SetParam are copied,
+// and the random source of the copy is forked from the one of the model, unless opts set the seed or the source.
+// Nothing is re-evaluated.
+// The entities are shared, except the ones implementing MutableEntity, which are copied by CloneInto,
+// and the shared ones are neither released nor passed to the hook of WithRecycle by either model.
+// The states given to the options, e.g. the cache of WithSharedCache, are shared too,
+// while the states kept by the options, e.g. the history of WithHistory, start empty.
+// With WithReproducibleParallel, the random numbers of the slots depend on the seed, which is copied.
+func (m *GA) Clone(opts ...Option) *GA {
+	c := blank(m.n, m.generator, m.opts)
+	c.pm, c.pmin, c.pmax, c.fixed = m.pm, m.pmin, m.pmax, m.fixed
+	c.pc, c.pressure, c.elitism = m.pc, m.pressure, m.elitism
+	c.seed = m.seed
+	seed, src := c.seed, c.src
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.wire()
+	c.opts = append(m.opts[:len(m.opts):len(m.opts)], opts...)
+	if c.noise != nil || c.dynamic {
+		c.memo = nil
+	}
+	if c.src == src {
+		if c.seed != seed {
+			c.src = rand.NewPCG(uint64(c.seed), mix(uint64(c.seed)))
+		} else {
+			c.src = rand.NewPCG(m.rnd.Uint64(), m.rnd.Uint64())
+		}
+		c.rnd = rand.New(c.src)
+	}
+
+	for i, e := range m.pop.entities {
+		c.pop.entities[i] = copyOf(e)
+	}
+	copy(c.pop.fitnesses, m.pop.fitnesses)
+	c.mutable, c.recycler.release = m.mutable, m.recycler.release
+	if c.recycling() {
+		m.shareWith(c)
+	}
+	c.gen, c.evals, c.fitness = m.gen, m.evals, m.fitness
+	c.draws.Store(m.draws.Load())
+	if m.elite != nil {
+		c.elite = copyOf(m.elite)
+	}
+	c.pm0 = m.pm0
+	c.base = m.base
+	if c.invariants != nil {
+		c.checkGenerator()
+	}
+	c.refresh()
+	c.trajectory = append([]float64(nil), m.trajectory...)
+	return c
+}
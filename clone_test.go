@@ -0,0 +1,82 @@
+package ga_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestClone(t *testing.T) {
+	m := ga.New(30, MIN{}.Mutate, ga.WithSeed(1))
+	m.EvolveTo(10)
+	es := m.Population()
+	s := m.Stats()
+
+	c := m.Clone(ga.WithFixedMutationRate(0.5))
+	if cs := c.Stats(); cs.Generation != s.Generation || cs.Fitness != s.Fitness || cs.Evaluations != s.Evaluations {
+		t.Fatal("stats:", cs, s)
+	}
+	if c.Elite() != m.Elite() {
+		t.Fatal("elite:", c.Elite(), m.Elite())
+	}
+	if c.MutationRate() != 0.5 {
+		t.Fatal("pm:", c.MutationRate())
+	}
+
+	c.EvolveTo(20)
+	if g := m.Stats().Generation; g != 10 {
+		t.Fatal("generation:", g)
+	}
+	es2 := m.Population()
+	for i := range es {
+		if es[i] != es2[i] {
+			t.Fatal("population changed:", i)
+		}
+	}
+	m.EvolveTo(20)
+	if c.Stats().Generation != 20 || c.Elite() == nil {
+		t.Fatal("clone:", c.Stats())
+	}
+}
+
+// Owned is a Boxed which records its release.
+type Owned struct {
+	Boxed
+	released bool
+}
+
+func (o *Owned) Release() {
+	o.released = true
+}
+
+func (o *Owned) Mutate() ga.Entity {
+	return &Owned{Boxed: *o.Boxed.Mutate().(*Boxed)}
+}
+
+func (o *Owned) Crossover(e ga.Entity, w float64) ga.Entity {
+	return &Owned{Boxed: *o.Boxed.Crossover(&e.(*Owned).Boxed, w).(*Boxed)}
+}
+
+func TestCloneReleaser(t *testing.T) {
+	m := ga.New(20, func() ga.Entity {
+		return &Owned{Boxed: Boxed{Walk(rand.Float64())}}
+	})
+	c := m.Clone()
+	for i := 0; i < 3; i++ {
+		m.Next()
+	}
+	for _, e := range c.Population() {
+		if e.(*Owned).released {
+			t.Fatal("released by the original")
+		}
+	}
+	for i := 0; i < 3; i++ {
+		c.Next()
+	}
+	for _, e := range m.Population() {
+		if e.(*Owned).released {
+			t.Fatal("released by the clone")
+		}
+	}
+}
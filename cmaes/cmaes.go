@@ -0,0 +1,368 @@
+// Package cmaes implements the covariance matrix adaptation evolution strategy, CMA-ES,
+// over the vector space of package vector, with the same driver surface as GA model,
+// so the two can be swapped on the same problem. It is usually much faster for smooth objectives
+// of moderate dimensions, e.g. less than 100.
+package cmaes
+
+import (
+	"math"
+	"math/rand/v2"
+	"sort"
+	"time"
+
+	"github.com/ofunc/ga"
+	"github.com/ofunc/ga/vector"
+)
+
+// Strategy is a (μ/μw, λ)-CMA-ES.
+type Strategy struct {
+	space   *vector.Space
+	lambda  int
+	mu      int
+	weights []float64
+	mueff   float64
+	cc, cs  float64
+	c1, cmu float64
+	damps   float64
+	chi     float64
+
+	mean     []float64
+	sigma    float64
+	pc, ps   []float64
+	c, b     [][]float64
+	d        []float64
+	invsqrt  [][]float64
+	evals    int
+	eigens   int
+	elite    ga.Entity
+	fitness  float64
+	seed     int64
+	nc       int
+	exec     ga.Executor
+	sigma0   float64
+	rnd      *rand.Rand
+	samples  [][]float64
+	entities []ga.Entity
+	fs       []float64
+}
+
+// Option is an option of Strategy.
+type Option func(*Strategy)
+
+// WithSeed sets the seed of the random source, default to the current time.
+func WithSeed(seed int64) Option {
+	return func(s *Strategy) {
+		s.seed = seed
+	}
+}
+
+// WithLambda sets the number of samples of each generation, default to 4 + 3 ln(d).
+func WithLambda(n int) Option {
+	return func(s *Strategy) {
+		s.lambda = n
+	}
+}
+
+// WithSigma sets the initial step size relative to the mean range of the bounds, default to 0.3.
+func WithSigma(sigma float64) Option {
+	return func(s *Strategy) {
+		s.sigma0 = sigma
+	}
+}
+
+// WithConcurrency sets the number of concurrency of the evaluation, default to ga.NC when New is called.
+func WithConcurrency(n int) Option {
+	return func(s *Strategy) {
+		s.nc = n
+	}
+}
+
+// WithExecutor evaluates the samples by e, e.g. the one shared with GA models by ga.WithExecutor,
+// instead of the worker pool shared by all GA models.
+func WithExecutor(e ga.Executor) Option {
+	return func(s *Strategy) {
+		s.exec = e
+	}
+}
+
+// New creates a strategy in the space s, whose initial mean is uniformly distributed.
+func New(s *vector.Space, opts ...Option) *Strategy {
+	n := s.Dim()
+	m := &Strategy{
+		space:   s,
+		lambda:  4 + int(3*math.Log(float64(n))),
+		fitness: math.Inf(-1),
+		seed:    time.Now().UnixNano(),
+		sigma0:  0.3,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	if m.nc <= 0 {
+		m.nc = ga.NC
+	}
+	if m.exec == nil {
+		m.exec = ga.SharedExecutor()
+	}
+	if m.lambda < 2 {
+		m.lambda = 2
+	}
+	m.rnd = rand.New(rand.NewPCG(uint64(m.seed), 0x9e3779b97f4a7c15))
+
+	N := float64(n)
+	m.mu = m.lambda / 2
+	m.weights = make([]float64, m.mu)
+	sum, sum2 := 0.0, 0.0
+	for i := range m.weights {
+		m.weights[i] = math.Log(float64(m.lambda+1)/2) - math.Log(float64(i+1))
+		sum += m.weights[i]
+	}
+	for i := range m.weights {
+		m.weights[i] /= sum
+		sum2 += m.weights[i] * m.weights[i]
+	}
+	m.mueff = 1 / sum2
+	m.cc = (4 + m.mueff/N) / (N + 4 + 2*m.mueff/N)
+	m.cs = (m.mueff + 2) / (N + m.mueff + 5)
+	m.c1 = 2 / ((N+1.3)*(N+1.3) + m.mueff)
+	m.cmu = math.Min(1-m.c1, 2*(m.mueff-2+1/m.mueff)/((N+2)*(N+2)+m.mueff))
+	m.damps = 1 + 2*math.Max(0, math.Sqrt((m.mueff-1)/(N+1))-1) + m.cs
+	m.chi = math.Sqrt(N) * (1 - 1/(4*N) + 1/(21*N*N))
+
+	lower, upper := s.Bounds()
+	m.mean, m.pc, m.ps, m.d = make([]float64, n), make([]float64, n), make([]float64, n), make([]float64, n)
+	m.c, m.b, m.invsqrt = identity(n), identity(n), identity(n)
+	r := 0.0
+	for i := range m.mean {
+		m.mean[i] = lower[i] + m.rnd.Float64()*(upper[i]-lower[i])
+		m.d[i] = 1
+		r += (upper[i] - lower[i]) / N
+	}
+	m.sigma = m.sigma0 * r
+	m.samples, m.entities, m.fs = make([][]float64, m.lambda), make([]ga.Entity, m.lambda), make([]float64, m.lambda)
+	return m
+}
+
+// Fitness returns the fitness of the current elite.
+func (m *Strategy) Fitness() float64 {
+	return m.fitness
+}
+
+// Elite returns the current elite, which is a *vector.Vector, or nil before the first generation.
+func (m *Strategy) Elite() ga.Entity {
+	return m.elite
+}
+
+// Mean returns the mean of the search distribution, which must not be modified.
+func (m *Strategy) Mean() []float64 {
+	return m.mean
+}
+
+// Sigma returns the current step size.
+func (m *Strategy) Sigma() float64 {
+	return m.sigma
+}
+
+// Next samples and evaluates a generation, adapts the distribution, and returns the current elite and fitness.
+// The samples are clamped into the bounds of the space.
+func (m *Strategy) Next() (ga.Entity, float64) {
+	n := len(m.mean)
+	z := make([]float64, n)
+	for k := range m.samples {
+		for i := range z {
+			z[i] = m.d[i] * m.rnd.NormFloat64()
+		}
+		x := make([]float64, n)
+		for i := range x {
+			y := 0.0
+			for j := range z {
+				y += m.b[i][j] * z[j]
+			}
+			x[i] = m.mean[i] + m.sigma*y
+		}
+		v := m.space.New(x)
+		m.samples[k], m.entities[k] = v.X(), v
+	}
+	m.evaluate()
+	m.evals += m.lambda
+
+	idx := make([]int, m.lambda)
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		return m.fs[idx[i]] > m.fs[idx[j]]
+	})
+	if f := m.fs[idx[0]]; m.fitness < f {
+		m.fitness, m.elite = f, m.entities[idx[0]]
+	}
+	m.update(idx[:m.mu])
+	return m.elite, m.fitness
+}
+
+// Evolve runs the strategy until the elite k generations have not changed,
+// or the max of iterations has been reached.
+func (m *Strategy) Evolve(k int, max int) (ga.Entity, float64, bool) {
+	i, fitness := 0, m.fitness
+	for j := 0; i < k && j < max; i, j = i+1, j+1 {
+		if _, f := m.Next(); fitness < f {
+			i, fitness = 0, f
+		}
+	}
+	return m.elite, fitness, i >= k
+}
+
+// evaluate evaluates the samples by the concurrent workers.
+func (m *Strategy) evaluate() {
+	n := len(m.entities)
+	nc := min(m.nc, n)
+	m.exec.Run(nc, func(c int) {
+		for i := c * n / nc; i < (c+1)*n/nc; i++ {
+			m.fs[i] = m.entities[i].Fitness()
+		}
+	})
+}
+
+// update adapts the mean, the evolution paths, the covariance matrix and the step size
+// by the selected samples idx, in the order of fitness.
+func (m *Strategy) update(idx []int) {
+	n := len(m.mean)
+	N := float64(n)
+	old := append([]float64(nil), m.mean...)
+	for i := range m.mean {
+		m.mean[i] = 0
+		for k, j := range idx {
+			m.mean[i] += m.weights[k] * m.samples[j][i]
+		}
+	}
+	y := make([]float64, n)
+	for i := range y {
+		y[i] = (m.mean[i] - old[i]) / m.sigma
+	}
+
+	a := math.Sqrt(m.cs * (2 - m.cs) * m.mueff)
+	norm := 0.0
+	for i := range m.ps {
+		w := 0.0
+		for j := range y {
+			w += m.invsqrt[i][j] * y[j]
+		}
+		m.ps[i] = (1-m.cs)*m.ps[i] + a*w
+		norm += m.ps[i] * m.ps[i]
+	}
+	norm = math.Sqrt(norm)
+	hsig := 0.0
+	if norm/math.Sqrt(1-math.Pow(1-m.cs, 2*float64(m.evals)/float64(m.lambda)))/m.chi < 1.4+2/(N+1) {
+		hsig = 1
+	}
+	a = math.Sqrt(m.cc * (2 - m.cc) * m.mueff)
+	for i := range m.pc {
+		m.pc[i] = (1-m.cc)*m.pc[i] + hsig*a*y[i]
+	}
+
+	ds := make([][]float64, len(idx))
+	for k, j := range idx {
+		ds[k] = make([]float64, n)
+		for i := range ds[k] {
+			ds[k][i] = (m.samples[j][i] - old[i]) / m.sigma
+		}
+	}
+	c0 := 1 - m.c1 - m.cmu + (1-hsig)*m.c1*m.cc*(2-m.cc)
+	for i := 0; i < n; i++ {
+		for j := 0; j <= i; j++ {
+			r := 0.0
+			for k, d := range ds {
+				r += m.weights[k] * d[i] * d[j]
+			}
+			c := c0*m.c[i][j] + m.c1*m.pc[i]*m.pc[j] + m.cmu*r
+			m.c[i][j], m.c[j][i] = c, c
+		}
+	}
+	m.sigma *= math.Exp(m.cs / m.damps * (norm/m.chi - 1))
+
+	if float64(m.evals-m.eigens) > float64(m.lambda)/(m.c1+m.cmu)/N/10 {
+		m.eigens = m.evals
+		m.decompose()
+	}
+}
+
+// decompose updates B, D and C^-1/2 by the eigendecomposition of C.
+func (m *Strategy) decompose() {
+	n := len(m.mean)
+	a := make([][]float64, n)
+	for i := range a {
+		a[i] = append([]float64(nil), m.c[i]...)
+	}
+	values, vectors := jacobi(a)
+	for i := range m.d {
+		m.d[i] = math.Sqrt(math.Max(values[i], 1e-20))
+	}
+	m.b = vectors
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			r := 0.0
+			for k := 0; k < n; k++ {
+				r += m.b[i][k] * m.b[j][k] / m.d[k]
+			}
+			m.invsqrt[i][j] = r
+		}
+	}
+}
+
+// jacobi returns the eigenvalues and the eigenvectors as the columns of the symmetric matrix a,
+// by the cyclic Jacobi method, which destroys a.
+func jacobi(a [][]float64) ([]float64, [][]float64) {
+	n := len(a)
+	v := identity(n)
+	for sweep := 0; sweep < 100; sweep++ {
+		off := 0.0
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				off += a[i][j] * a[i][j]
+			}
+		}
+		if off < 1e-30 {
+			break
+		}
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				if a[p][q] == 0 {
+					continue
+				}
+				theta := (a[q][q] - a[p][p]) / (2 * a[p][q])
+				t := 1 / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				if theta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+				for k := 0; k < n; k++ {
+					akp, akq := a[k][p], a[k][q]
+					a[k][p], a[k][q] = c*akp-s*akq, s*akp+c*akq
+				}
+				for k := 0; k < n; k++ {
+					apk, aqk := a[p][k], a[q][k]
+					a[p][k], a[q][k] = c*apk-s*aqk, s*apk+c*aqk
+				}
+				for k := 0; k < n; k++ {
+					vkp, vkq := v[k][p], v[k][q]
+					v[k][p], v[k][q] = c*vkp-s*vkq, s*vkp+c*vkq
+				}
+			}
+		}
+	}
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = a[i][i]
+	}
+	return values, v
+}
+
+func identity(n int) [][]float64 {
+	a := make([][]float64, n)
+	for i := range a {
+		a[i] = make([]float64, n)
+		a[i][i] = 1
+	}
+	return a
+}
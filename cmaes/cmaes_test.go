@@ -0,0 +1,87 @@
+package cmaes_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ofunc/ga"
+	"github.com/ofunc/ga/cmaes"
+	"github.com/ofunc/ga/vector"
+)
+
+func sphere(x []float64) float64 {
+	f := 0.0
+	for _, v := range x {
+		f -= (v - 1) * (v - 1)
+	}
+	return f
+}
+
+// ellipsoid is a rotated ill-conditioned ellipsoid, which needs the covariance to be adapted.
+func ellipsoid(x []float64) float64 {
+	f := 0.0
+	for i := range x {
+		s := 0.0
+		for j := 0; j <= i; j++ {
+			s += x[j] - 1
+		}
+		f -= math.Pow(1e4, float64(i)/float64(len(x)-1)) * s * s
+	}
+	return f
+}
+
+type driver interface {
+	Evolve(k, max int) (ga.Entity, float64, bool)
+	Elite() ga.Entity
+	Fitness() float64
+}
+
+func TestStrategy(t *testing.T) {
+	s := vector.NewSpace([]float64{-5, -5, -5}, []float64{5, 5, 5}, sphere)
+	for name, m := range map[string]driver{
+		"cmaes": cmaes.New(s, cmaes.WithSeed(1)),
+		"ga":    ga.New(100, s.Random),
+	} {
+		e, f, ok := m.Evolve(50, 1000)
+		if !ok || f < -1e-2 || e != m.Elite() || f != m.Fitness() || e.Fitness() != f {
+			t.Error(name, "result:", e, f, ok)
+		}
+		for _, x := range e.(*vector.Vector).X() {
+			if x < -5 || x > 5 {
+				t.Error(name, "out of bounds:", x)
+			}
+		}
+	}
+}
+
+func TestEllipsoid(t *testing.T) {
+	lower, upper := make([]float64, 10), make([]float64, 10)
+	for i := range lower {
+		lower[i], upper[i] = -5, 5
+	}
+	s := vector.NewSpace(lower, upper, ellipsoid)
+	m := cmaes.New(s, cmaes.WithSeed(1))
+	if _, f, _ := m.Evolve(200, 5000); f < -1e-8 {
+		t.Fatal("fitness:", f, m.Sigma())
+	}
+	for i, x := range m.Mean() {
+		if math.Abs(x-1) > 1e-3 {
+			t.Fatal("mean:", i, x)
+		}
+	}
+}
+
+func TestStrategyExecutor(t *testing.T) {
+	runs := 0
+	e := ga.ExecutorFunc(func(n int, f func(i int)) {
+		runs++
+		for i := 0; i < n; i++ {
+			f(i)
+		}
+	})
+	s := vector.NewSpace([]float64{-5, -5, -5}, []float64{5, 5, 5}, sphere)
+	m := cmaes.New(s, cmaes.WithSeed(1), cmaes.WithExecutor(e))
+	if _, f, _ := m.Evolve(50, 1000); f < -1e-2 || runs == 0 {
+		t.Fatal("executor:", f, runs)
+	}
+}
@@ -0,0 +1,279 @@
+// Command ga runs the experiments of a JSON spec on the benchmark problems, and writes the summaries as CSV.
+//
+// Usage:
+//
+//	ga [-aggregate] spec.json
+//
+// The spec is an experiment, or an array of experiments for a parameter sweep, e.g.
+//
+//	{
+//		"name": "rastrigin-100",
+//		"problem": "rastrigin",
+//		"dim": 10,
+//		"population": 100,
+//		"termination": {"generations": 1000, "stagnation": 50},
+//		"seeds": [1, 2, 3],
+//		"trace": "trace-%d.csv"
+//	}
+//
+// The problems are sphere, rastrigin, rosenbrock, ackley and schwefel of dim dimensions,
+// onemax of dim bits, and knapsack of the instance p01, p07, or random with dim items.
+// Each of the seeds, or 1 to repetitions if there are no seeds, is a run,
+// and if trace is set, each run writes the trace to the path with %d replaced by the seed,
+// in CSV if the path ends with .csv, or JSON lines otherwise.
+// The seed seeds the random source of the model, which the operators of the genomes draw from too,
+// and the random streams are derived per slot, so the runs are reproducible regardless of the parallelism.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ofunc/ga"
+	"github.com/ofunc/ga/bench"
+)
+
+// Experiment is the spec of an experiment.
+type Experiment struct {
+	Name         string      `json:"name"`
+	Problem      string      `json:"problem"`
+	Dim          int         `json:"dim"`
+	Instance     string      `json:"instance"`
+	Population   int         `json:"population"`
+	MutationRate float64     `json:"mutation_rate"`
+	Elitism      int         `json:"elitism"`
+	Termination  Termination `json:"termination"`
+	Seeds        []int64     `json:"seeds"`
+	Repetitions  int         `json:"repetitions"`
+	Trace        string      `json:"trace"`
+}
+
+// Termination is the termination of the runs, which stop when any of the set conditions is met.
+type Termination struct {
+	Generations int      `json:"generations"`
+	Evaluations int64    `json:"evaluations"`
+	Stagnation  int      `json:"stagnation"`
+	Target      *float64 `json:"target"`
+	Timeout     string   `json:"timeout"`
+}
+
+// Result is the result of a run.
+type Result struct {
+	Seed        int64
+	Generations int
+	Evaluations int64
+	Fitness     float64
+	Gap         float64
+	Elapsed     time.Duration
+}
+
+func main() {
+	aggregate := flag.Bool("aggregate", false, "write one summary per experiment instead of per run")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: ga [-aggregate] spec.json")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	xs, err := load(flag.Arg(0))
+	if err == nil {
+		err = run(xs, *aggregate, os.Stdout)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "ga:", err)
+		os.Exit(1)
+	}
+}
+
+// load reads the experiments of the spec file.
+func load(path string) ([]Experiment, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parse(b)
+}
+
+// parse parses an experiment or an array of experiments.
+func parse(b []byte) ([]Experiment, error) {
+	var xs []Experiment
+	if s := strings.TrimSpace(string(b)); strings.HasPrefix(s, "[") {
+		err := json.Unmarshal(b, &xs)
+		return xs, err
+	}
+	var x Experiment
+	err := json.Unmarshal(b, &x)
+	return append(xs, x), err
+}
+
+// run runs the experiments, and writes the summaries to w.
+func run(xs []Experiment, aggregate bool, w io.Writer) error {
+	if aggregate {
+		fmt.Fprintln(w, "name,runs,mean,std,best,worst,mean_gap,solved,mean_generations,mean_evaluations")
+	} else {
+		fmt.Fprintln(w, "name,seed,generations,evaluations,fitness,gap,seconds")
+	}
+	for i, x := range xs {
+		if x.Name == "" {
+			x.Name = x.Problem + "-" + strconv.Itoa(i)
+		}
+		rs, err := x.Run()
+		if err != nil {
+			return fmt.Errorf("%s: %v", x.Name, err)
+		}
+		if aggregate {
+			summarize(w, x.Name, rs)
+			continue
+		}
+		for _, r := range rs {
+			fmt.Fprintf(w, "%s,%d,%d,%d,%g,%g,%.3f\n", x.Name, r.Seed, r.Generations, r.Evaluations, r.Fitness, r.Gap, r.Elapsed.Seconds())
+		}
+	}
+	return nil
+}
+
+// summarize writes the aggregated summary of the results of an experiment.
+func summarize(w io.Writer, name string, rs []Result) {
+	n := float64(len(rs))
+	best, worst := math.Inf(-1), math.Inf(1)
+	var mean, mean2, gap, gens, evals float64
+	solved := 0
+	for _, r := range rs {
+		mean += r.Fitness / n
+		mean2 += r.Fitness * r.Fitness / n
+		gap += r.Gap / n
+		gens += float64(r.Generations) / n
+		evals += float64(r.Evaluations) / n
+		best, worst = math.Max(best, r.Fitness), math.Min(worst, r.Fitness)
+		if r.Gap <= 1e-6 {
+			solved++
+		}
+	}
+	std := math.Sqrt(math.Max(0, mean2-mean*mean))
+	fmt.Fprintf(w, "%s,%d,%g,%g,%g,%g,%g,%d,%g,%g\n", name, len(rs), mean, std, best, worst, gap, solved, gens, evals)
+}
+
+// problem returns the benchmark problem of the experiment.
+func (x Experiment) problem() (bench.Problem, error) {
+	d := x.Dim
+	if d <= 0 {
+		d = 10
+	}
+	switch x.Problem {
+	case "sphere":
+		return bench.Sphere.Problem(d), nil
+	case "rastrigin":
+		return bench.Rastrigin.Problem(d), nil
+	case "rosenbrock":
+		return bench.Rosenbrock.Problem(d), nil
+	case "ackley":
+		return bench.Ackley.Problem(d), nil
+	case "schwefel":
+		return bench.Schwefel.Problem(d), nil
+	case "onemax":
+		return bench.OneMax(d), nil
+	case "knapsack":
+		switch x.Instance {
+		case "", "p01":
+			return bench.P01.Problem(), nil
+		case "p07":
+			return bench.P07.Problem(), nil
+		case "random":
+			return bench.RandomKnapsack(d, 1).Problem(), nil
+		}
+		return bench.Problem{}, fmt.Errorf("unknown knapsack instance %q", x.Instance)
+	}
+	return bench.Problem{}, fmt.Errorf("unknown problem %q", x.Problem)
+}
+
+// stop returns the stop condition of the termination, default to 1000 generations.
+func (t Termination) stop() (ga.StopCondition, error) {
+	var cs []ga.StopCondition
+	if t.Generations > 0 {
+		cs = append(cs, ga.MaxGenerations(t.Generations))
+	}
+	if t.Evaluations > 0 {
+		cs = append(cs, ga.MaxEvaluations(t.Evaluations))
+	}
+	if t.Stagnation > 0 {
+		cs = append(cs, ga.Stagnation(t.Stagnation))
+	}
+	if t.Target != nil {
+		cs = append(cs, ga.Target(*t.Target))
+	}
+	if t.Timeout != "" {
+		d, err := time.ParseDuration(t.Timeout)
+		if err != nil {
+			return nil, err
+		}
+		cs = append(cs, ga.Timeout(d))
+	}
+	if len(cs) == 0 {
+		cs = append(cs, ga.MaxGenerations(1000))
+	}
+	return ga.Any(cs...), nil
+}
+
+// Run runs the experiment once per seed.
+func (x Experiment) Run() ([]Result, error) {
+	p, err := x.problem()
+	if err != nil {
+		return nil, err
+	}
+	if x.Population <= 0 {
+		return nil, errors.New("population must be positive")
+	}
+	seeds := x.Seeds
+	if len(seeds) == 0 {
+		for i := 1; i <= x.Repetitions || i == 1; i++ {
+			seeds = append(seeds, int64(i))
+		}
+	}
+	rs := make([]Result, len(seeds))
+	for i, seed := range seeds {
+		c, err := x.Termination.stop()
+		if err != nil {
+			return nil, err
+		}
+		opts := []ga.Option{ga.WithSeed(seed), ga.WithReproducibleParallel()}
+		if x.MutationRate > 0 {
+			opts = append(opts, ga.WithFixedMutationRate(x.MutationRate))
+		}
+		if x.Elitism > 0 {
+			opts = append(opts, ga.WithElitism(x.Elitism))
+		}
+		var trace *os.File
+		if x.Trace != "" {
+			if trace, err = os.Create(strings.Replace(x.Trace, "%d", strconv.FormatInt(seed, 10), -1)); err != nil {
+				return nil, err
+			}
+			format := ga.JSONLines
+			if strings.HasSuffix(x.Trace, ".csv") {
+				format = ga.CSV
+			}
+			opts = append(opts, ga.WithTrace(trace, format))
+		}
+		start := time.Now()
+		m := ga.NewCtx(x.Population, p.RandomCtx, opts...)
+		_, f := m.EvolveUntil(c)
+		s := m.Stats()
+		rs[i] = Result{seed, s.Generation, s.Evaluations, f, p.Gap(f), time.Since(start)}
+		if trace != nil {
+			if err := trace.Close(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return rs, nil
+}
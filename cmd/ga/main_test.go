@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	dir, err := os.MkdirTemp("", "ga")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	xs, err := parse([]byte(`[
+		{"problem": "sphere", "dim": 3, "population": 20, "termination": {"generations": 30}, "seeds": [1, 2], "trace": "` +
+		filepath.ToSlash(filepath.Join(dir, "trace-%d.csv")) + `"},
+		{"name": "p01", "problem": "knapsack", "population": 20, "termination": {"stagnation": 10, "target": 309}, "repetitions": 3}
+	]`))
+	if err != nil || len(xs) != 2 {
+		t.Fatal("parse:", xs, err)
+	}
+
+	var b bytes.Buffer
+	if err := run(xs, false, &b); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&b).ReadAll()
+	if err != nil || len(rows) != 6 || rows[1][0] != "sphere-0" || rows[2][1] != "2" || rows[5][0] != "p01" {
+		t.Fatal("runs:", rows, err)
+	}
+	if rows[1][2] != "30" {
+		t.Fatal("generations:", rows[1])
+	}
+	trace, err := os.ReadFile(filepath.Join(dir, "trace-2.csv"))
+	if err != nil || strings.Count(string(trace), "\n") != 32 {
+		t.Fatal("trace:", len(trace), err)
+	}
+
+	b.Reset()
+	if err := run(xs, true, &b); err != nil {
+		t.Fatal(err)
+	}
+	if rows, err = csv.NewReader(&b).ReadAll(); err != nil || len(rows) != 3 || rows[2][1] != "3" {
+		t.Fatal("aggregate:", rows, err)
+	}
+
+	xs[0].Problem = "unknown"
+	if err := run(xs, false, &b); err == nil {
+		t.Fatal("unknown problem")
+	}
+}
+
+func TestRunReproducible(t *testing.T) {
+	for _, problem := range []string{"rastrigin", "onemax"} {
+		x := Experiment{Problem: problem, Dim: 8, Population: 30, Termination: Termination{Generations: 20}, Seeds: []int64{7}}
+		a, err := x.Run()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := x.Run()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a[0].Fitness != b[0].Fitness || a[0].Evaluations != b[0].Evaluations {
+			t.Fatal(problem, "not reproducible:", a[0], b[0])
+		}
+	}
+}
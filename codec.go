@@ -0,0 +1,118 @@
+package ga
+
+import (
+	"encoding/gob"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Codec serializes entities, e.g. for the checkpoints by Save, the distributed evaluation by package remote,
+// and WritePopulation, so all the persistence agrees on one encoding.
+type Codec interface {
+	Encode(e Entity) ([]byte, error)
+	Decode(b []byte) (Entity, error)
+}
+
+// funcCodec is a Codec of the functions registered by RegisterCodec.
+type funcCodec struct {
+	marshal   func(Entity) ([]byte, error)
+	unmarshal func([]byte) (Entity, error)
+}
+
+func (c funcCodec) Encode(e Entity) ([]byte, error) {
+	return c.marshal(e)
+}
+
+func (c funcCodec) Decode(b []byte) (Entity, error) {
+	return c.unmarshal(b)
+}
+
+var codecs = struct {
+	sync.RWMutex
+	m map[string]Codec
+}{m: make(map[string]Codec)}
+
+// RegisterCodec registers the codec of the entities by marshal and unmarshal as name,
+// which is typically called in an init function of the package of the entities.
+// Like gob.RegisterName, it panics if name is already registered.
+func RegisterCodec(name string, marshal func(Entity) ([]byte, error), unmarshal func([]byte) (Entity, error)) {
+	codecs.Lock()
+	defer codecs.Unlock()
+	if _, ok := codecs.m[name]; ok {
+		panic("ga: codec registered twice: " + name)
+	}
+	codecs.m[name] = funcCodec{marshal, unmarshal}
+}
+
+// LookupCodec returns the codec registered as name, and false if there is not.
+func LookupCodec(name string) (Codec, bool) {
+	codecs.RLock()
+	defer codecs.RUnlock()
+	c, ok := codecs.m[name]
+	return c, ok
+}
+
+// lookupCodec returns the codec registered as name, or an error if there is not.
+func lookupCodec(name string) (Codec, error) {
+	c, ok := LookupCodec(name)
+	if !ok {
+		return nil, fmt.Errorf("ga: unknown codec %q", name)
+	}
+	return c, nil
+}
+
+// WithCodec sets the codec registered as name, by which Save encodes the entities,
+// instead of encoding.BinaryMarshaler. The name is saved in the checkpoint,
+// so Load decodes by the same codec without a decode function.
+// It panics if name is not registered.
+func WithCodec(name string) Option {
+	c, err := lookupCodec(name)
+	if err != nil {
+		panic(err)
+	}
+	return func(m *GA) {
+		m.codec, m.cname = c, name
+	}
+}
+
+type population struct {
+	Codec    string
+	Entities [][]byte
+}
+
+// WritePopulation writes the entities es, e.g. of Population, encoded by the codec registered as name, to w,
+// which can be read by ReadPopulation.
+func WritePopulation(w io.Writer, name string, es []Entity) error {
+	c, err := lookupCodec(name)
+	if err != nil {
+		return err
+	}
+	p := population{name, make([][]byte, len(es))}
+	for i, e := range es {
+		if p.Entities[i], err = c.Encode(e); err != nil {
+			return err
+		}
+	}
+	return gob.NewEncoder(w).Encode(p)
+}
+
+// ReadPopulation reads the entities written by WritePopulation from r, decoded by the codec of the same name,
+// e.g. to warm start a model by NewFrom or SetPopulation.
+func ReadPopulation(r io.Reader) ([]Entity, error) {
+	var p population
+	if err := gob.NewDecoder(r).Decode(&p); err != nil {
+		return nil, err
+	}
+	c, err := lookupCodec(p.Codec)
+	if err != nil {
+		return nil, err
+	}
+	es := make([]Entity, len(p.Entities))
+	for i, b := range p.Entities {
+		if es[i], err = c.Decode(b); err != nil {
+			return nil, err
+		}
+	}
+	return es, nil
+}
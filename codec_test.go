@@ -0,0 +1,71 @@
+package ga_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func init() {
+	ga.RegisterCodec("walk", func(e ga.Entity) ([]byte, error) {
+		b := make([]byte, 8)
+		binary.LittleEndian.PutUint64(b, math.Float64bits(float64(e.(Walk))))
+		return b, nil
+	}, func(b []byte) (ga.Entity, error) {
+		if len(b) != 8 {
+			return nil, errors.New("walk: bad length")
+		}
+		return Walk(math.Float64frombits(binary.LittleEndian.Uint64(b))), nil
+	})
+}
+
+func TestCodec(t *testing.T) {
+	g := func() ga.Entity {
+		return Walk(20*rand.Float64() - 10)
+	}
+	m := ga.New(20, g, ga.WithCodec("walk"))
+	m.EvolveTo(5)
+	var b bytes.Buffer
+	if err := m.Save(&b); err != nil {
+		t.Fatal(err)
+	}
+	x := ga.New(10, g, ga.WithCodec("walk"))
+	if err := x.Load(&b, nil); err != nil {
+		t.Fatal(err)
+	}
+	if x.Elite() != m.Elite() || x.Fitness() != m.Fitness() || x.Size() != 20 {
+		t.Fatal("load:", x.Elite(), m.Elite(), x.Size())
+	}
+
+	b.Reset()
+	if err := ga.WritePopulation(&b, "walk", m.Population()); err != nil {
+		t.Fatal(err)
+	}
+	es, err := ga.ReadPopulation(&b)
+	if err != nil || len(es) != 20 {
+		t.Fatal("read:", len(es), err)
+	}
+	for i, e := range m.Population() {
+		if es[i] != e {
+			t.Fatal("population:", i, es[i], e)
+		}
+	}
+
+	if _, ok := ga.LookupCodec("unknown"); ok {
+		t.Fatal("unknown codec")
+	}
+	if err := ga.WritePopulation(&b, "unknown", es); err == nil {
+		t.Fatal("unknown codec written")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("registered twice")
+		}
+	}()
+	ga.RegisterCodec("walk", nil, nil)
+}
@@ -0,0 +1,104 @@
+package ga
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"sync/atomic"
+)
+
+// PopulationSpec specifies a population of Coevolution.
+type PopulationSpec struct {
+	// N is the size of the population.
+	N int
+	// G is the generator of the entities.
+	G func() Entity
+	// Options are the options of the GA model of the population.
+	Options []Option
+}
+
+// Coevolution co-evolves several interacting populations, competitive or cooperative,
+// where the fitness of an entity is defined by pairing it with the members of the other populations.
+type Coevolution struct {
+	models  []*GA
+	specs   []PopulationSpec
+	payoff  func(i int, team []Entity) float64
+	samples int
+}
+
+// NewCoevolution creates the co-evolution of the populations, where the fitness of an entity of the population i
+// is the mean of payoff(i, team) over samples teams, and team has one entity of each population,
+// the entity itself at i, and the partners drawn uniformly from the current other populations.
+// So payoff(i, team) is the fitness of team[i] in a competitive setting, e.g. attackers against defenders,
+// or the score of the whole team in a cooperative one.
+// The initial populations are evaluated against partners made by the generators of the others.
+// Fitness of the entities is not used, and payoff is called concurrently.
+// The payoffs of a population with WithMinimize are minimized, e.g. the costs of a cooperative team.
+// The fitnesses of different generations are not comparable, so the elite of each model is the fittest of its current generation.
+func NewCoevolution(payoff func(i int, team []Entity) float64, samples int, specs ...PopulationSpec) *Coevolution {
+	if samples < 1 {
+		samples = 1
+	}
+	c := &Coevolution{
+		models:  make([]*GA, len(specs)),
+		specs:   specs,
+		payoff:  payoff,
+		samples: samples,
+	}
+	for i, s := range specs {
+		i := i
+		c.models[i] = New(s.N, s.G, append(s.Options[:len(s.Options):len(s.Options)], func(m *GA) {
+			m.relative = true
+			m.pop.eval = func(_ context.Context, e Entity) float64 {
+				atomic.AddInt64(&m.evals, 1)
+				if f := m.objective(c.fitness(i, e)); !math.IsNaN(f) {
+					return f
+				}
+				return math.Inf(-1)
+			}
+		})...)
+	}
+	return c
+}
+
+// Models returns the GA models of the populations.
+func (c *Coevolution) Models() []*GA {
+	return c.models
+}
+
+// Next produces the next generation of the populations in turn, each evaluated against the current others,
+// and returns the elites and their fitnesses.
+func (c *Coevolution) Next() ([]Entity, []float64) {
+	for _, m := range c.models {
+		m.Next()
+	}
+	return c.Elites()
+}
+
+// Elites returns the elites of the populations and their fitnesses.
+func (c *Coevolution) Elites() ([]Entity, []float64) {
+	es, fs := make([]Entity, len(c.models)), make([]float64, len(c.models))
+	for i, m := range c.models {
+		es[i], fs[i] = m.elite, m.objective(m.fitness)
+	}
+	return es, fs
+}
+
+// fitness evaluates e of the population i against the other populations.
+func (c *Coevolution) fitness(i int, e Entity) float64 {
+	sum := 0.0
+	team := make([]Entity, len(c.specs))
+	for k := 0; k < c.samples; k++ {
+		for j := range team {
+			if j == i {
+				team[j] = e
+			} else if m := c.models[j]; m != nil {
+				team[j] = m.pop.entities[rand.IntN(m.n)]
+			} else {
+				team[j] = c.specs[j].G()
+			}
+		}
+		sum += c.payoff(i, team)
+	}
+	return sum / float64(c.samples)
+}
@@ -0,0 +1,46 @@
+package ga_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestCoevolution(t *testing.T) {
+	g := func() ga.Entity {
+		return Walk(10*rand.Float64() - 5)
+	}
+	payoff := func(i int, team []ga.Entity) float64 {
+		x, y := float64(team[0].(Walk)), float64(team[1].(Walk))
+		return -sqr(x+y-4) - sqr(x-y)
+	}
+	c := ga.NewCoevolution(payoff, 3, ga.PopulationSpec{N: 50, G: g}, ga.PopulationSpec{N: 50, G: g})
+	for i := 0; i < 100; i++ {
+		c.Next()
+	}
+	es, fs := c.Elites()
+	for i, e := range es {
+		if x := float64(e.(Walk)); x < 1.5 || x > 2.5 {
+			t.Fatal("elite:", i, x, fs[i])
+		}
+	}
+	if len(c.Models()) != 2 || c.Models()[1].Elite() != es[1] {
+		t.Fatal("models")
+	}
+
+	cost := func(i int, team []ga.Entity) float64 {
+		return -payoff(i, team)
+	}
+	c = ga.NewCoevolution(cost, 3, ga.PopulationSpec{N: 50, G: g, Options: []ga.Option{ga.WithMinimize()}},
+		ga.PopulationSpec{N: 50, G: g, Options: []ga.Option{ga.WithMinimize()}})
+	for i := 0; i < 100; i++ {
+		c.Next()
+	}
+	es, fs = c.Elites()
+	for i, e := range es {
+		if x := float64(e.(Walk)); x < 1.5 || x > 2.5 || fs[i] < 0 || fs[i] != c.Models()[i].Fitness() {
+			t.Fatal("minimized elite:", i, x, fs[i])
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package ga
+
+import (
+	"math"
+	"sync/atomic"
+)
+
+// Constrained is an optional interface of Entity, which reports the violation of its constraints.
+// The entity is feasible if the violation is not greater than 0.
+// Entities not implementing Constrained are feasible.
+type Constrained interface {
+	Violation() float64
+}
+
+// Repairer is an optional interface of Entity, which repairs an infeasible entity into a feasible one.
+// Repair is called on every offspring after crossover and mutation, and should return the entity itself if it is feasible.
+type Repairer interface {
+	Repair() Entity
+}
+
+// Penalty is a penalty schedule, which returns the penalized fitness of an infeasible entity,
+// with the generation gen, the fitness f and the violation v > 0.
+type Penalty func(gen int, f, v float64) float64
+
+// WithPenalty penalizes the fitnesses of the infeasible entities by p, instead of encoding the constraints into Fitness.
+// The elite keeps the fitness it is evaluated with, so a schedule raising the penalty may keep an infeasible elite.
+func WithPenalty(p Penalty) Option {
+	return func(m *GA) {
+		m.penalty = p
+	}
+}
+
+// StaticPenalty subtracts c times the violation.
+func StaticPenalty(c float64) Penalty {
+	return func(gen int, f, v float64) float64 {
+		return f - c*v
+	}
+}
+
+// AdaptivePenalty subtracts c·growth^gen times the violation, so infeasible entities are tolerated early
+// for exploration, and are driven out as the evolution goes on.
+func AdaptivePenalty(c, growth float64) Penalty {
+	return func(gen int, f, v float64) float64 {
+		return f - c*math.Pow(growth, float64(gen))*v
+	}
+}
+
+// DeathPenalty gives the infeasible entities the fitness -Inf, so they are never selected.
+func DeathPenalty() Penalty {
+	return func(gen int, f, v float64) float64 {
+		return math.Inf(-1)
+	}
+}
+
+// InfeasibleFallback is the behavior of GA model when all entities of a generation are infeasible.
+type InfeasibleFallback int
+
+const (
+	// FitnessFallback selects by fitness as usual, the default.
+	FitnessFallback InfeasibleFallback = iota
+	// ViolationFallback selects by least violation instead of fitness, which drives the population toward feasibility.
+	ViolationFallback
+	// RegenerateFallback regenerates the whole population by the generator, which injects diversity.
+	RegenerateFallback
+)
+
+// WithInfeasibleFallback sets the behavior when all entities of a generation are infeasible.
+func WithInfeasibleFallback(mode InfeasibleFallback) Option {
+	return func(m *GA) {
+		m.fallback = mode
+	}
+}
+
+// infeasible returns the violations of the population, or nil if any entity is feasible.
+func (m *GA) infeasible() []float64 {
+	vs := make([]float64, m.n)
+	var feasible int32
+	m.do(func(c, i int) {
+		if e, ok := m.pop.entities[i].(Constrained); ok {
+			vs[i] = e.Violation()
+		}
+		if vs[i] <= 0 {
+			atomic.StoreInt32(&feasible, 1)
+		}
+	})
+	if feasible != 0 {
+		return nil
+	}
+	return vs
+}
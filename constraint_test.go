@@ -0,0 +1,111 @@
+package ga_test
+
+import (
+	"math/rand"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+// Far must satisfy X >= 10, but its fitness prefers smaller X.
+type Far float64
+
+func (x Far) Fitness() float64 {
+	return -float64(x)
+}
+
+func (x Far) Violation() float64 {
+	return 10 - float64(x)
+}
+
+func (x Far) Mutate() ga.Entity {
+	return x + Far(rand.Float64()-0.5)
+}
+
+func (x Far) Crossover(e ga.Entity, w float64) ga.Entity {
+	return Far(w*float64(x) + (1-w)*float64(e.(Far)))
+}
+
+func TestInfeasibleFallback(t *testing.T) {
+	g := func() ga.Entity {
+		return Far(rand.Float64())
+	}
+	mean := func(m *ga.GA) float64 {
+		return -m.SnapshotStats().Mean
+	}
+
+	m := ga.New(50, g)
+	m.Evolve(20, 20)
+	if x := mean(m); x > 0.5 {
+		t.Fatal("fitness:", x)
+	}
+
+	m = ga.New(50, g, ga.WithInfeasibleFallback(ga.ViolationFallback))
+	m.Evolve(20, 20)
+	if x := mean(m); x < 0.5 {
+		t.Fatal("violation:", x)
+	}
+
+	var n int32
+	m = ga.New(10, func() ga.Entity {
+		atomic.AddInt32(&n, 1)
+		return g()
+	}, ga.WithInfeasibleFallback(ga.RegenerateFallback))
+	if n != 20 {
+		t.Fatal("regenerate:", n)
+	}
+}
+
+func TestPenalty(t *testing.T) {
+	g := func() ga.Entity {
+		return Far(20 * rand.Float64())
+	}
+	for name, p := range map[string]ga.Penalty{
+		"static":   ga.StaticPenalty(100),
+		"adaptive": ga.AdaptivePenalty(2, 1.05),
+		"death":    ga.DeathPenalty(),
+	} {
+		m := ga.New(100, g, ga.WithPenalty(p))
+		m.EvolveTo(100)
+		if f := m.SnapshotStats().Best; f < -10.5 || f > -10 {
+			t.Error(name, "best:", f)
+		}
+	}
+}
+
+// Fixed is Far, repaired into feasibility.
+type Fixed struct {
+	Far
+}
+
+func (x Fixed) Mutate() ga.Entity {
+	return Fixed{x.Far.Mutate().(Far)}
+}
+
+func (x Fixed) Crossover(e ga.Entity, w float64) ga.Entity {
+	return Fixed{x.Far.Crossover(e.(Fixed).Far, w).(Far)}
+}
+
+func (x Fixed) Repair() ga.Entity {
+	if x.Far < 10 {
+		return Fixed{10}
+	}
+	return x
+}
+
+func TestRepair(t *testing.T) {
+	m := ga.New(50, func() ga.Entity {
+		return Fixed{Far(10 + rand.Float64())}
+	})
+	for i := 1; i <= 10; i++ {
+		for _, e := range m.EvolveTo(i) {
+			if x := e.(Fixed).Far; x < 10 {
+				t.Fatal("infeasible:", i, x)
+			}
+		}
+	}
+	if f := m.Fitness(); f != -10 {
+		t.Fatal("fitness:", f)
+	}
+}
@@ -0,0 +1,31 @@
+package ga
+
+import "context"
+
+// NextContext produces the next generation like Next, unless ctx is done.
+// It checks ctx before the generation, so a generation already started always completes,
+// but its evaluations are aborted once ctx is done, like by WithGenerationDeadline, see FitnessContext.
+func (m *GA) NextContext(ctx context.Context) (Entity, float64, error) {
+	if err := ctx.Err(); err != nil {
+		return m.elite, m.objective(m.fitness), err
+	}
+	if ctx.Done() != nil {
+		prev := m.pop.ctx
+		m.pop.ctx = ctx
+		defer func() {
+			m.pop.ctx = prev
+		}()
+	}
+	e, f := m.Next()
+	return e, f, nil
+}
+
+// EvolveContext runs the GA model like Evolve, until ctx is done.
+// If ctx is done first, it returns the elite and fitness so far, with the error of ctx.
+func (m *GA) EvolveContext(ctx context.Context, k int, max int) (Entity, float64, bool, error) {
+	r := m.Generations(ctx, k, max)
+	for r.Next() {
+	}
+	e, f, ok := r.Result()
+	return e, f, ok, r.Err()
+}
@@ -0,0 +1,54 @@
+package ga_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ofunc/ga"
+)
+
+func TestEvolveContext(t *testing.T) {
+	m := ga.New(20, MIN{}.Mutate)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	n := m.SnapshotStats().Generation
+	if _, _, err := m.NextContext(ctx); err != context.Canceled {
+		t.Fatal("next:", err)
+	}
+	if _, _, ok, err := m.EvolveContext(ctx, 10, 100); ok || err != context.Canceled {
+		t.Fatal("evolve:", ok, err)
+	}
+	if g := m.SnapshotStats().Generation; g != n {
+		t.Fatal("generations:", g-n)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, _, _, err := m.EvolveContext(ctx, 1<<30, 1<<30); err != context.DeadlineExceeded {
+		t.Fatal("deadline:", err)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Fatal("elapsed:", d)
+	}
+
+	if _, _, ok, err := m.EvolveContext(context.Background(), 5, 1000); !ok || err != nil {
+		t.Fatal("background:", ok, err)
+	}
+}
+
+func TestEvolveContextAbort(t *testing.T) {
+	m := ga.New(20, func() ga.Entity {
+		return Abortable{Slow{MIN{}.Mutate().(MIN), false}}
+	}, ga.WithSequentialEval())
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, _, _, err := m.EvolveContext(ctx, 1<<30, 1<<30); err != context.DeadlineExceeded {
+		t.Fatal("deadline:", err)
+	}
+	if d := time.Since(start); d > 500*time.Millisecond {
+		t.Fatal("elapsed:", d)
+	}
+}
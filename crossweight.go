@@ -0,0 +1,41 @@
+package ga
+
+// WeightlessCrossover is an optional interface of Entity, whose crossover takes no weight.
+// The model calls Crossover2 instead of Crossover, so the genome is not coupled to the selection weights.
+type WeightlessCrossover interface {
+	Crossover2(Entity) Entity
+}
+
+// CrossoverWeight is the weight passed to Crossover.
+type CrossoverWeight int
+
+const (
+	// SelectionWeight is the relative selection weight of the parent, the default.
+	// It depends on the scaling and the selection strategy, and on the WeightConvention.
+	SelectionWeight CrossoverWeight = iota
+	// EvenWeight is always 1/2.
+	EvenWeight
+	// RandomWeight is uniformly random in [0, 1).
+	RandomWeight
+)
+
+// WithCrossoverWeight sets the weight passed to Crossover.
+func WithCrossoverWeight(w CrossoverWeight) Option {
+	return func(m *GA) {
+		m.cweight = w
+	}
+}
+
+// crossweight returns the weight passed to Crossover, from the selection weight w.
+func (m *GA) crossweight(w float64, u func() float64) float64 {
+	switch m.cweight {
+	case EvenWeight:
+		return 0.5
+	case RandomWeight:
+		return u()
+	}
+	if m.convention == OtherWeight {
+		return 1 - w
+	}
+	return w
+}
@@ -0,0 +1,52 @@
+package ga
+
+// WithDeterministicCrowding switches the replacement of GA model to deterministic crowding with the distance dist between entities.
+// The population is paired at random, each pair produces two offspring by crossover and mutation,
+// and each offspring competes against the more similar parent, which it replaces if it is not less fit.
+// So the offspring only compete within their niches, which preserves the optima of multimodal problems.
+// There is no selection by fitness, and elitism is implied.
+func WithDeterministicCrowding(dist func(x, y Entity) float64) Option {
+	return func(m *GA) {
+		m.crowding = dist
+	}
+}
+
+// crowd produces the next generation by deterministic crowding into the previous generation's buffers.
+func (m *GA) crowd() {
+	p, n := &m.pop, m.n
+	pairs := m.rnd.Perm(n)
+	// The offspring of a pair are crossed with the same weight of the receivers, so they are complementary.
+	mates, ws := make([]int, n), make([]float64, n)
+	for k := 0; k < n; k += 2 {
+		// The odd one out crosses with a random mate, and competes against its parent only.
+		i, j, w := pairs[k], pairs[0], 0.5
+		if k+1 < n {
+			j = pairs[k+1]
+		}
+		if s := p.weights[i] + p.weights[j]; s > 0 {
+			w = p.weights[i] / s
+		}
+		mates[i], ws[i] = j, w
+		if k+1 < n {
+			mates[j], ws[j] = i, w
+		}
+	}
+	m.fork()
+	m.do(func(c, i int) {
+		m.tentities[i] = m.vary(i, p.entities[i], p.entities[mates[i]], ws[i], m.random(c, i))
+	})
+	p.evaluateInto(m.tentities, m.tfitnesses)
+	es, fs := m.tentities, m.tfitnesses
+	d := m.crowding
+	for k := 0; k+1 < n; k += 2 {
+		i, j := pairs[k], pairs[k+1]
+		if d(p.entities[i], es[i])+d(p.entities[j], es[j]) > d(p.entities[i], es[j])+d(p.entities[j], es[i]) {
+			es[i], es[j], fs[i], fs[j] = es[j], es[i], fs[j], fs[i]
+		}
+	}
+	for i, f := range fs {
+		if f < p.fitnesses[i] {
+			es[i], fs[i] = p.entities[i], p.fitnesses[i]
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package ga_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+// Peaks has the peaks of Twin, with the arithmetic crossover.
+type Peaks float64
+
+func (x Peaks) Fitness() float64 {
+	return Twin(x).Fitness()
+}
+
+func (x Peaks) Mutate() ga.Entity {
+	return x + Peaks(rand.NormFloat64()/10)
+}
+
+func (x Peaks) Crossover(e ga.Entity, w float64) ga.Entity {
+	return Peaks(w)*x + Peaks(1-w)*e.(Peaks)
+}
+
+func TestDeterministicCrowding(t *testing.T) {
+	dist := func(x, y ga.Entity) float64 {
+		return math.Abs(float64(x.(Peaks) - y.(Peaks)))
+	}
+	for _, n := range []int{100, 101} {
+		m := ga.New(n, func() ga.Entity {
+			return Peaks(8*rand.Float64() - 4)
+		}, ga.WithDeterministicCrowding(dist), ga.WithFixedMutationRate(0.5))
+		prev := m.Fitness()
+		left, right := 0, 0
+		for i := 0; i < 100; i++ {
+			if _, f := m.Next(); f < prev {
+				t.Fatal("lost the elite:", prev, f)
+			} else {
+				prev = f
+			}
+		}
+		for _, e := range m.Population() {
+			switch x := float64(e.(Peaks)); {
+			case math.Abs(x+2) < 0.5:
+				left++
+			case math.Abs(x-2) < 0.5:
+				right++
+			}
+		}
+		if left < 30 || right < 30 || prev < -0.01 {
+			t.Fatal("niches:", n, left, right, prev)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package ga
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+	"sort"
+)
+
+type cycles struct {
+	hashes []uint64
+	gens   []int
+	next   int
+	f      func(gen, period int) bool
+}
+
+// WithCycleDetection detects populations oscillating between states without progress.
+// Each generation, the state is hashed, and compared with the states of the last window generations.
+// The state is the sorted keys if all entities implement Keyer, or the sorted fitnesses otherwise.
+// When a state repeats, f is called with the generation number and the period of the cycle,
+// and if f returns true, Evolve stops and reports convergence.
+// For discrete fitnesses without keys, distinct populations may share the same fitnesses,
+// so false positives are possible; for continuous fitnesses, exact repetitions are rare.
+func WithCycleDetection(window int, f func(gen, period int) bool) Option {
+	return func(m *GA) {
+		m.cycles = &cycles{hashes: make([]uint64, window), gens: make([]int, window), f: f}
+		for i := range m.cycles.gens {
+			m.cycles.gens[i] = -1
+		}
+	}
+}
+
+func (m *GA) detect() {
+	c := m.cycles
+	if c == nil || len(c.hashes) == 0 {
+		return
+	}
+	h := m.hash()
+	for i, x := range c.hashes {
+		if c.gens[i] >= 0 && x == h {
+			if c.f(m.gen, m.gen-c.gens[i]) {
+				m.halt = true
+			}
+			break
+		}
+	}
+	c.hashes[c.next], c.gens[c.next] = h, m.gen
+	c.next = (c.next + 1) % len(c.hashes)
+}
+
+// hash hashes the state of the population.
+func (m *GA) hash() uint64 {
+	h := fnv.New64a()
+	keys := make([]string, 0, m.n)
+	for _, e := range m.pop.entities {
+		if k, ok := e.(Keyer); ok {
+			keys = append(keys, k.Key())
+		}
+	}
+	if len(keys) == m.n {
+		sort.Strings(keys)
+		for _, k := range keys {
+			h.Write([]byte(k))
+			h.Write([]byte{0})
+		}
+		return h.Sum64()
+	}
+	fs := append([]float64(nil), m.pop.fitnesses...)
+	sort.Float64s(fs)
+	var b [8]byte
+	for _, f := range fs {
+		binary.LittleEndian.PutUint64(b[:], math.Float64bits(f))
+		h.Write(b[:])
+	}
+	return h.Sum64()
+}
@@ -0,0 +1,38 @@
+package ga_test
+
+import (
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+type Flip int
+
+func (x Flip) Fitness() float64 {
+	return float64(x)
+}
+
+func (x Flip) Mutate() ga.Entity {
+	return x
+}
+
+func (x Flip) Crossover(e ga.Entity, w float64) ga.Entity {
+	return 1 - x
+}
+
+func TestCycleDetection(t *testing.T) {
+	gen, period := 0, 0
+	m := ga.New(10, func() ga.Entity {
+		return Flip(0)
+	}, ga.WithCycleDetection(5, func(g, p int) bool {
+		gen, period = g, p
+		return true
+	}))
+	_, _, ok := m.Evolve(100, 100)
+	if !ok || gen != 2 || period != 2 {
+		t.Fatal("cycle:", ok, gen, period)
+	}
+	if s := m.SnapshotStats(); s.Generation != 2 {
+		t.Fatal("generation:", s.Generation)
+	}
+}
@@ -0,0 +1,75 @@
+package ga
+
+// Combiner is an entity which supports the variation of differential evolution.
+type Combiner interface {
+	Entity
+	// Combine returns the trial entity, which is the mutant a + F*(b-c) crossed with this entity at rate CR.
+	Combine(a, b, c Entity, F, CR float64) Entity
+}
+
+// WithDifferentialEvolution switches the variation of GA model to differential evolution, DE/rand/1 by default,
+// with the differential weight F and the crossover rate CR.
+// Each entity is combined with three distinct random entities into a trial entity,
+// which replaces it in the next generation if it is not less fit.
+// The entities must implement Combiner, and the population size must be at least 4.
+// Crossover, Mutate and the mutation probability are not used.
+func WithDifferentialEvolution(F, CR float64) Option {
+	return func(m *GA) {
+		m.de, m.df, m.dcr = true, F, CR
+	}
+}
+
+// DEStrategy is the strategy of differential evolution, which chooses the base vector of the mutant.
+type DEStrategy int
+
+const (
+	// DERand1 is DE/rand/1/bin, where the base is a random entity, the default.
+	// It explores well and is robust on multimodal problems.
+	DERand1 DEStrategy = iota
+	// DEBest1 is DE/best/1/bin, where the base is the fittest entity of the generation.
+	// It converges faster, but is more prone to premature convergence.
+	DEBest1
+)
+
+// WithDEStrategy sets the strategy of differential evolution, see WithDifferentialEvolution.
+func WithDEStrategy(s DEStrategy) Option {
+	return func(m *GA) {
+		m.dstrategy = s
+	}
+}
+
+// differ produces the next generation by differential evolution into the previous generation's buffers.
+func (m *GA) differ() {
+	m.fork()
+	p := &m.pop
+	m.do(func(c, i int) {
+		a, b, d := m.distinct(i, m.random(c, i))
+		if m.dstrategy == DEBest1 && p.ibest >= 0 {
+			a = p.ibest
+		}
+		m.tentities[i] = p.entities[i].(Combiner).Combine(p.entities[a], p.entities[b], p.entities[d], m.df, m.dcr)
+		if r, ok := m.tentities[i].(Repairer); ok {
+			m.tentities[i] = r.Repair()
+		}
+	})
+	p.evaluateInto(m.tentities, m.tfitnesses)
+	for i, f := range m.tfitnesses {
+		if f < p.fitnesses[i] {
+			m.tentities[i], m.tfitnesses[i] = p.entities[i], p.fitnesses[i]
+		}
+	}
+}
+
+// distinct returns three distinct random indices other than i.
+func (m *GA) distinct(i int, u func() float64) (int, int, int) {
+	var xs [3]int
+	for k := 0; k < len(xs); {
+		x := int(u() * float64(m.n))
+		if x == i || x >= m.n || (k > 0 && x == xs[0]) || (k > 1 && x == xs[1]) {
+			continue
+		}
+		xs[k] = x
+		k++
+	}
+	return xs[0], xs[1], xs[2]
+}
@@ -0,0 +1,63 @@
+package ga_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+type Vec []float64
+
+func (v Vec) Fitness() float64 {
+	s := 0.0
+	for _, x := range v {
+		s -= sqr(x - 1)
+	}
+	return s
+}
+
+func (v Vec) Mutate() ga.Entity {
+	u := make(Vec, len(v))
+	for i := range u {
+		u[i] = 10*rand.Float64() - 5
+	}
+	return u
+}
+
+func (v Vec) Crossover(e ga.Entity, w float64) ga.Entity {
+	a, u := e.(Vec), make(Vec, len(v))
+	for i := range u {
+		u[i] = w*v[i] + (1-w)*a[i]
+	}
+	return u
+}
+
+func (v Vec) Combine(a, b, c ga.Entity, F, CR float64) ga.Entity {
+	x, y, z, u := a.(Vec), b.(Vec), c.(Vec), make(Vec, len(v))
+	k := rand.Intn(len(v))
+	for i := range u {
+		if i == k || rand.Float64() < CR {
+			u[i] = x[i] + F*(y[i]-z[i])
+		} else {
+			u[i] = v[i]
+		}
+	}
+	return u
+}
+
+func TestDifferentialEvolution(t *testing.T) {
+	m := ga.New(50, make(Vec, 5).Mutate, ga.WithDifferentialEvolution(0.5, 0.9))
+	e, f, _ := m.Evolve(30, 300)
+	if f < -1e-4 {
+		t.Fatal("fitness(0):", f, e)
+	}
+}
+
+func TestDEBest1(t *testing.T) {
+	m := ga.New(50, make(Vec, 5).Mutate, ga.WithDifferentialEvolution(0.5, 0.9), ga.WithDEStrategy(ga.DEBest1))
+	e, f, _ := m.Evolve(30, 300)
+	if f < -1e-4 {
+		t.Fatal("fitness(0):", f, e)
+	}
+}
@@ -0,0 +1,94 @@
+package ga
+
+import (
+	"context"
+	"math"
+	"sync/atomic"
+	"time"
+)
+
+// WithGenerationDeadline bounds the time of the evaluations of each generation by d.
+// After d, the evaluations still running or not started are abandoned,
+// and their entities get the fitness -Inf, so they are never selected.
+// The statistics of the generation are computed from the other entities.
+// Go cannot interrupt a running Fitness, so it keeps running in the background until it returns,
+// and then its result is discarded, unless the entity implements FitnessContext to abort it.
+func WithGenerationDeadline(d time.Duration) Option {
+	return func(m *GA) {
+		m.pop.deadline = d
+	}
+}
+
+// FitnessContext is an entity whose evaluation can be aborted.
+// It is evaluated by FitnessContext instead of Fitness, with a context which is done
+// when the generation deadline set by WithGenerationDeadline expires, the budget of EvolveFor is exhausted,
+// or the context of NextContext, EvolveContext or Generations is done.
+// Then it should return as soon as possible, and its result is discarded.
+// The fitness cache and the oracle still evaluate it by Fitness, so that aborted results are never cached.
+type FitnessContext interface {
+	Entity
+	FitnessContext(ctx context.Context) float64
+}
+
+// EvolveFor runs the GA model until d has elapsed, and returns the elite and fitness.
+// The evaluations of the last generation are aborted when d elapses, like WithGenerationDeadline,
+// so EvolveFor returns shortly after d, with the best-effort elite.
+func (m *GA) EvolveFor(d time.Duration) (Entity, float64) {
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+	m.pop.ctx = ctx
+	defer func() {
+		m.pop.ctx = nil
+	}()
+	m.halt = false
+	for ctx.Err() == nil && !m.halt {
+		m.Next()
+	}
+	return m.elite, m.objective(m.fitness)
+}
+
+// evaluate evaluates the fitnesses of es into fs by run,
+// within the deadline d if d > 0, and until ctx is done if ctx is not nil.
+func evaluate(es []Entity, fs []float64, eval func(context.Context, Entity) float64, ctx context.Context, d time.Duration, run func(n int, f func(c, i int))) {
+	if d <= 0 && ctx == nil {
+		run(len(es), func(c, i int) {
+			fs[i] = eval(context.Background(), es[i])
+		})
+		return
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if d > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d)
+		defer cancel()
+	}
+
+	es = append([]Entity(nil), es...)
+	bits, done := make([]uint64, len(es)), make([]int32, len(es))
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		run(len(es), func(c, i int) {
+			if ctx.Err() != nil {
+				return
+			}
+			if f := eval(ctx, es[i]); ctx.Err() == nil {
+				atomic.StoreUint64(&bits[i], math.Float64bits(f))
+				atomic.StoreInt32(&done[i], 1)
+			}
+		})
+	}()
+	select {
+	case <-finished:
+	case <-ctx.Done():
+	}
+	for i := range fs {
+		if atomic.LoadInt32(&done[i]) != 0 {
+			fs[i] = math.Float64frombits(atomic.LoadUint64(&bits[i]))
+		} else {
+			fs[i] = math.Inf(-1)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package ga_test
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ofunc/ga"
+)
+
+type Slow struct {
+	MIN
+	Slow bool
+}
+
+func (s Slow) Fitness() float64 {
+	if s.Slow {
+		time.Sleep(200 * time.Millisecond)
+	}
+	return s.MIN.Fitness()
+}
+
+func (s Slow) Mutate() ga.Entity {
+	return Slow{s.MIN.Mutate().(MIN), rand.Intn(10) == 0}
+}
+
+func (s Slow) Crossover(e ga.Entity, w float64) ga.Entity {
+	return Slow{s.MIN.Crossover(e.(Slow).MIN, w).(MIN), s.Slow}
+}
+
+func TestGenerationDeadline(t *testing.T) {
+	start := time.Now()
+	m := ga.New(50, Slow{}.Mutate, ga.WithGenerationDeadline(20*time.Millisecond))
+	m.Next()
+	m.Next()
+	if d := time.Since(start); d > 150*time.Millisecond {
+		t.Fatal("deadline:", d)
+	}
+	if e, ok := m.Elite().(Slow); ok && e.Slow {
+		t.Fatal("elite is slow")
+	}
+	s := m.SnapshotStats()
+	if math.IsInf(s.Mean, 0) || math.IsNaN(s.Mean) || math.IsNaN(s.Std) {
+		t.Fatal("stats:", s)
+	}
+}
+
+var aborted int32
+
+// Abortable is Slow, aborting the slow evaluations by the context.
+type Abortable struct {
+	Slow
+}
+
+func (a Abortable) FitnessContext(ctx context.Context) float64 {
+	if a.Slow.Slow {
+		select {
+		case <-time.After(time.Second):
+		case <-ctx.Done():
+			atomic.AddInt32(&aborted, 1)
+		}
+	}
+	return a.MIN.Fitness()
+}
+
+func (a Abortable) Mutate() ga.Entity {
+	return Abortable{a.Slow.Mutate().(Slow)}
+}
+
+func (a Abortable) Crossover(e ga.Entity, w float64) ga.Entity {
+	return Abortable{a.Slow.Crossover(e.(Abortable).Slow, w).(Slow)}
+}
+
+func TestEvolveFor(t *testing.T) {
+	m := ga.New(50, func() ga.Entity {
+		return Abortable{Slow{MIN{}.Mutate().(MIN), false}}
+	})
+	atomic.StoreInt32(&aborted, 0)
+	start := time.Now()
+	e, f := m.EvolveFor(50 * time.Millisecond)
+	if d := time.Since(start); d > 500*time.Millisecond {
+		t.Fatal("budget:", d)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if atomic.LoadInt32(&aborted) == 0 {
+		t.Fatal("not aborted")
+	}
+	if e.(Abortable).Slow.Slow || math.IsInf(f, 0) || f != m.Fitness() {
+		t.Fatal("elite:", e, f)
+	}
+}
+
+// Late is a Far whose evaluation outlives the generation deadline.
+type Late struct {
+	Far
+}
+
+func (x Late) Fitness() float64 {
+	time.Sleep(2 * time.Millisecond)
+	return x.Far.Fitness()
+}
+
+func (x Late) Mutate() ga.Entity {
+	return Late{x.Far.Mutate().(Far)}
+}
+
+func (x Late) Crossover(e ga.Entity, w float64) ga.Entity {
+	return Late{x.Far.Crossover(e.(Late).Far, w).(Far)}
+}
+
+func TestGenerationDeadlinePenalty(t *testing.T) {
+	m := ga.New(10, func() ga.Entity {
+		return Late{Far(rand.Float64())}
+	}, ga.WithGenerationDeadline(time.Millisecond), ga.WithPenalty(ga.StaticPenalty(1)))
+	for i := 0; i < 20; i++ {
+		m.Next()
+	}
+	time.Sleep(10 * time.Millisecond)
+}
@@ -0,0 +1,38 @@
+package ga
+
+// WithNoDuplicates suppresses the duplicate offspring, where hash returns the hash of the genome of an entity,
+// and the entities with the same hash are identical.
+// An offspring identical to a preceding entity of the next generation, or of the population it joins
+// in the steady-state mode, is mutated again, up to 10 times, before it is evaluated,
+// so the evaluations are not wasted on clones.
+func WithNoDuplicates(hash func(Entity) uint64) Option {
+	return func(m *GA) {
+		m.dedup = hash
+	}
+}
+
+// unique mutates the offspring es[from:] again, which duplicate the preceding entities of es,
+// or the population if joining.
+func (m *GA) unique(es []Entity, from int, joining bool) {
+	seen := make(map[uint64]bool, len(es))
+	if joining {
+		for _, e := range m.pop.entities {
+			seen[m.dedup(e)] = true
+		}
+	}
+	for _, e := range es[:from] {
+		seen[m.dedup(e)] = true
+	}
+	for i := from; i < len(es); i++ {
+		e := es[i]
+		h := m.dedup(e)
+		for t := 0; seen[h] && t < 10; t++ {
+			e = m.mutate(i, e, false, m.rnd.Float64)
+			if r, ok := e.(Repairer); ok {
+				e = r.Repair()
+			}
+			h = m.dedup(e)
+		}
+		es[i], seen[h] = e, true
+	}
+}
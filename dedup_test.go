@@ -0,0 +1,50 @@
+package ga_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+// Grid is an integer maximized at 500.
+type Grid int
+
+func (x Grid) Fitness() float64 {
+	return -sqr(float64(x - 500))
+}
+
+func (x Grid) Mutate() ga.Entity {
+	return x + Grid(rand.Intn(41)-20)
+}
+
+func (x Grid) Crossover(e ga.Entity, w float64) ga.Entity {
+	if rand.Float64() < w {
+		return x
+	}
+	return e
+}
+
+func TestNoDuplicates(t *testing.T) {
+	distinct := func(opts ...ga.Option) int {
+		m := ga.New(50, func() ga.Entity {
+			return Grid(rand.Intn(1000))
+		}, opts...)
+		seen := make(map[ga.Entity]bool)
+		for _, e := range m.EvolveTo(50) {
+			seen[e] = true
+		}
+		return len(seen)
+	}
+	hash := func(e ga.Entity) uint64 {
+		return uint64(e.(Grid))
+	}
+	with, without := distinct(ga.WithNoDuplicates(hash)), distinct()
+	if with < 45 || with <= without {
+		t.Fatal("distinct:", with, without)
+	}
+	with, without = distinct(ga.WithNoDuplicates(hash), ga.WithSteadyState(0.2)), distinct(ga.WithSteadyState(0.2))
+	if with <= without {
+		t.Fatal("steady-state distinct:", with, without)
+	}
+}
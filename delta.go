@@ -0,0 +1,39 @@
+package ga
+
+// DeltaEntity is an optional interface of Entity, whose fitness can be computed incrementally from its parent,
+// e.g. a large genome whose full scoring is O(n) while the change by a mutation is scored in O(1).
+// An offspring mutated from its parent without crossover is evaluated by DeltaFitness of the parent instead of Fitness,
+// so it takes effect on the offspring not crossed, e.g. by WithCrossoverRate below 1.
+// The offspring must be of a comparable type, e.g. a pointer, to be matched with its parent.
+type DeltaEntity interface {
+	Entity
+	// DeltaFitness returns the fitness of the receiver, computed incrementally from parent, which it is mutated from.
+	DeltaFitness(parent Entity) float64
+}
+
+// derive records that z is mutated from the parent x, so it is evaluated by DeltaFitness.
+func (m *GA) derive(z, x Entity) {
+	if _, ok := z.(DeltaEntity); !ok || !comparable(z) || z == x {
+		return
+	}
+	m.dmutex.Lock()
+	if m.derived == nil {
+		m.derived = make(map[Entity]Entity)
+	}
+	m.derived[z] = x
+	m.dmutex.Unlock()
+}
+
+// parentOf returns the parent recorded for e by derive, and forgets it.
+func (m *GA) parentOf(e Entity) (Entity, bool) {
+	if _, ok := e.(DeltaEntity); !ok || !comparable(e) {
+		return nil, false
+	}
+	m.dmutex.Lock()
+	defer m.dmutex.Unlock()
+	x, ok := m.derived[e]
+	if ok {
+		delete(m.derived, e)
+	}
+	return x, ok
+}
@@ -0,0 +1,55 @@
+package ga_test
+
+import (
+	"math/rand/v2"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+// Scored is a pointer to Walk, which caches its fitness for the incremental evaluation.
+type Scored struct {
+	W     Walk
+	score float64
+}
+
+var full, deltas int32
+
+func (s *Scored) Fitness() float64 {
+	atomic.AddInt32(&full, 1)
+	s.score = s.W.Fitness()
+	return s.score
+}
+
+func (s *Scored) DeltaFitness(parent ga.Entity) float64 {
+	atomic.AddInt32(&deltas, 1)
+	p := parent.(*Scored)
+	// -(w-1)^2 = -(v-1)^2 - (w-v)(w+v-2)
+	w, v := float64(s.W), float64(p.W)
+	s.score = p.score - (w-v)*(w+v-2)
+	return s.score
+}
+
+func (s *Scored) Mutate() ga.Entity {
+	return &Scored{W: s.W.Mutate().(Walk)}
+}
+
+func (s *Scored) Crossover(e ga.Entity, w float64) ga.Entity {
+	return &Scored{W: s.W.Crossover(e.(*Scored).W, w).(Walk)}
+}
+
+func TestDeltaFitness(t *testing.T) {
+	atomic.StoreInt32(&full, 0)
+	atomic.StoreInt32(&deltas, 0)
+	m := ga.New(30, func() ga.Entity {
+		return &Scored{W: Walk(rand.Float64()*4 - 2)}
+	}, ga.WithCrossoverRate(0.5), ga.WithFixedMutationRate(1))
+	m.EvolveTo(50)
+	if d := atomic.LoadInt32(&deltas); d < 500 || int64(d)+int64(atomic.LoadInt32(&full)) != m.Evaluations() {
+		t.Fatal("evaluations:", d, full, m.Evaluations())
+	}
+	if e := m.Elite().(*Scored); m.Fitness() < -1e-2 || m.Fitness()-e.W.Fitness() > 1e-9 || e.W.Fitness()-m.Fitness() > 1e-9 {
+		t.Fatal("fitness:", m.Fitness(), e.W.Fitness())
+	}
+}
@@ -0,0 +1,24 @@
+package ga
+
+import "fmt"
+
+// Describer is an optional interface of Entity, which describes the entity in a human-readable form.
+type Describer interface {
+	Describe() string
+}
+
+// Describe returns a human-readable description of the entity e.
+// It uses Describe if e implements Describer, String if e implements fmt.Stringer,
+// and the default format of fmt otherwise.
+func Describe(e Entity) string {
+	switch x := e.(type) {
+	case nil:
+		return "<nil>"
+	case Describer:
+		return x.Describe()
+	case fmt.Stringer:
+		return x.String()
+	default:
+		return fmt.Sprintf("%v", e)
+	}
+}
@@ -0,0 +1,27 @@
+package ga_test
+
+import (
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+type Described struct {
+	MIN
+}
+
+func (d Described) Describe() string {
+	return "described"
+}
+
+func TestDescribe(t *testing.T) {
+	if s := ga.Describe(Described{}); s != "described" {
+		t.Fatal("Describer:", s)
+	}
+	if s := ga.Describe(MIN{1, 2}); s != "{1 2}" {
+		t.Fatal("default:", s)
+	}
+	if s := ga.Describe(nil); s != "<nil>" {
+		t.Fatal("nil:", s)
+	}
+}
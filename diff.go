@@ -0,0 +1,90 @@
+package ga
+
+import (
+	"math"
+	"sort"
+)
+
+// PopDiff is the difference between two populations a and b, where each shift is of b relative to a.
+type PopDiff struct {
+	// MeanShift is the shift of the mean fitness.
+	MeanShift float64
+	// MedianShift is the shift of the median fitness.
+	MedianShift float64
+	// PercentileShifts are the shifts of the 10th, 25th, 50th, 75th and 90th percentiles of fitnesses.
+	PercentileShifts [5]float64
+	// Overlap is the Jaccard index of the genotypes identified by Keyer,
+	// or NaN if any entity does not implement Keyer.
+	Overlap float64
+}
+
+// DiffPopulations compares the populations a and b with the fitnesses fa and fb, e.g. for A/B experiments.
+// If fa or fb is nil, the fitnesses are evaluated in parallel by the worker pool shared by all GA models,
+// as there is no model of which the executor set by WithExecutor could be used.
+func DiffPopulations(a, b []Entity, fa, fb []float64) PopDiff {
+	sa, sb := sorted(a, fa), sorted(b, fb)
+	d := PopDiff{
+		MeanShift:   sum(sb)/float64(len(sb)) - sum(sa)/float64(len(sa)),
+		MedianShift: percentile(sb, 50) - percentile(sa, 50),
+		Overlap:     overlap(a, b),
+	}
+	for i, p := range [...]float64{10, 25, 50, 75, 90} {
+		d.PercentileShifts[i] = percentile(sb, p) - percentile(sa, p)
+	}
+	return d
+}
+
+func sorted(es []Entity, fs []float64) []float64 {
+	if fs == nil {
+		fs = make([]float64, len(es))
+		parallel(concurrency(), len(es), func(c, i int) {
+			fs[i] = fitness(es[i])
+		})
+	} else {
+		fs = append([]float64(nil), fs...)
+	}
+	sort.Float64s(fs)
+	return fs
+}
+
+// percentile returns the p-th percentile of the sorted xs, by linear interpolation.
+func percentile(xs []float64, p float64) float64 {
+	if len(xs) == 0 {
+		return math.NaN()
+	}
+	r := p / 100 * float64(len(xs)-1)
+	i := int(r)
+	if i >= len(xs)-1 {
+		return xs[len(xs)-1]
+	}
+	return xs[i] + (r-float64(i))*(xs[i+1]-xs[i])
+}
+
+func overlap(a, b []Entity) float64 {
+	ka, kb := keys(a), keys(b)
+	if ka == nil || kb == nil {
+		return math.NaN()
+	}
+	n := 0
+	for k := range ka {
+		if kb[k] {
+			n++
+		}
+	}
+	if u := len(ka) + len(kb) - n; u > 0 {
+		return float64(n) / float64(u)
+	}
+	return 1
+}
+
+func keys(es []Entity) map[string]bool {
+	ks := make(map[string]bool, len(es))
+	for _, e := range es {
+		k, ok := e.(Keyer)
+		if !ok {
+			return nil
+		}
+		ks[k.Key()] = true
+	}
+	return ks
+}
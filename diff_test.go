@@ -0,0 +1,28 @@
+package ga_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestDiffPopulations(t *testing.T) {
+	a := []ga.Entity{Keyed{ILP{0, 0}}, Keyed{ILP{1, 0}}, Keyed{ILP{2, 0}}}
+	b := []ga.Entity{Keyed{ILP{1, 0}}, Keyed{ILP{2, 0}}, Keyed{ILP{3, 0}}, Keyed{ILP{4, 0}}}
+	d := ga.DiffPopulations(a, b, nil, nil)
+	if math.Abs(d.MeanShift-7.5) > 1e-12 || math.Abs(d.MedianShift-7.5) > 1e-12 {
+		t.Fatal("shifts:", d)
+	}
+	if math.Abs(d.PercentileShifts[0]-(6.5-1)) > 1e-12 {
+		t.Fatal("percentile:", d.PercentileShifts)
+	}
+	if math.Abs(d.Overlap-0.4) > 1e-12 {
+		t.Fatal("overlap:", d.Overlap)
+	}
+
+	d = ga.DiffPopulations([]ga.Entity{MIN{}}, []ga.Entity{MIN{}}, []float64{1}, []float64{3})
+	if d.MeanShift != 2 || !math.IsNaN(d.Overlap) {
+		t.Fatal("no keys:", d)
+	}
+}
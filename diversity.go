@@ -0,0 +1,77 @@
+package ga
+
+import "math"
+
+// PairwiseDistance returns the diversity metric of the mean distance dist between the entities,
+// over all the pairs, or over samples pairs drawn deterministically if samples > 0 and there are more pairs.
+// The distances are computed in parallel, so dist must be safe for concurrent use,
+// by the worker pool shared by all GA models, since the metric is not bound to the executor of a model.
+func PairwiseDistance(dist func(x, y Entity) float64, samples int) func(pop []Entity) float64 {
+	return func(pop []Entity) float64 {
+		n := len(pop)
+		if n < 2 {
+			return 0
+		}
+		pairs := n * (n - 1) / 2
+		all := samples <= 0 || samples >= pairs
+		if !all {
+			pairs = samples
+		}
+		ds := make([]float64, pairs)
+		parallel(concurrency(), pairs, func(c, k int) {
+			var i, j int
+			if all {
+				// The k-th pair (i, j) with i > j, in the order of i.
+				i = int((1 + math.Sqrt(float64(1+8*k))) / 2)
+				for i*(i-1)/2 > k {
+					i--
+				}
+				for (i+1)*i/2 <= k {
+					i++
+				}
+				j = k - i*(i-1)/2
+			} else {
+				s := stream(mix(uint64(k)))
+				i = index(s.Float64(), n)
+				j = index(s.Float64(), n-1)
+				if j >= i {
+					j++
+				}
+			}
+			ds[k] = dist(pop[i], pop[j])
+		})
+		return sum(ds) / float64(pairs)
+	}
+}
+
+// Entropy returns the diversity metric of the Shannon entropy in bits of the genotypes of the entities,
+// identified by key, or by Keyer or Describe if key is nil.
+// It is 0 if all the entities are the same, and log2(n) if they are all different.
+func Entropy(key func(Entity) string) func(pop []Entity) float64 {
+	if key == nil {
+		key = func(e Entity) string {
+			if k, ok := e.(Keyer); ok {
+				return k.Key()
+			}
+			return Describe(e)
+		}
+	}
+	return func(pop []Entity) float64 {
+		counts := make(map[string]int)
+		for _, e := range pop {
+			counts[key(e)]++
+		}
+		h, n := 0.0, float64(len(pop))
+		for _, c := range counts {
+			p := float64(c) / n
+			h -= p * math.Log2(p)
+		}
+		return h
+	}
+}
+
+// DiversityOf returns the diversity of the current population measured by the metric f, e.g. PairwiseDistance,
+// regardless of WithDiversityMetric. The population passed to f must not be retained or modified.
+func (m *GA) DiversityOf(f func(pop []Entity) float64) float64 {
+	return f(m.pop.entities)
+}
@@ -0,0 +1,68 @@
+package ga_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func spread(pop []ga.Entity) float64 {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, e := range pop {
+		x := e.(MIN).X
+		lo, hi = math.Min(lo, x), math.Max(hi, x)
+	}
+	return hi - lo
+}
+
+func TestDiversityMetric(t *testing.T) {
+	m := ga.New(100, MIN{}.Mutate, ga.WithDiversityMetric(spread))
+	d0 := m.Diversity()
+	if d0 <= 0 || d0 > 10 {
+		t.Fatal("initial diversity:", d0)
+	}
+	m.Evolve(30, 100)
+	if d := m.Diversity(); d >= d0 {
+		t.Fatal("diversity should shrink:", d0, d)
+	}
+	if d := ga.New(10, MIN{}.Mutate).Diversity(); d != 0 {
+		t.Fatal("no metric:", d)
+	}
+}
+
+func TestPairwiseDistance(t *testing.T) {
+	dist := func(x, y ga.Entity) float64 {
+		return math.Abs(float64(x.(Walk) - y.(Walk)))
+	}
+	pop := []ga.Entity{Walk(0), Walk(1), Walk(2), Walk(3)}
+	if d := ga.PairwiseDistance(dist, 0)(pop); math.Abs(d-10.0/6) > 1e-12 {
+		t.Fatal("all pairs:", d)
+	}
+	pop = make([]ga.Entity, 200)
+	for i := range pop {
+		pop[i] = Walk(i % 2)
+	}
+	if d := ga.PairwiseDistance(dist, 1000)(pop); math.Abs(d-0.5) > 0.05 {
+		t.Fatal("sampled pairs:", d)
+	}
+	if h := ga.Entropy(nil)(pop); math.Abs(h-1) > 1e-12 {
+		t.Fatal("entropy:", h)
+	}
+	if h := ga.Entropy(nil)(pop[:1]); h != 0 {
+		t.Fatal("entropy of one:", h)
+	}
+
+	m := ga.New(50, func() ga.Entity {
+		return Walk(rand.Float64() * 10)
+	}, ga.WithDiversityMetric(ga.PairwiseDistance(dist, 0)))
+	d0 := m.DiversityOf(ga.PairwiseDistance(dist, 0))
+	if d0 != m.Diversity() || d0 < 2 {
+		t.Fatal("initial:", d0, m.Diversity())
+	}
+	m.Evolve(50, 100)
+	if d := m.DiversityOf(ga.PairwiseDistance(dist, 100)); d >= d0 {
+		t.Fatal("diversity should shrink:", d0, d)
+	}
+}
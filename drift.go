@@ -0,0 +1,31 @@
+package ga
+
+// GeneFrequencies returns the average gene values of the current population, computed concurrently,
+// where extract returns the comparable gene values of an entity, e.g. 0 or 1 for each allele.
+// All entities must have the same number of genes.
+// Tracking it over generations shows the genetic drift.
+func (m *GA) GeneFrequencies(extract func(Entity) []float64) []float64 {
+	sums := make([][]float64, m.pop.concurrency())
+	m.do(func(c, i int) {
+		xs := extract(m.pop.entities[i])
+		if sums[c] == nil {
+			sums[c] = make([]float64, len(xs))
+		}
+		for j, x := range xs {
+			sums[c][j] += x
+		}
+	})
+	var fs []float64
+	for _, s := range sums {
+		if fs == nil {
+			fs = make([]float64, len(s))
+		}
+		for j, x := range s {
+			fs[j] += x
+		}
+	}
+	for j := range fs {
+		fs[j] /= float64(m.n)
+	}
+	return fs
+}
@@ -0,0 +1,23 @@
+package ga_test
+
+import (
+	"math"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestGeneFrequencies(t *testing.T) {
+	var i int32
+	m := ga.New(4, func() ga.Entity {
+		return MIN{float64(atomic.AddInt32(&i, 1)), 1}
+	})
+	fs := m.GeneFrequencies(func(e ga.Entity) []float64 {
+		r := e.(MIN)
+		return []float64{r.X, r.Y}
+	})
+	if len(fs) != 2 || math.Abs(fs[0]-2.5) > 1e-12 || fs[1] != 1 {
+		t.Fatal("frequencies:", fs)
+	}
+}
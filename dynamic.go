@@ -0,0 +1,22 @@
+package ga
+
+// WithDynamicFitness supports fitness landscapes changing over time, e.g. online optimization:
+// the elite is re-evaluated every generation, so the recorded fitness may decrease,
+// and the elite is replaced by the best of the population if it is fitter then.
+// Evolve and EvolveContext return the current fitness of the final elite.
+func WithDynamicFitness() Option {
+	return func(m *GA) {
+		m.dynamic = true
+	}
+}
+
+// reevaluate re-evaluates the elite for WithDynamicFitness.
+func (m *GA) reevaluate() {
+	if m.elite == nil {
+		return
+	}
+	m.fitness = m.eval(m.elite)
+	if e, f := m.pop.Best(); m.fitter(e, f) {
+		m.fitness, m.elite = f, e
+	}
+}
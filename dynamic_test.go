@@ -0,0 +1,46 @@
+package ga_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+// peak is the optimum of Moving.
+var peak float64
+
+// Moving is Walk with the optimum at peak.
+type Moving float64
+
+func (x Moving) Fitness() float64 {
+	return -sqr(float64(x) - peak)
+}
+
+func (x Moving) Mutate() ga.Entity {
+	return Moving(Walk(x).Mutate().(Walk))
+}
+
+func (x Moving) Crossover(e ga.Entity, w float64) ga.Entity {
+	return Moving(Walk(x).Crossover(Walk(e.(Moving)), w).(Walk))
+}
+
+func TestDynamicFitness(t *testing.T) {
+	defer func() { peak = 0 }()
+	peak = 0
+	m := ga.New(50, func() ga.Entity {
+		return Moving(0.1)
+	}, ga.WithDynamicFitness(), ga.WithFixedMutationRate(0.5))
+	m.EvolveTo(10)
+	if f := m.Fitness(); f < -0.01 {
+		t.Fatal("fitness:", f)
+	}
+	peak = 3
+	if _, f := m.Next(); f > -1 {
+		t.Fatal("stale fitness:", f)
+	}
+	e, f, _ := m.Evolve(100, 100)
+	if f < -0.01 || f != e.Fitness() || math.Abs(float64(e.(Moving))-3) > 0.1 {
+		t.Fatal("tracking:", e, f)
+	}
+}
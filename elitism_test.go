@@ -0,0 +1,23 @@
+package ga_test
+
+import (
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestElitism(t *testing.T) {
+	m := ga.New(30, MIN{}.Mutate, ga.WithElitism(3))
+	for i := 0; i < 20; i++ {
+		m.EvolveTo(i)
+		best := m.Elite()
+		next := m.EvolveTo(i + 1)
+		found := false
+		for _, e := range next {
+			found = found || e == best
+		}
+		if !found {
+			t.Fatal("elite lost:", i, best)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package ga
+
+import "math"
+
+// WithImprovementEpsilon sets the tolerance of the improvements of the elite counted by the stagnation of Evolve,
+// EvolveContext and Generations, so the jitter of the fitnesses, e.g. by the rounding, does not keep a run alive forever.
+// The elite is improved only if its fitness is better than the one of the last improvement
+// by more than max(abs, rel·|fitness|), so the small improvements still count once they add up.
+// By default, any improvement counts.
+func WithImprovementEpsilon(abs, rel float64) Option {
+	return func(m *GA) {
+		m.epsabs, m.epsrel = abs, rel
+	}
+}
+
+// improved reports whether the internal fitness cur is an improvement over prev, by the tolerance.
+func (m *GA) improved(prev, cur float64) bool {
+	if math.IsInf(prev, -1) {
+		return cur > prev
+	}
+	return cur-prev > math.Max(m.epsabs, m.epsrel*math.Abs(prev))
+}
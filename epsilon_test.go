@@ -0,0 +1,55 @@
+package ga_test
+
+import (
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+type Creep int
+
+func (c Creep) Fitness() float64 {
+	return 1 + float64(c)*1e-12
+}
+
+func (c Creep) Mutate() ga.Entity {
+	return c + 1
+}
+
+func (c Creep) Crossover(e ga.Entity, w float64) ga.Entity {
+	return c
+}
+
+func TestImprovementEpsilon(t *testing.T) {
+	g := func() ga.Entity {
+		return Creep(0)
+	}
+	opts := []ga.Option{ga.WithFixedMutationRate(1), ga.WithElitism(1)}
+	if _, _, ok := ga.New(10, g, opts...).Evolve(5, 100); ok {
+		t.Fatal("stagnated without epsilon")
+	}
+	for _, eps := range [][2]float64{{1e-9, 0}, {0, 1e-9}} {
+		m := ga.New(10, g, append(opts, ga.WithImprovementEpsilon(eps[0], eps[1]))...)
+		e, f, ok := m.Evolve(5, 100)
+		if !ok || m.Stats().Generation > 10 || f != e.Fitness() {
+			t.Fatal("epsilon:", eps, ok, m.Stats().Generation, f, e)
+		}
+	}
+}
+
+func TestSlopeBelow(t *testing.T) {
+	c := ga.SlopeBelow(0.5, 4)
+	c.Start(ga.Stats{Fitness: 0})
+	for i, f := range []float64{1, 2, 3, 4, 10, 10, 10, 10, 10} {
+		if stop := c.Stop(ga.Stats{Fitness: f}); stop != (i >= 8) {
+			t.Fatal("slope:", i, f, stop)
+		}
+	}
+	c = ga.SlopeBelow(0.5, 4)
+	c.Start(ga.Stats{Fitness: 10, Minimize: true})
+	for i, f := range []float64{9, 8, 7, 6, 5} {
+		if c.Stop(ga.Stats{Fitness: f, Minimize: true}) {
+			t.Fatal("minimize:", i, f)
+		}
+	}
+}
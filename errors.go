@@ -0,0 +1,103 @@
+package ga
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CheckedEntity is an entity whose operators may fail.
+// The model calls TryMutate and TryCrossover instead of Mutate and Crossover,
+// and keeps the parent when they fail, so a failure never puts a degenerate entity into the population.
+type CheckedEntity interface {
+	Entity
+	// TryMutate is the mutation operation, which may fail.
+	TryMutate() (Entity, error)
+	// TryCrossover is the crossover operation, which may fail.
+	TryCrossover(Entity, float64) (Entity, error)
+}
+
+// OperatorError is a failure of the generator or an operator.
+type OperatorError struct {
+	// Op is the failed operation, i.e. "generate", "mutate" or "crossover".
+	Op string
+	// Err is the error returned by the operation.
+	Err error
+}
+
+func (e *OperatorError) Error() string {
+	return "ga: " + e.Op + ": " + e.Err.Error()
+}
+
+// Unwrap returns the error returned by the operation.
+func (e *OperatorError) Unwrap() error {
+	return e.Err
+}
+
+// Errors is the aggregated failures of the generator and operators.
+type Errors []*OperatorError
+
+func (es Errors) Error() string {
+	if len(es) == 1 {
+		return es[0].Error()
+	}
+	ss := make([]string, len(es))
+	for i, e := range es {
+		ss[i] = e.Op + ": " + e.Err.Error()
+	}
+	return fmt.Sprintf("ga: %d errors: %s", len(es), strings.Join(ss, "; "))
+}
+
+// maxErrors is the maximum number of failures kept between calls of Err.
+const maxErrors = 100
+
+// NewChecked creates a GA model whose generator may fail.
+// It returns the failures of the initial population, if any, as Errors instead of a model.
+// The later failures of g, e.g. when restarting, are reported by Err, and the entity is replaced by the elite.
+func NewChecked(n int, g func() (Entity, error), opts ...Option) (*GA, error) {
+	es := make([]Entity, n)
+	var errs Errors
+	for i := range es {
+		e, err := g()
+		if err != nil {
+			errs = append(errs, &OperatorError{"generate", err})
+		}
+		es[i] = e
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	var m *GA
+	m = newGA(n, es, func() Entity {
+		e, err := g()
+		if err == nil {
+			return e
+		}
+		m.fail("generate", err)
+		if m.elite != nil {
+			return m.elite
+		}
+		return es[0]
+	}, opts)
+	return m, nil
+}
+
+// Err returns the failures of the generator and operators since the last call, as Errors, or nil if there is none.
+func (m *GA) Err() error {
+	m.emutex.Lock()
+	defer m.emutex.Unlock()
+	if len(m.errs) == 0 {
+		return nil
+	}
+	errs := m.errs
+	m.errs = nil
+	return errs
+}
+
+// fail records the failure of the operation op.
+func (m *GA) fail(op string, err error) {
+	m.emutex.Lock()
+	if len(m.errs) < maxErrors {
+		m.errs = append(m.errs, &OperatorError{op, err})
+	}
+	m.emutex.Unlock()
+}
@@ -0,0 +1,75 @@
+package ga_test
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+var errFlaky = errors.New("flaky")
+
+type Flaky float64
+
+func (f Flaky) Fitness() float64 {
+	return -float64(f-1) * float64(f-1)
+}
+
+func (f Flaky) Mutate() ga.Entity {
+	panic("unchecked")
+}
+
+func (f Flaky) Crossover(e ga.Entity, w float64) ga.Entity {
+	panic("unchecked")
+}
+
+func (f Flaky) TryMutate() (ga.Entity, error) {
+	if rand.Intn(4) == 0 {
+		return nil, errFlaky
+	}
+	return f + Flaky(rand.Float64()-0.5), nil
+}
+
+func (f Flaky) TryCrossover(e ga.Entity, w float64) (ga.Entity, error) {
+	if rand.Intn(4) == 0 {
+		return nil, errFlaky
+	}
+	return Flaky(w)*f + Flaky(1-w)*e.(Flaky), nil
+}
+
+func TestNewChecked(t *testing.T) {
+	i := 0
+	_, err := ga.NewChecked(10, func() (ga.Entity, error) {
+		if i++; i == 3 {
+			return nil, errFlaky
+		}
+		return Flaky(0), nil
+	})
+	var errs ga.Errors
+	if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Op != "generate" || !errors.Is(errs[0], errFlaky) {
+		t.Fatal("generator:", err)
+	}
+
+	m, err := ga.NewChecked(20, func() (ga.Entity, error) {
+		return Flaky(rand.Float64()), nil
+	}, ga.WithFixedMutationRate(0.5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Next()
+	if !errors.As(m.Err(), &errs) || !errors.Is(errs[0], errFlaky) {
+		t.Fatal("operators:", m.Err())
+	}
+	if m.Err() != nil {
+		t.Fatal("not cleared")
+	}
+	for _, e := range m.Population() {
+		if e == nil {
+			t.Fatal("degenerate entity")
+		}
+	}
+	if e, f, _ := m.Evolve(10, 500); f < -0.01 {
+		t.Fatal("fitness:", e, f)
+	}
+}
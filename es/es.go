@@ -0,0 +1,369 @@
+// Package es implements the (μ/ρ,λ) and (μ/ρ+λ) evolution strategies over the vector space of package vector,
+// with the step sizes controlled by self-adaptation or by the 1/5 success rule,
+// and the same driver surface as GA model, so they can be swapped on the same problem.
+// They are often better suited than GA for the continuous parameters.
+package es
+
+import (
+	"math"
+	"math/rand/v2"
+	"sort"
+	"time"
+
+	"github.com/ofunc/ga"
+	"github.com/ofunc/ga/vector"
+)
+
+// StepControl is the control of the step sizes of the mutation.
+type StepControl int
+
+const (
+	// SelfAdaptive lets each individual carry a step size per dimension, which is recombined and mutated
+	// log-normally with it, so the step sizes are selected by the fitness of the individuals, the default.
+	SelfAdaptive StepControl = iota
+	// OneFifth uses a global step size, which grows if more than 1/5 of the offspring are fitter than their first parents,
+	// and shrinks otherwise.
+	OneFifth
+)
+
+// Strategy is a (μ/ρ,λ) or (μ/ρ+λ) evolution strategy.
+type Strategy struct {
+	space   *vector.Space
+	mu      int
+	rho     int
+	lambda  int
+	plus    bool
+	control StepControl
+	sigma0  float64
+	seed    int64
+	nc      int
+	exec    ga.Executor
+	epsabs  float64
+	epsrel  float64
+	rnd     *rand.Rand
+
+	sigma     float64
+	tau, tau0 float64
+	parents   []individual
+	offspring []individual
+	elite     ga.Entity
+	fitness   float64
+	evals     int64
+	gen       int
+}
+
+// individual is an individual with its step sizes, and the fitness of its first parent for the 1/5 success rule.
+type individual struct {
+	v      *vector.Vector
+	s      []float64
+	f      float64
+	parent float64
+}
+
+// Option is an option of Strategy.
+type Option func(*Strategy)
+
+// WithSeed sets the seed of the random source, default to the current time.
+func WithSeed(seed int64) Option {
+	return func(s *Strategy) {
+		s.seed = seed
+	}
+}
+
+// WithMu sets the number of parents μ, default to 15.
+func WithMu(mu int) Option {
+	return func(s *Strategy) {
+		s.mu = mu
+	}
+}
+
+// WithRho sets the number of parents ρ recombined into each offspring, default to 2.
+// The recombination is intermediate, the mean of the genes and the step sizes of the parents.
+func WithRho(rho int) Option {
+	return func(s *Strategy) {
+		s.rho = rho
+	}
+}
+
+// WithLambda sets the number of offspring λ, default to 7μ.
+func WithLambda(lambda int) Option {
+	return func(s *Strategy) {
+		s.lambda = lambda
+	}
+}
+
+// WithPlus switches to the (μ/ρ+λ) selection, where the parents compete with the offspring,
+// instead of the (μ/ρ,λ) selection from the offspring only.
+func WithPlus() Option {
+	return func(s *Strategy) {
+		s.plus = true
+	}
+}
+
+// WithStepControl sets the control of the step sizes, default to SelfAdaptive.
+func WithStepControl(c StepControl) Option {
+	return func(s *Strategy) {
+		s.control = c
+	}
+}
+
+// WithSigma sets the initial step size relative to the mean range of the bounds, default to 0.3.
+func WithSigma(sigma float64) Option {
+	return func(s *Strategy) {
+		s.sigma0 = sigma
+	}
+}
+
+// WithConcurrency sets the number of concurrency of the evaluation, default to ga.NC when New is called.
+func WithConcurrency(n int) Option {
+	return func(s *Strategy) {
+		s.nc = n
+	}
+}
+
+// WithExecutor evaluates the offspring by e, e.g. the one shared with GA models by ga.WithExecutor,
+// default to ga.SharedExecutor.
+func WithExecutor(e ga.Executor) Option {
+	return func(s *Strategy) {
+		s.exec = e
+	}
+}
+
+// WithImprovementEpsilon sets the tolerance of the improvements counted by Evolve, as ga.WithImprovementEpsilon:
+// the elite is improved only if its fitness is better than the one of the last improvement by more than max(abs, rel·|fitness|).
+// By default, any improvement counts.
+func WithImprovementEpsilon(abs, rel float64) Option {
+	return func(s *Strategy) {
+		s.epsabs, s.epsrel = abs, rel
+	}
+}
+
+// New creates a strategy in the space s, whose initial parents are uniformly distributed.
+// λ is at least μ, and ρ is clamped into [1, μ].
+func New(s *vector.Space, opts ...Option) *Strategy {
+	m := &Strategy{
+		space:   s,
+		mu:      15,
+		rho:     2,
+		sigma0:  0.3,
+		seed:    time.Now().UnixNano(),
+		fitness: math.Inf(-1),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	if m.nc <= 0 {
+		m.nc = ga.NC
+	}
+	if m.exec == nil {
+		m.exec = ga.SharedExecutor()
+	}
+	if m.mu < 1 {
+		m.mu = 1
+	}
+	if m.lambda <= 0 {
+		m.lambda = 7 * m.mu
+	}
+	if m.lambda < m.mu {
+		m.lambda = m.mu
+	}
+	m.rho = max(1, min(m.rho, m.mu))
+	m.rnd = rand.New(rand.NewPCG(uint64(m.seed), 0x9e3779b97f4a7c15))
+
+	n := s.Dim()
+	N := float64(n)
+	m.tau, m.tau0 = 1/math.Sqrt(2*math.Sqrt(N)), 1/math.Sqrt(2*N)
+	lower, upper := s.Bounds()
+	r := 0.0
+	for i := range lower {
+		r += (upper[i] - lower[i]) / N
+	}
+	m.sigma = m.sigma0 * r
+	m.parents = make([]individual, m.mu)
+	for k := range m.parents {
+		x, ss := make([]float64, n), make([]float64, n)
+		for i := range x {
+			x[i] = lower[i] + m.rnd.Float64()*(upper[i]-lower[i])
+			ss[i] = m.sigma
+		}
+		m.parents[k] = individual{v: s.New(x), s: ss}
+	}
+	m.evaluate(m.parents)
+	m.survive(m.parents)
+	return m
+}
+
+// Fitness returns the fitness of the current elite.
+func (m *Strategy) Fitness() float64 {
+	return m.fitness
+}
+
+// Elite returns the current elite, which is a *vector.Vector.
+func (m *Strategy) Elite() ga.Entity {
+	return m.elite
+}
+
+// Sigma returns the mean step size of the parents, or the global step size by OneFifth.
+func (m *Strategy) Sigma() float64 {
+	if m.control == OneFifth {
+		return m.sigma
+	}
+	s := 0.0
+	for _, p := range m.parents {
+		for _, x := range p.s {
+			s += x
+		}
+	}
+	return s / float64(len(m.parents)*len(m.parents[0].s))
+}
+
+// Parents returns the current parents, the fittest first.
+func (m *Strategy) Parents() []ga.Entity {
+	es := make([]ga.Entity, len(m.parents))
+	for i, p := range m.parents {
+		es[i] = p.v
+	}
+	return es
+}
+
+// Stats returns the statistics of the parents of the current generation, for the stop conditions of package ga,
+// where PM is the step size by Sigma.
+func (m *Strategy) Stats() ga.Stats {
+	fs := make([]float64, len(m.parents))
+	mean := 0.0
+	for i, p := range m.parents {
+		fs[i] = p.f
+		mean += p.f / float64(len(fs))
+	}
+	std := 0.0
+	for _, f := range fs {
+		std += (f - mean) * (f - mean) / float64(len(fs))
+	}
+	return ga.Stats{
+		Generation:  m.gen,
+		Fitness:     m.fitness,
+		Best:        fs[0],
+		Mean:        mean,
+		Std:         math.Sqrt(std),
+		Worst:       fs[len(fs)-1],
+		PM:          m.Sigma(),
+		Evaluations: m.evals,
+	}
+}
+
+// Next produces and evaluates the offspring, selects the next parents, adapts the step sizes,
+// and returns the current elite and fitness. The offspring are clamped into the bounds of the space.
+func (m *Strategy) Next() (ga.Entity, float64) {
+	n := m.space.Dim()
+	if len(m.offspring) != m.lambda {
+		m.offspring = make([]individual, m.lambda)
+	}
+	x, ps := make([]float64, n), make([]int, m.rho)
+	for k := range m.offspring {
+		for j := range ps {
+			ps[j] = m.rnd.IntN(m.mu)
+		}
+		s := make([]float64, n)
+		for i := range x {
+			x[i], s[i] = 0, 0
+			for _, j := range ps {
+				x[i] += m.parents[j].v.X()[i] / float64(m.rho)
+				s[i] += m.parents[j].s[i] / float64(m.rho)
+			}
+		}
+		if m.control == OneFifth {
+			for i := range x {
+				s[i] = m.sigma
+				x[i] += m.sigma * m.rnd.NormFloat64()
+			}
+		} else {
+			g := m.tau0 * m.rnd.NormFloat64()
+			for i := range x {
+				s[i] *= math.Exp(g + m.tau*m.rnd.NormFloat64())
+				x[i] += s[i] * m.rnd.NormFloat64()
+			}
+		}
+		m.offspring[k] = individual{v: m.space.New(x), s: s, parent: m.parents[ps[0]].f}
+	}
+	m.evaluate(m.offspring)
+	if m.control == OneFifth {
+		m.adapt()
+	}
+	pool := m.offspring
+	if m.plus {
+		pool = append(append([]individual(nil), m.parents...), m.offspring...)
+	}
+	m.survive(pool)
+	m.gen++
+	return m.elite, m.fitness
+}
+
+// adapt adapts the global step size by the 1/5 success rule.
+func (m *Strategy) adapt() {
+	k := 0
+	for _, o := range m.offspring {
+		if o.f > o.parent {
+			k++
+		}
+	}
+	p := float64(k) / float64(len(m.offspring))
+	d := 1 + float64(m.space.Dim())/2
+	m.sigma *= math.Exp((p - 0.2) / 0.8 / d)
+}
+
+// survive keeps the μ fittest of the pool as the parents, and updates the elite.
+func (m *Strategy) survive(pool []individual) {
+	sort.SliceStable(pool, func(i, j int) bool {
+		return pool[i].f > pool[j].f
+	})
+	m.parents = append(m.parents[:0], pool[:m.mu]...)
+	if f := m.parents[0].f; m.fitness < f {
+		m.fitness, m.elite = f, m.parents[0].v
+	}
+}
+
+// Evolve runs the strategy until the elite k generations have not improved, see WithImprovementEpsilon,
+// or the max of iterations has been reached.
+func (m *Strategy) Evolve(k int, max int) (ga.Entity, float64, bool) {
+	i, last := 0, m.fitness
+	for j := 0; i < k && j < max; i, j = i+1, j+1 {
+		if _, f := m.Next(); m.improved(last, f) {
+			i, last = 0, f
+		}
+	}
+	return m.elite, m.fitness, i >= k
+}
+
+// improved reports whether the fitness cur is an improvement over prev, by the tolerance.
+func (m *Strategy) improved(prev, cur float64) bool {
+	if math.IsInf(prev, -1) {
+		return cur > prev
+	}
+	return cur-prev > math.Max(m.epsabs, m.epsrel*math.Abs(prev))
+}
+
+// EvolveUntil runs the strategy until the condition c of package ga is met, and returns the elite and fitness.
+// The statistics passed to c are by Stats.
+func (m *Strategy) EvolveUntil(c ga.StopCondition) (ga.Entity, float64) {
+	c.Start(m.Stats())
+	for {
+		m.Next()
+		if c.Stop(m.Stats()) {
+			return m.elite, m.fitness
+		}
+	}
+}
+
+// evaluate evaluates the individuals by the executor, in contiguous shares.
+func (m *Strategy) evaluate(is []individual) {
+	m.evals += int64(len(is))
+	nc := min(m.nc, len(is))
+	m.exec.Run(nc, func(c int) {
+		for i := c * len(is) / nc; i < (c+1)*len(is)/nc; i++ {
+			is[i].f = is[i].v.Fitness()
+			if math.IsNaN(is[i].f) {
+				is[i].f = math.Inf(-1)
+			}
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package es_test
+
+import (
+	"testing"
+
+	"github.com/ofunc/ga"
+	"github.com/ofunc/ga/es"
+	"github.com/ofunc/ga/vector"
+)
+
+func sphere(x []float64) float64 {
+	f := 0.0
+	for _, v := range x {
+		f -= (v - 1) * (v - 1)
+	}
+	return f
+}
+
+func TestStrategy(t *testing.T) {
+	lower, upper := make([]float64, 5), make([]float64, 5)
+	for i := range lower {
+		lower[i], upper[i] = -5, 5
+	}
+	s := vector.NewSpace(lower, upper, sphere)
+	for name, m := range map[string]*es.Strategy{
+		"comma":         es.New(s, es.WithSeed(1)),
+		"plus":          es.New(s, es.WithSeed(1), es.WithPlus(), es.WithMu(5), es.WithLambda(20)),
+		"one-fifth":     es.New(s, es.WithSeed(1), es.WithStepControl(es.OneFifth), es.WithPlus()),
+		"comma-fifth":   es.New(s, es.WithSeed(1), es.WithStepControl(es.OneFifth), es.WithRho(4)),
+		"single-parent": es.New(s, es.WithSeed(1), es.WithMu(1), es.WithLambda(10), es.WithPlus(), es.WithConcurrency(1)),
+	} {
+		e, f, ok := m.Evolve(30, 2000)
+		if !ok || f < -1e-6 || e != m.Elite() || f != m.Fitness() || e.Fitness() != f {
+			t.Error(name, "result:", f, ok, m.Sigma())
+		}
+		if m.Sigma() > 0.1 {
+			t.Error(name, "sigma:", m.Sigma())
+		}
+	}
+}
+
+func TestEvolveUntil(t *testing.T) {
+	s := vector.NewSpace([]float64{-5, -5}, []float64{5, 5}, sphere)
+	var runs int
+	m := es.New(s, es.WithSeed(2), es.WithExecutor(ga.ExecutorFunc(func(n int, f func(int)) {
+		runs++
+		for i := 0; i < n; i++ {
+			f(i)
+		}
+	})))
+	if _, f := m.EvolveUntil(ga.Any(ga.Target(-1e-4), ga.MaxGenerations(500))); f < -1e-4 {
+		t.Fatal("fitness:", f)
+	}
+	if st := m.Stats(); runs != st.Generation+1 || st.Evaluations != int64(15+105*st.Generation) || st.Best != m.Fitness() {
+		t.Fatal("stats:", runs, st)
+	}
+}
+
+func TestImprovementEpsilon(t *testing.T) {
+	s := vector.NewSpace([]float64{-5, -5}, []float64{5, 5}, sphere)
+	m := es.New(s, es.WithSeed(3), es.WithImprovementEpsilon(1e9, 0))
+	if _, _, ok := m.Evolve(5, 100); !ok || m.Stats().Generation != 5 {
+		t.Fatal("stagnation:", ok, m.Stats().Generation)
+	}
+	m = es.New(s, es.WithSeed(3), es.WithImprovementEpsilon(0, 1e-3))
+	if _, f, ok := m.Evolve(5, 1000); !ok || f < -1e-2 || m.Stats().Generation >= 1000 {
+		t.Fatal("tolerance:", f, ok, m.Stats().Generation)
+	}
+}
@@ -0,0 +1,65 @@
+package ga
+
+import "math"
+
+// window is the number of recent generations used by the estimation.
+const window = 64
+
+// EstimateGenerationsToTarget estimates how many more generations are needed for the elite to reach target,
+// by fitting an exponential approach to the best fitnesses of the recent generations.
+// It returns false if no reliable estimate is possible, e.g. too few improvements,
+// or target is beyond the projected limit. The estimate is rough, and only meant for budgeting.
+func (m *GA) EstimateGenerationsToTarget(target float64) (int, bool) {
+	fs := m.trajectory
+	if len(fs) > window {
+		fs = fs[len(fs)-window:]
+	}
+	return estimate(fs, m.objective(target))
+}
+
+// estimate extrapolates the best fitnesses fs of consecutive generations to target.
+// The improvements are modeled as d(t) = c*r^t, fitted by the least squares of log d(t).
+func estimate(fs []float64, target float64) (int, bool) {
+	if len(fs) == 0 {
+		return 0, false
+	}
+	cur := fs[len(fs)-1]
+	if cur >= target {
+		return 0, true
+	}
+	var ts, ls []float64
+	for t := 1; t < len(fs); t++ {
+		if d := fs[t] - fs[t-1]; d > 0 && !math.IsInf(d, 0) {
+			ts, ls = append(ts, float64(t)), append(ls, math.Log(d))
+		}
+	}
+	if len(ts) < 3 {
+		return 0, false
+	}
+	a, b := fit(ts, ls)
+	need, last := target-cur, float64(len(fs)-1)
+	if b >= 0 {
+		rate := (cur - fs[0]) / last
+		return int(math.Ceil(need / rate)), true
+	}
+	c, r := math.Exp(a), math.Exp(b)
+	// The gain of the next g generations is c*r^(last+1)*(1-r^g)/(1-r).
+	x := 1 - need*(1-r)/(c*math.Pow(r, last+1))
+	if x <= 0 {
+		return 0, false
+	}
+	return int(math.Ceil(math.Log(x) / math.Log(r))), true
+}
+
+// fit returns the intercept and the slope of the least squares line of ys on xs.
+func fit(xs, ys []float64) (float64, float64) {
+	n := float64(len(xs))
+	mx, my := sum(xs)/n, sum(ys)/n
+	sxy, sxx := 0.0, 0.0
+	for i := range xs {
+		sxy += (xs[i] - mx) * (ys[i] - my)
+		sxx += (xs[i] - mx) * (xs[i] - mx)
+	}
+	b := sxy / sxx
+	return my - b*mx, b
+}
@@ -0,0 +1,33 @@
+package ga
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEstimate(t *testing.T) {
+	fs := make([]float64, 20)
+	for i := range fs {
+		fs[i] = 10 - 10*math.Pow(0.8, float64(i))
+	}
+	// 10 - 10*0.8^(19+g) >= 9.99 when g >= 12.
+	if g, ok := estimate(fs, 9.99); !ok || g != 12 {
+		t.Fatal("exponential:", g, ok)
+	}
+	if _, ok := estimate(fs, 10.1); ok {
+		t.Fatal("beyond the limit")
+	}
+	if g, ok := estimate(fs, 5); !ok || g != 0 {
+		t.Fatal("reached:", g, ok)
+	}
+
+	for i := range fs {
+		fs[i] = float64(2 * i)
+	}
+	if g, ok := estimate(fs, 48); !ok || g != 5 {
+		t.Fatal("linear:", g, ok)
+	}
+	if _, ok := estimate([]float64{1, 1, 1, 2}, 3); ok {
+		t.Fatal("too few improvements")
+	}
+}
@@ -0,0 +1,69 @@
+package ga
+
+import "math"
+
+// EventKind is the kind of an Event.
+type EventKind int
+
+const (
+	// SelectionEvent is a pair of parents selected for a slot.
+	SelectionEvent EventKind = iota
+	// CrossoverEvent is a crossover applied to the parents of a slot.
+	// The variations reverted by WithMaxSize emit neither CrossoverEvent nor MutationEvent.
+	CrossoverEvent
+	// MutationEvent is a mutation applied to the entity of a slot.
+	MutationEvent
+	// EliteEvent is the elite replaced by a fitter entity, at the end of a generation.
+	EliteEvent
+)
+
+// Event is an operator-level event of the evolution, see OnEvent.
+type Event struct {
+	Kind EventKind
+	// Generation is the generation being produced.
+	Generation int
+	// Slot is the slot of the offspring in the generation, or -1 for EliteEvent.
+	Slot int
+	// Parents are the selected parents for SelectionEvent and CrossoverEvent,
+	// and the entity before the mutation in the first for MutationEvent.
+	Parents [2]Entity
+	// Indices are the indices of the selected parents in the population, for SelectionEvent,
+	// or -1 if they are not selected by their indices, e.g. by ALPS, speciation or a script.
+	Indices [2]int
+	// Fitnesses are the fitnesses of the selected parents, for SelectionEvent, or NaN if the indices are -1.
+	Fitnesses [2]float64
+	// Weight is the crossover weight, for SelectionEvent and CrossoverEvent.
+	Weight float64
+	// Entity is the offspring for CrossoverEvent and MutationEvent, and the new elite for EliteEvent.
+	Entity Entity
+	// Fitness is the fitness of the new elite for EliteEvent.
+	Fitness float64
+}
+
+// OnEvent registers the listener f of the operator-level events, e.g. to measure the selection intensity
+// or the effective population size, with no overhead if no listener is registered.
+// The selection, crossover and mutation events are emitted by the workers breeding the offspring,
+// so f is called concurrently and must be safe for concurrent use, and it should be fast, as it delays the breeding.
+// The modes breeding the offspring otherwise, e.g. WithDifferentialEvolution, emit only the elite events.
+// The listeners are called in the order of registration.
+func (m *GA) OnEvent(f func(Event)) {
+	m.listeners = append(m.listeners, f)
+}
+
+// emit delivers e to the listeners.
+func (m *GA) emit(e Event) {
+	for _, f := range m.listeners {
+		f(e)
+	}
+}
+
+// selected emits the selection of the parents x and y of the indices ix and iy for the slot i.
+func (m *GA) selected(i int, x, y Entity, ix, iy int, w float64) {
+	e := Event{Kind: SelectionEvent, Generation: m.gen + 1, Slot: i, Parents: [2]Entity{x, y}, Indices: [2]int{ix, iy}, Weight: w}
+	if ix >= 0 {
+		e.Fitnesses = [2]float64{m.objective(m.pop.fitnesses[ix]), m.objective(m.pop.fitnesses[iy])}
+	} else {
+		e.Fitnesses = [2]float64{math.NaN(), math.NaN()}
+	}
+	m.emit(e)
+}
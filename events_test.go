@@ -0,0 +1,66 @@
+package ga_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestOnEvent(t *testing.T) {
+	m := ga.New(40, MIN{}.Mutate, ga.WithElitism(2))
+	var mutex sync.Mutex
+	counts := make(map[ga.EventKind]int)
+	last := -1e300
+	m.OnEvent(func(e ga.Event) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		counts[e.Kind]++
+		switch e.Kind {
+		case ga.SelectionEvent:
+			if e.Indices[0] < 0 || e.Indices[1] >= 40 || e.Parents[0] == nil || e.Fitnesses[0] != e.Parents[0].Fitness() {
+				t.Error("selection:", e)
+			}
+		case ga.CrossoverEvent, ga.MutationEvent:
+			if e.Entity == nil || e.Slot < 2 || e.Slot >= 40 {
+				t.Error("variation:", e)
+			}
+		case ga.EliteEvent:
+			if e.Fitness <= last || e.Slot != -1 {
+				t.Error("elite:", e, last)
+			}
+			last = e.Fitness
+		}
+	})
+	m.EvolveTo(10)
+	if counts[ga.SelectionEvent] != 10*38 || counts[ga.CrossoverEvent] != 10*38 {
+		t.Fatal("counts:", counts)
+	}
+	if counts[ga.MutationEvent] == 0 || counts[ga.EliteEvent] == 0 {
+		t.Fatal("counts:", counts)
+	}
+}
+
+func TestOnEventMaxSize(t *testing.T) {
+	m := ga.New(40, func() ga.Entity {
+		return Blob{12, 0}
+	}, ga.WithMaxSize(12), ga.WithFixedMutationRate(1))
+	var mutex sync.Mutex
+	mutations := 0
+	m.OnEvent(func(e ga.Event) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if e.Kind == ga.CrossoverEvent || e.Kind == ga.MutationEvent {
+			if e.Entity.(ga.Sized).Size() > 12 {
+				t.Error("reverted offspring:", e)
+			}
+		}
+		if e.Kind == ga.MutationEvent {
+			mutations++
+		}
+	})
+	m.EvolveTo(5)
+	if mutations == 0 {
+		t.Fatal("no mutation events")
+	}
+}
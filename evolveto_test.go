@@ -0,0 +1,58 @@
+package ga_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+type Det struct {
+	X float64
+	R *rand.Rand
+}
+
+func (d Det) Fitness() float64 {
+	return -sqr(d.X - 3)
+}
+
+func (d Det) Mutate() ga.Entity {
+	return Det{d.X + d.R.Float64() - 0.5, d.R}
+}
+
+func (d Det) Crossover(e ga.Entity, w float64) ga.Entity {
+	return Det{w*d.X + (1-w)*e.(Det).X, d.R}
+}
+
+func newDet(seed int64) *ga.GA {
+	r := rand.New(rand.NewSource(seed))
+	return ga.New(50, func() ga.Entity {
+		return Det{10*r.Float64() - 5, r}
+	}, ga.WithSeed(seed))
+}
+
+func TestEvolveTo(t *testing.T) {
+	nc := ga.NC
+	ga.NC = 1
+	defer func() { ga.NC = nc }()
+
+	xs, ys := newDet(7).EvolveTo(20), newDet(7).EvolveTo(20)
+	if len(xs) != 50 || len(ys) != 50 {
+		t.Fatal("size:", len(xs), len(ys))
+	}
+	for i := range xs {
+		if xs[i].(Det).X != ys[i].(Det).X {
+			t.Fatal("reproducibility:", i, xs[i].(Det).X, ys[i].(Det).X)
+		}
+	}
+}
+
+func TestDefaultSeed(t *testing.T) {
+	x, y := ga.New(5, MIN{}.Mutate), ga.New(5, MIN{}.Mutate)
+	if x.Seed() == y.Seed() {
+		t.Fatal("same default seed:", x.Seed())
+	}
+	if s := newDet(7).Seed(); s != 7 {
+		t.Fatal("seed:", s)
+	}
+}
@@ -0,0 +1,58 @@
+package ga
+
+// Executor runs the parallel steps of GA models, e.g. on a worker pool of the caller,
+// a rate-limited scheduler, or an errgroup.
+// Run calls f(i) for i in [0, n) and returns after all of them have returned, and the calls may run concurrently.
+// Each f(i) is a share of the work by a worker, and the number of shares is the concurrency of the model.
+type Executor interface {
+	Run(n int, f func(i int))
+}
+
+// ExecutorFunc is an adapter to allow the use of ordinary functions as Executor.
+type ExecutorFunc func(n int, f func(i int))
+
+// Run calls e(n, f).
+func (e ExecutorFunc) Run(n int, f func(i int)) {
+	e(n, f)
+}
+
+// WithExecutor runs the parallel steps of the model, including the evaluation and the variation, by e,
+// instead of the worker pool shared by all GA models.
+// So the total CPU of many concurrent GA models may be bounded by a single executor.
+// Run has no error, so an executor which recovers the panics of the shares should panic again after all of them return.
+func WithExecutor(e Executor) Option {
+	return func(m *GA) {
+		m.pop.executor = e
+	}
+}
+
+// SharedExecutor returns the Executor of the worker pool shared by all GA models without WithExecutor,
+// e.g. for the strategies of the other packages to share the pool by default.
+func SharedExecutor() Executor {
+	return ExecutorFunc(func(n int, f func(i int)) {
+		parallel(n, n, func(c, _ int) {
+			f(c)
+		})
+	})
+}
+
+// parallel calls f(c, i) for i in [0, n) by the workers of the population, where c is the index of the worker.
+func (p *Population) parallel(n int, f func(c, i int)) {
+	nc := p.concurrency()
+	if p.executor == nil {
+		parallel(nc, n, f)
+		return
+	}
+	if n <= 0 {
+		return
+	}
+	if nc > n {
+		nc = n
+	}
+	p.executor.Run(nc, func(c int) {
+		lo, hi := span(c, nc, n)
+		for i := lo; i < hi; i++ {
+			f(c, i)
+		}
+	})
+}
@@ -0,0 +1,40 @@
+package ga_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestExecutor(t *testing.T) {
+	var runs, active, peak int64
+	sem := make(chan struct{}, 2)
+	e := ga.ExecutorFunc(func(n int, f func(i int)) {
+		atomic.AddInt64(&runs, 1)
+		var wg sync.WaitGroup
+		for i := 0; i < n; i++ {
+			i := i
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				sem <- struct{}{}
+				if k := atomic.AddInt64(&active, 1); k > atomic.LoadInt64(&peak) {
+					atomic.StoreInt64(&peak, k)
+				}
+				f(i)
+				atomic.AddInt64(&active, -1)
+				<-sem
+			}()
+		}
+		wg.Wait()
+	})
+	m := ga.New(100, MIN{}.Mutate, ga.WithConcurrency(8), ga.WithExecutor(e))
+	if _, f, _ := m.Evolve(30, 100); f < -1e-2 {
+		t.Fatal("fitness:", f)
+	}
+	if runs < 2*int64(m.Generation()) || peak > 2 {
+		t.Fatal("executor:", runs, peak)
+	}
+}
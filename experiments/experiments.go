@@ -0,0 +1,274 @@
+// Package experiments compares the variants of GA models statistically,
+// which runs each variant over the same seeded trials, collects the distributions of the final fitnesses,
+// and tests the differences between the variants by the Mann–Whitney U test.
+package experiments
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/ofunc/ga"
+)
+
+// Variant is a named variant of GA models, e.g. with another selector or operator.
+type Variant struct {
+	// Name is the name of the variant.
+	Name string
+	// Options returns the options of a model, applied after those of the experiment.
+	// It is called for every trial, so the stateful options, e.g. BoltzmannSelector, are not shared.
+	Options func() []ga.Option
+}
+
+// Experiment is the setting shared by all the variants.
+type Experiment struct {
+	// Size is the population size, default to 100.
+	Size int
+	// Generator is the generator of the entities.
+	Generator func() ga.Entity
+	// Options are the options of every model, applied before those of the variant.
+	Options []ga.Option
+	// Evolve runs a model, and returns its final fitness, default to Evolve(50, 1000).
+	Evolve func(*ga.GA) float64
+	// Trials is the number of trials of each variant, default to 30.
+	Trials int
+	// Seed is the seed of the first trial, and the trial i is seeded by Seed+i, shared by all the variants.
+	Seed int64
+}
+
+// Result is the distribution of the final fitnesses of a variant over the trials.
+type Result struct {
+	Variant string
+	// Fitnesses are the final fitnesses of the trials.
+	Fitnesses []float64
+	// Mean, Std and Median are the statistics of the fitnesses.
+	Mean, Std, Median float64
+	// Best and Worst are the best and worst of the fitnesses.
+	Best, Worst float64
+	// Evaluations is the mean number of evaluations.
+	Evaluations float64
+	// Minimize reports whether the fitnesses are minimized, see ga.WithMinimize.
+	Minimize bool
+}
+
+// Run runs the trials of each variant, and returns the results in the order of vs.
+func Run(x Experiment, vs []Variant) []Result {
+	size, trials := x.Size, x.Trials
+	if size <= 0 {
+		size = 100
+	}
+	if trials <= 0 {
+		trials = 30
+	}
+	evolve := x.Evolve
+	if evolve == nil {
+		evolve = func(m *ga.GA) float64 {
+			_, f, _ := m.Evolve(50, 1000)
+			return f
+		}
+	}
+	rs := make([]Result, len(vs))
+	for i, v := range vs {
+		r := Result{Variant: v.Name, Fitnesses: make([]float64, trials)}
+		for j := range r.Fitnesses {
+			opts := append([]ga.Option(nil), x.Options...)
+			if v.Options != nil {
+				opts = append(opts, v.Options()...)
+			}
+			m := ga.New(size, x.Generator, append(opts, ga.WithSeed(x.Seed+int64(j)))...)
+			r.Fitnesses[j] = evolve(m)
+			s := m.Stats()
+			r.Evaluations += float64(s.Evaluations) / float64(trials)
+			r.Minimize = s.Minimize
+		}
+		r.summarize()
+		rs[i] = r
+	}
+	return rs
+}
+
+func (r *Result) summarize() {
+	n := float64(len(r.Fitnesses))
+	r.Best, r.Worst = r.Fitnesses[0], r.Fitnesses[0]
+	for _, f := range r.Fitnesses {
+		r.Mean += f / n
+		if f > r.Best != r.Minimize {
+			r.Best = f
+		}
+		if f < r.Worst != r.Minimize {
+			r.Worst = f
+		}
+	}
+	for _, f := range r.Fitnesses {
+		r.Std += (f - r.Mean) * (f - r.Mean) / n
+	}
+	r.Std = math.Sqrt(r.Std)
+	fs := append([]float64(nil), r.Fitnesses...)
+	sort.Float64s(fs)
+	if k := len(fs); k%2 == 1 {
+		r.Median = fs[k/2]
+	} else {
+		r.Median = (fs[k/2-1] + fs[k/2]) / 2
+	}
+}
+
+// Comparison is the Mann–Whitney U test of the fitnesses of the variants A and B.
+type Comparison struct {
+	A, B string
+	// U is the statistic of A, the number of the pairs of trials where A is fitter than B, counting the ties as 1/2.
+	U float64
+	// P is the two-sided p-value, and Adjusted is adjusted by the Holm method over all the comparisons.
+	P, Adjusted float64
+	// Effect is the Vargha–Delaney effect size A12, i.e. the probability that A is fitter than B, where 0.5 means no difference.
+	Effect float64
+}
+
+// Compare compares every pair of the results by MannWhitney, in the order of rs.
+func Compare(rs []Result) []Comparison {
+	var cs []Comparison
+	for i := range rs {
+		for j := i + 1; j < len(rs); j++ {
+			a, b := rs[i], rs[j]
+			u, p := MannWhitney(a.Fitnesses, b.Fitnesses)
+			n := float64(len(a.Fitnesses) * len(b.Fitnesses))
+			if a.Minimize {
+				u = n - u
+			}
+			cs = append(cs, Comparison{A: a.Variant, B: b.Variant, U: u, P: p, Effect: u / n})
+		}
+	}
+	holm(cs)
+	return cs
+}
+
+// holm sets the p-values of cs adjusted by the Holm–Bonferroni method.
+func holm(cs []Comparison) {
+	idx := make([]int, len(cs))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		return cs[idx[i]].P < cs[idx[j]].P
+	})
+	last := 0.0
+	for k, i := range idx {
+		p := math.Min(1, float64(len(cs)-k)*cs[i].P)
+		last = math.Max(last, p)
+		cs[i].Adjusted = last
+	}
+}
+
+// MannWhitney returns the Mann–Whitney U statistic of xs, the number of the pairs where x > y counting the ties as 1/2,
+// and the two-sided p-value of the hypothesis that xs and ys are of the same distribution.
+// The p-value is exact if there are no ties and both samples are smaller than 50,
+// or else by the normal approximation with the tie and continuity corrections.
+func MannWhitney(xs, ys []float64) (u, p float64) {
+	n1, n2 := len(xs), len(ys)
+	if n1 == 0 || n2 == 0 {
+		return 0, math.NaN()
+	}
+	type obs struct {
+		v float64
+		x bool
+	}
+	os := make([]obs, 0, n1+n2)
+	for _, x := range xs {
+		os = append(os, obs{x, true})
+	}
+	for _, y := range ys {
+		os = append(os, obs{y, false})
+	}
+	sort.SliceStable(os, func(i, j int) bool {
+		return os[i].v < os[j].v
+	})
+	r, ties := 0.0, 0.0
+	for i := 0; i < len(os); {
+		j := i
+		for j < len(os) && os[j].v == os[i].v {
+			j++
+		}
+		rank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			if os[k].x {
+				r += rank
+			}
+		}
+		t := float64(j - i)
+		ties += t*t*t - t
+		i = j
+	}
+	u = r - float64(n1*(n1+1))/2
+
+	mu := float64(n1*n2) / 2
+	if ties == 0 && n1 < 50 && n2 < 50 {
+		return u, exact(n1, n2, u)
+	}
+	n := float64(n1 + n2)
+	sigma := math.Sqrt(float64(n1*n2) / 12 * (n + 1 - ties/(n*(n-1))))
+	if sigma == 0 {
+		return u, 1
+	}
+	d := math.Abs(u-mu) - 0.5
+	if d < 0 {
+		d = 0
+	}
+	return u, math.Min(1, math.Erfc(d/sigma/math.Sqrt2))
+}
+
+// exact returns the exact two-sided p-value of the U statistic u of the samples of the sizes n1 and n2 without ties.
+func exact(n1, n2 int, u float64) float64 {
+	// c[j][k] is the number of the arrangements of i xs and j ys with the statistic k, for the current i.
+	m := n1 * n2
+	c := make([][]float64, n2+1)
+	for j := range c {
+		c[j] = make([]float64, m+1)
+		c[j][0] = 1
+	}
+	for i := 1; i <= n1; i++ {
+		next := make([][]float64, n2+1)
+		next[0] = make([]float64, m+1)
+		next[0][0] = 1
+		for j := 1; j <= n2; j++ {
+			next[j] = make([]float64, m+1)
+			for k := 0; k <= i*j; k++ {
+				// The largest is an x, greater than all the j ys, or a y.
+				if k >= j {
+					next[j][k] += c[j][k-j]
+				}
+				next[j][k] += next[j-1][k]
+			}
+		}
+		c = next
+	}
+	total, lo, hi := 0.0, 0.0, 0.0
+	for k, x := range c[n2] {
+		total += x
+		if float64(k) <= u {
+			lo += x
+		}
+		if float64(k) >= u {
+			hi += x
+		}
+	}
+	return math.Min(1, 2*math.Min(lo, hi)/total)
+}
+
+// WriteTable writes the summary table of the results, and the comparisons if any, to w as aligned text.
+func WriteTable(w io.Writer, rs []Result, cs []Comparison) error {
+	t := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(t, "variant\ttrials\tmean\tstd\tmedian\tbest\tworst\tevaluations")
+	for _, r := range rs {
+		fmt.Fprintf(t, "%s\t%d\t%.6g\t%.6g\t%.6g\t%.6g\t%.6g\t%.0f\n",
+			r.Variant, len(r.Fitnesses), r.Mean, r.Std, r.Median, r.Best, r.Worst, r.Evaluations)
+	}
+	if len(cs) > 0 {
+		fmt.Fprintln(t)
+		fmt.Fprintln(t, "a\tb\tU\tp\tholm\tA12")
+		for _, c := range cs {
+			fmt.Fprintf(t, "%s\t%s\t%g\t%.4g\t%.4g\t%.3f\n", c.A, c.B, c.U, c.P, c.Adjusted, c.Effect)
+		}
+	}
+	return t.Flush()
+}
@@ -0,0 +1,53 @@
+package experiments_test
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/ofunc/ga"
+	"github.com/ofunc/ga/bench"
+	"github.com/ofunc/ga/experiments"
+)
+
+func TestMannWhitney(t *testing.T) {
+	u, p := experiments.MannWhitney([]float64{1, 2, 3, 4, 5}, []float64{6, 7, 8, 9, 10})
+	if u != 0 || math.Abs(p-2.0/252) > 1e-12 {
+		t.Fatal("exact:", u, p)
+	}
+	u, p = experiments.MannWhitney([]float64{1, 2, 2, 3}, []float64{2, 4, 5})
+	if u != 2 || math.Abs(p-0.19909) > 1e-4 {
+		t.Fatal("normal:", u, p)
+	}
+	if _, p := experiments.MannWhitney([]float64{3, 1, 2}, []float64{2.5, 1.5, 0.5}); p < 0.5 {
+		t.Fatal("same:", p)
+	}
+}
+
+func TestRun(t *testing.T) {
+	p := bench.Sphere.Problem(5)
+	evolve := func(m *ga.GA) float64 {
+		_, f := m.EvolveUntil(ga.MaxGenerations(30))
+		return f
+	}
+	rs := experiments.Run(experiments.Experiment{
+		Size: 30, Generator: p.Random, Evolve: evolve, Trials: 8, Seed: 1,
+	}, []experiments.Variant{
+		{Name: "default"},
+		{Name: "crippled", Options: func() []ga.Option {
+			return []ga.Option{ga.WithCrossoverRate(0), ga.WithFixedMutationRate(0)}
+		}},
+	})
+	if len(rs) != 2 || rs[0].Variant != "default" || len(rs[1].Fitnesses) != 8 || rs[0].Median < rs[1].Median {
+		t.Fatal("results:", rs)
+	}
+	cs := experiments.Compare(rs)
+	if len(cs) != 1 || cs[0].P > 0.01 || cs[0].Adjusted != cs[0].P || cs[0].Effect < 0.9 {
+		t.Fatal("comparison:", cs)
+	}
+	var b bytes.Buffer
+	if err := experiments.WriteTable(&b, rs, cs); err != nil || !strings.Contains(b.String(), "crippled") {
+		t.Fatal("table:", b.String(), err)
+	}
+}
@@ -0,0 +1,86 @@
+package ga
+
+import (
+	"reflect"
+	"sort"
+)
+
+// WithHallOfFame keeps the size fittest distinct entities ever seen across all generations, see HallOfFame.
+// Entities are distinguished by Keyer or Hasher if implemented, or else by ==, if their type is comparable.
+// Entities of other types are all distinct.
+func WithHallOfFame(size int) Option {
+	return func(m *GA) {
+		m.fame = &fame{size: size, seen: make(map[interface{}]bool)}
+	}
+}
+
+// HallOfFame returns the fittest distinct entities ever seen and their fitnesses, the fittest first,
+// or nil if WithHallOfFame is not set.
+func (m *GA) HallOfFame() ([]Entity, []float64) {
+	if m.fame == nil {
+		return nil, nil
+	}
+	es, fs := make([]Entity, len(m.fame.es)), make([]float64, len(m.fame.fs))
+	copy(es, m.fame.es)
+	for i, f := range m.fame.fs {
+		fs[i] = m.objective(f)
+	}
+	return es, fs
+}
+
+type fame struct {
+	size int
+	es   []Entity
+	fs   []float64
+	ids  []interface{}
+	seen map[interface{}]bool
+}
+
+// update admits the fittest entities of p into the hall of fame.
+func (h *fame) update(p *Population) {
+	for _, i := range order(p.fitnesses) {
+		e, f := p.entities[i], p.fitnesses[i]
+		if len(h.es) >= h.size && (h.size <= 0 || f <= h.fs[len(h.fs)-1]) {
+			continue
+		}
+		id := identity(e)
+		if id != nil && h.seen[id] {
+			continue
+		}
+		j := sort.Search(len(h.fs), func(j int) bool {
+			return h.fs[j] < f
+		})
+		h.es = append(h.es[:j], append([]Entity{e}, h.es[j:]...)...)
+		h.fs = append(h.fs[:j], append([]float64{f}, h.fs[j:]...)...)
+		h.ids = append(h.ids[:j], append([]interface{}{id}, h.ids[j:]...)...)
+		if id != nil {
+			h.seen[id] = true
+		}
+		if k := len(h.es) - 1; k >= h.size {
+			if h.ids[k] != nil {
+				delete(h.seen, h.ids[k])
+			}
+			h.es, h.fs, h.ids = h.es[:k], h.fs[:k], h.ids[:k]
+		}
+	}
+}
+
+// reset forgets the entities seen.
+func (h *fame) reset() {
+	h.es, h.fs, h.ids = nil, nil, nil
+	h.seen = make(map[interface{}]bool)
+}
+
+// identity returns the identity of e, or nil if e is distinct from all.
+func identity(e Entity) interface{} {
+	switch x := e.(type) {
+	case Keyer:
+		return x.Key()
+	case Hasher:
+		return x.Hash()
+	}
+	if reflect.TypeOf(e).Comparable() {
+		return e
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package ga_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestHallOfFame(t *testing.T) {
+	m := ga.New(30, MIN{}.Mutate, ga.WithHallOfFame(5))
+	best := math.Inf(-1)
+	m.OnGeneration(func(gen int, e ga.Entity, f float64, s ga.Stats) {
+		best = math.Max(best, s.Best)
+	})
+	m.Evolve(20, 100)
+	es, fs := m.HallOfFame()
+	if len(es) != 5 || len(fs) != 5 {
+		t.Fatal("size:", len(es), len(fs))
+	}
+	if es[0] != m.Elite() || fs[0] != m.Fitness() || fs[0] < best {
+		t.Fatal("fittest:", es[0], fs[0], m.Elite(), m.Fitness())
+	}
+	for i := range es {
+		if es[i].Fitness() != fs[i] || i > 0 && (fs[i] > fs[i-1] || es[i] == es[i-1]) {
+			t.Fatal("order:", i, es, fs)
+		}
+	}
+	if es, _ := ga.New(5, MIN{}.Mutate).HallOfFame(); es != nil {
+		t.Fatal("hall without option")
+	}
+}
+
+func TestHallOfFameDistinct(t *testing.T) {
+	m := ga.New(20, func() ga.Entity {
+		return Walk(1)
+	}, ga.WithHallOfFame(3))
+	if es, _ := m.HallOfFame(); len(es) != 1 {
+		t.Fatal("duplicates:", es)
+	}
+}
@@ -0,0 +1,16 @@
+package ga
+
+// Fitness32 is an optional interface of Entity, whose fitness is computed in float32, e.g. on a GPU or an embedded target.
+// The model calls Fitness32 instead of Fitness, and accumulates the statistics in float64,
+// so they do not lose precision over large populations.
+type Fitness32 interface {
+	Fitness32() float32
+}
+
+// fitness returns the fitness of e, by Fitness32 or Fitness.
+func fitness(e Entity) float64 {
+	if f, ok := e.(Fitness32); ok {
+		return float64(f.Fitness32())
+	}
+	return e.Fitness()
+}
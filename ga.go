@@ -4,10 +4,12 @@
 package ga
 
 import (
+	"context"
 	"math"
-	"math/rand"
+	"math/rand/v2"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -18,140 +20,764 @@ type Entity interface {
 	// Mutate is the mutation operation.
 	Mutate() Entity
 	// Crossover is the crossover operation.
+	// The weight is of the receiver by default, see WithWeightConvention and WithCrossoverWeight.
 	Crossover(Entity, float64) Entity
 }
 
 // GA is a GA model.
 type GA struct {
-	n         int
-	fitness   float64
-	elite     Entity
-	pm        float64
-	base      float64
-	fsum      float64
-	rnd       *rand.Rand
-	mutex     sync.Mutex
-	fentities []float64
-	entities  []Entity
-	tentities []Entity
-}
-
-// NC is the number of concurrency, default to runtime.GOMAXPROCS.
+	evals      int64
+	n          int
+	gen        int
+	seed       int64
+	fitness    float64
+	elite      Entity
+	pm         float64
+	base       float64
+	warm       *Adaptation
+	diversity  float64
+	metric     func([]Entity) float64
+	sink       Sink
+	rnd        *rand.Rand
+	src        rand.Source
+	smutex     sync.Mutex
+	stats      Stats
+	published  Entity
+	survivor   Survivor
+	cache      *Cache
+	oracle     *oracle
+	threshold  float64
+	regression func(gen int, prev, cur float64) bool
+	halt       bool
+	script     *script
+	convention WeightConvention
+	cweight    CrossoverWeight
+	cycles     *cycles
+	de         bool
+	df         float64
+	dcr        float64
+	solution   Entity
+	spread     int
+	g          func() Entity
+	fallback   InfeasibleFallback
+	share      float64
+	selector   Selector
+	pick       func(func() float64) int
+	mo         *pareto
+	observers  []func(int, Entity, float64, Stats)
+	elitism    int
+	steady     float64
+	penalty    Penalty
+	niche      *sharing
+	sizing     *[2]int
+	fame       *fame
+	opts       []Option
+	pmin       float64
+	pmax       float64
+	fixed      bool
+	pc         float64
+	streams    bool
+	workers    []worker
+	ls         func(Entity) Entity
+	lfrac      float64
+	dstrategy  DEStrategy
+	relative   bool
+	species    *speciation
+	minimize   bool
+	noise      *noise
+	dynamic    bool
+	recycler   recycler
+	mutable    bool
+	ops        *operators
+	dedup      func(Entity) uint64
+	alps       *alps
+	immigrants float64
+	policy     func(Stats) float64
+	schedule   func(gen int, evals int64) Schedule
+	pressure   float64
+	tuning     tuning
+	replay     *Variation
+	bloat      *bloat
+	validator  *validator
+	epsabs     float64
+	epsrel     float64
+	replacer   Replacer
+	lambda     int
+	invariants *invariants
+	generator  func() Entity
+	indexed    bool
+	sequential bool
+	batch      func(es []Entity, fs []float64)
+	batcher    string
+	screen     func(es []Entity, fs []float64, evaluate func(es []Entity, fs []float64))
+	partial    *partial
+	listeners  []func(Event)
+	store      *eliteStore
+	parchive   *ParetoArchive
+	draws      atomic.Uint64
+	emutex     sync.Mutex
+	errs       Errors
+	recovery   Recovery
+	etimeout   time.Duration
+	aborted    int32
+	crowding   func(x, y Entity) float64
+	mating     *mating
+	restarts   *autorestart
+	archive    *archive
+	history    *history
+	dmutex     sync.Mutex
+	derived    map[Entity]Entity
+	linkage    *linkage
+	memo       *memo
+	sus        bool
+	mates      []int
+	parents    int
+	anneal     *annealing
+	streaming  *streaming
+	lineage    *lineage
+	codec      Codec
+	pm0        float64
+	nearby     *neighborhoods
+	cname      string
+	frac       float64
+	g2         func() Entity
+	pop        Population
+	tfitnesses []float64
+	sorted     []float64
+	trajectory []float64
+	tentities  []Entity
+}
+
+// NC is the default number of concurrency of GA models without WithConcurrency, default to runtime.GOMAXPROCS.
+// It is read once when a model or a population is created, and the concurrency of a model is fixed after that.
+//
+// Deprecated: Use WithConcurrency, since NC is shared by all the packages of a program.
 var NC = runtime.GOMAXPROCS(0)
 
-// New creates a GA model.
-func New(n int, g func() Entity) *GA {
+// seeds distinguishes the default seeds of GA models created at the same time.
+var seeds int64
+
+// New creates a GA model of n entities generated by g, configured by the options,
+// e.g. WithSeed, WithConcurrency, WithElitism, WithSelector or WithMinimize.
+// Without options, the model maximizes the fitnesses by the sigmoid scaled roulette with the adaptive mutation probability.
+// g is called concurrently by the workers, e.g. to generate the initial population or the immigrants,
+// so it must be safe for concurrent use, unlike the sampler of NewSampled, whose calls are serialized.
+func New(n int, g func() Entity, opts ...Option) *GA {
+	return newGA(n, nil, g, opts)
+}
+
+// NewFrom creates a GA model whose initial population starts with the entities es, e.g. the elites of a previous run,
+// and the generator g fills the rest of the n entities. Entities beyond n are ignored.
+// As by New, g must be safe for concurrent use.
+func NewFrom(n int, es []Entity, g func() Entity, opts ...Option) *GA {
+	return newGA(n, es, g, opts)
+}
+
+func newGA(n int, es []Entity, g func() Entity, opts []Option) *GA {
+	m := blank(n, g, opts)
+	m.generate(es)
+	if m.invariants != nil {
+		m.checkGenerator()
+	}
+	if n > 0 {
+		_, m.mutable = m.pop.entities[0].(MutableEntity)
+		_, m.recycler.release = m.pop.entities[0].(Releaser)
+	}
+	if m.warm != nil {
+		m.adjust()
+		m.restore(*m.warm)
+	} else {
+		m.base = m.adjust()
+		if m.streaming != nil {
+			m.base = m.streamInitial()
+		}
+	}
+	if m.lineage != nil {
+		m.lineage.settle(m)
+	}
+	m.detect()
+	m.publish()
+	m.report()
+	return m
+}
+
+// blank creates a GA model of the options, without the population.
+func blank(n int, g func() Entity, opts []Option) *GA {
 	m := &GA{
-		n:         n,
-		fitness:   math.Inf(-1),
-		pm:        0.1,
-		rnd:       rand.New(rand.NewSource(time.Now().Unix())),
-		fentities: make([]float64, n),
-		entities:  make([]Entity, n),
-		tentities: make([]Entity, n),
+		n:          n,
+		fitness:    math.Inf(-1),
+		pm:         0.1,
+		pmin:       0.0001,
+		pmax:       0.1,
+		pc:         1,
+		pressure:   1,
+		tfitnesses: make([]float64, n),
+		tentities:  make([]Entity, n),
+		g:          g,
+		generator:  g,
+		opts:       opts,
 	}
-	m.do(func(c, i int) {
-		m.entities[i] = g()
-	})
-	m.base = m.adjust()
+	m.pop.init(make([]Entity, n))
+	m.pop.eval = m.evalContext
+	m.seed = time.Now().UnixNano() + atomic.AddInt64(&seeds, 1)
+	for _, opt := range opts {
+		opt(m)
+	}
+	m.wire()
+	if m.noise != nil || m.dynamic {
+		m.memo = nil
+	}
+	if m.pop.nc <= 0 {
+		m.pop.nc = NC
+	}
+	if m.src == nil {
+		m.src = rand.NewPCG(uint64(m.seed), mix(uint64(m.seed)))
+	}
+	m.rnd = rand.New(m.src)
+	m.pm0 = m.pm
 	return m
 }
 
+// generate fills the population with the entities es, and the generators for the rest.
+func (m *GA) generate(es []Entity) {
+	k := int(math.Round(math.Max(0, math.Min(1, m.frac)) * float64(m.n)))
+	kn := 0
+	if m.nearby != nil {
+		kn = m.nearby.size(m.n)
+	}
+	m.drop(m.pop.entities...)
+	sequential := m.sequential
+	if m.streams && m.indexed {
+		m.sequential = true
+	}
+	defer func() {
+		m.sequential = sequential
+	}()
+	m.spawn(m.n, func(i int) {
+		if i < len(es) {
+			m.pop.entities[i] = es[i]
+		} else if m.solution != nil {
+			m.pop.entities[i] = m.perturb(i)
+		} else if i < kn {
+			m.pop.entities[i] = m.nearby.near(i, kn)
+		} else if i < k {
+			m.pop.entities[i] = m.g2()
+		} else {
+			m.pop.entities[i] = m.g()
+		}
+	})
+}
+
+// Seed returns the seed of the random source, which reproduces the run by WithSeed.
+func (m *GA) Seed() int64 {
+	return m.seed
+}
+
+// Generation returns the number of generations produced since New, or restored by Load.
+// It is safe to call concurrently with Next.
+func (m *GA) Generation() int {
+	return m.SnapshotStats().Generation
+}
+
+// Evaluations returns the number of fitness evaluations since New, including the initial population,
+// or restored by Load. It is safe to call concurrently with Next.
+func (m *GA) Evaluations() int64 {
+	return atomic.LoadInt64(&m.evals)
+}
+
 // Fitness returns the fitness of current elite.
+// It is safe to call concurrently with Next, and is of the last completed generation then.
 func (m *GA) Fitness() float64 {
-	return m.fitness
+	return m.SnapshotStats().Fitness
 }
 
 // Elite returns the current elite.
+// It is safe to call concurrently with Next, and is of the last completed generation then,
+// the same as the fitness returned by Fitness.
 func (m *GA) Elite() Entity {
-	return m.elite
+	m.smutex.Lock()
+	defer m.smutex.Unlock()
+	return m.published
+}
+
+// Diversity returns the diversity of the current population,
+// measured by the metric set by WithDiversityMetric, or 0 if no metric is set.
+// It is safe to call concurrently with Next.
+func (m *GA) Diversity() float64 {
+	return m.SnapshotStats().Diversity
 }
 
 // Next gets the next generation of GA model, and returns the current elite and fitness.
 func (m *GA) Next() (Entity, float64) {
+	m.partial = nil
+	m.tune()
+	_, best := m.pop.Best()
+	prev := m.fitness
+	if m.steady > 0 {
+		m.step()
+	} else if m.de {
+		m.differ()
+		m.swap()
+		m.normalize()
+	} else if m.crowding != nil {
+		m.crowd()
+		m.swap()
+		m.normalize()
+	} else if m.archive != nil {
+		m.illuminate()
+		m.swap()
+		m.normalize()
+	} else if m.streaming != nil {
+		m.streamNext()
+	} else if m.breed(); m.mo != nil {
+		m.paretoSurvive()
+	} else if m.replacer != nil {
+		m.renew()
+	} else if m.survivor != nil {
+		m.survive()
+	} else {
+		m.swap()
+		m.adjust()
+		if m.ops != nil {
+			m.ops.credit(&m.pop)
+		}
+	}
+	return m.conclude(prev, best)
+}
+
+// conclude completes the generation bred from the elite fitness prev and the best fitness best of the last generation.
+func (m *GA) conclude(prev, best float64) (Entity, float64) {
+	// The parents of the offspring not evaluated, e.g. by the memo, are not needed any more.
+	m.derived = nil
+	if m.ls != nil {
+		m.refine()
+	}
+	if m.immigrants > 0 {
+		m.immigrate()
+	}
+	if m.anneal != nil {
+		m.stagnate(prev)
+	}
+	if m.noise != nil {
+		m.resample()
+	} else if m.dynamic {
+		m.reevaluate()
+	}
+	m.gen++
+	m.guard(best)
+	if m.sizing != nil {
+		m.adapt(prev)
+	}
+	m.abort()
+	m.detect()
+	if len(m.listeners) > 0 && m.elite != nil && m.fitness != prev {
+		m.emit(Event{Kind: EliteEvent, Generation: m.gen, Slot: -1, Entity: m.elite, Fitness: m.objective(m.fitness)})
+	}
+	m.publish()
+	if m.store != nil && m.elite != nil && m.fitness != prev {
+		m.persist()
+	}
+	m.report()
+	m.notify()
+	if m.lineage != nil {
+		m.lineage.settle(m)
+	}
+	if m.recycling() {
+		m.recycle()
+	}
+	if m.memo != nil {
+		m.memo.retain(m.pop.entities)
+	}
+	return m.elite, m.objective(m.fitness)
+}
+
+// breed produces the offspring by selection, crossover and mutation.
+func (m *GA) breed() {
+	elites := m.elites()
+	if m.alps != nil {
+		m.prepareALPS()
+		elites = nil
+	}
+	m.fork()
+	if m.ops != nil {
+		m.ops.prepare(&m.pop)
+	}
+	if m.sus && m.pick == nil {
+		m.spin(m.n)
+	}
 	m.do(func(c, i int) {
-		x, y, w := m.select2()
-		z := x.Crossover(y, w)
-		if m.rand() < m.pm {
-			z = z.Mutate()
+		if i < len(elites) {
+			m.tentities[i] = elites[i]
+		} else if e, ok := m.alpsBest(i); ok {
+			m.tentities[i] = e
+		} else {
+			m.tentities[i] = m.offspring(i, m.random(c, i))
 		}
-		m.tentities[i] = z
 	})
-	m.entities, m.tentities = m.tentities, m.entities
-	m.adjust()
-	return m.elite, m.fitness
+	if m.alps != nil {
+		m.alps.ages, m.alps.next = m.alps.next, m.alps.ages
+	}
+	if m.dedup != nil {
+		m.unique(m.tentities, len(elites), false)
+	}
+	if m.validator != nil {
+		m.validate()
+	}
+}
+
+// offspring produces the offspring for the slot i by selection, crossover and mutation,
+// with the random numbers of u.
+func (m *GA) offspring(i int, u func() float64) Entity {
+	x, y, w := m.pair(i, u)
+	if m.mating != nil {
+		for k := 0; k < m.mating.retries && !m.mating.compatible(x, y); k++ {
+			x, y, w = m.pair(i, u)
+		}
+	}
+	return m.vary(i, x, y, w, u)
+}
+
+// pair selects the parents and the crossover weight for the slot i, with the random numbers of u.
+func (m *GA) pair(i int, u func() float64) (x, y Entity, w float64) {
+	ix, iy := -1, -1
+	if m.script != nil {
+		x, y, w = m.script.select2(m, i)
+	} else if m.alps != nil {
+		x, y, w = m.alps.select2(&m.pop, i, u)
+	} else if m.species != nil {
+		x, y, w = m.species.select2(&m.pop, i, u)
+	} else if m.pick != nil {
+		ix, iy, w = m.pick2(u)
+	} else if m.sus {
+		ix, iy, w = m.mate(i)
+	} else {
+		ix, iy, w = m.select2(u)
+	}
+	if ix >= 0 {
+		x, y = m.pop.entities[ix], m.pop.entities[iy]
+	}
+	if len(m.listeners) > 0 {
+		m.selected(i, x, y, ix, iy, w)
+	}
+	return x, y, w
+}
+
+// vary produces the offspring of x and y with the weight w for the slot i, by crossover and mutation.
+func (m *GA) vary(i int, x, y Entity, w float64, u func() float64) Entity {
+	w = m.crossweight(w, u)
+	z, fresh := x, false
+	crossed, mutated := false, false
+	if (m.pc >= 1 || u() < m.pc) && m.compatible(x, y) {
+		z = m.operate("crossover", x, func() (e Entity) {
+			e, fresh = m.crossover(i, x, y, w, u)
+			return
+		})
+		crossed = true
+	}
+	pre := z
+	if m.script != nil {
+		if m.script.mutate(m.gen, i) {
+			z, mutated = m.operate("mutate", z, func() Entity { return m.mutate(i, z, fresh, u) }), true
+		}
+	} else {
+		var pm float64
+		if z, pm = adapt(z, m.pm); u() < pm {
+			z, mutated = m.operate("mutate", z, func() Entity { return m.mutate(i, z, fresh, u) }), true
+		}
+	}
+	if m.bloat != nil && m.bloat.oversized(z) {
+		z, crossed, mutated = x, false, false
+	}
+	if len(m.listeners) > 0 {
+		if crossed {
+			m.emit(Event{Kind: CrossoverEvent, Generation: m.gen + 1, Slot: i, Parents: [2]Entity{x, y}, Weight: w, Entity: pre})
+		}
+		if mutated {
+			m.emit(Event{Kind: MutationEvent, Generation: m.gen + 1, Slot: i, Parents: [2]Entity{pre}, Entity: z})
+		}
+	}
+	if mutated && !crossed && m.replay == nil {
+		m.derive(z, x)
+	}
+	if r, ok := z.(Repairer); ok {
+		z = r.Repair()
+	}
+	if m.replay != nil {
+		m.replay.X, m.replay.Y, m.replay.W = x, y, w
+		m.replay.Crossed, m.replay.Mutated = crossed, mutated
+	} else if m.lineage != nil {
+		m.trace(i, z, x, y, crossed, mutated)
+	}
+	return z
+}
+
+// elites returns the k fittest entities of the population for elitism, the fittest first.
+func (m *GA) elites() []Entity {
+	k := m.elitism
+	if k <= 0 {
+		return nil
+	}
+	if k > m.n {
+		k = m.n
+	}
+	idx := order(m.pop.fitnesses)
+	es := make([]Entity, k)
+	for j := range es {
+		es[j] = m.pop.entities[idx[m.n-1-j]]
+	}
+	return es
+}
+
+// EvolveTo runs the GA model until gen generations have been produced since New,
+// and returns a snapshot of the population at that generation.
+// With the same seed, generator and operators, the snapshot is reproducible.
+// If the model is already past gen, the current population is returned.
+// As for Population, the entities implementing MutableEntity are overwritten by the later generations.
+func (m *GA) EvolveTo(gen int) []Entity {
+	for m.gen < gen {
+		m.Next()
+	}
+	es := make([]Entity, m.n)
+	copy(es, m.pop.entities)
+	return es
 }
 
 // Evolve runs the GA model until the elite k generations have not changed,
 // or the max of iterations has been reached.
+// The progress may be observed concurrently by Elite, Fitness, Generation, Evaluations and SnapshotStats,
+// but the model must not be modified then.
 func (m *GA) Evolve(k int, max int) (Entity, float64, bool) {
-	i, fitness := 0, m.fitness
-	for j := 0; i < k && j < max; i, j = i+1, j+1 {
-		_, f := m.Next()
-		if fitness < f {
-			i, fitness = 0, f
-		}
+	r := m.Generations(context.Background(), k, max)
+	for r.Next() {
 	}
-	return m.elite, fitness, i >= k
+	return r.Result()
+}
+
+// swap swaps the current and the previous generations.
+func (m *GA) swap() {
+	p := &m.pop
+	p.entities, m.tentities = m.tentities, p.entities
+	p.fitnesses, m.tfitnesses = m.tfitnesses, p.fitnesses
 }
 
 func (m *GA) adjust() float64 {
-	sms, svs, mfs, mes := make([]float64, NC), make([]float64, NC), make([]float64, NC), make([]Entity, NC)
-	for c := range mfs {
-		mfs[c] = math.Inf(-1)
+	if m.mo != nil {
+		m.rank()
+	} else {
+		m.pop.evaluate()
 	}
-	m.do(func(c, i int) {
-		e := m.entities[i]
-		f := e.Fitness()
-		m.fentities[i] = f
-		sms[c] += f
-		svs[c] += f * f
-		if mfs[c] < f {
-			mfs[c], mes[c] = f, e
-		}
-	})
-	if c, f := max(mfs); m.fitness < f {
-		m.fitness, m.elite = f, mes[c]
+	return m.normalize()
+}
+
+func (m *GA) eval(e Entity) float64 {
+	ctx := m.pop.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return m.penalize(e, m.evalContext(ctx, e))
+}
+
+// evalContext returns the objective of e, which is penalized by the callers,
+// so that an evaluation abandoned by the deadline never reads the generation.
+func (m *GA) evalContext(ctx context.Context, e Entity) float64 {
+	if m.noise != nil {
+		return m.objective(m.noise.sample(ctx, m, e))
 	}
+	return m.objective(m.measure(ctx, e))
+}
 
-	mean, std := sum(sms)/float64(m.n), 1.0
-	if v := sum(svs)/float64(m.n) - mean*mean; v > 0 {
-		std = math.Sqrt(v)
+// penalize returns the fitness f of e penalized by its violation, if any.
+// The fitness -Inf, e.g. of an abandoned evaluation, is kept.
+func (m *GA) penalize(e Entity, f float64) float64 {
+	if m.penalty == nil || math.IsInf(f, -1) {
+		return f
 	}
-	if m.base > 0 {
-		m.pm *= 0.2*math.Exp(-5*std/m.base) + 0.9
-		if m.pm > 0.1 {
-			m.pm = 0.1
-		} else if m.pm < 0.0001 {
-			m.pm = 0.0001
+	if c, ok := e.(Constrained); ok {
+		if v := c.Violation(); v > 0 {
+			return m.penalty(m.gen, f, v)
 		}
 	}
+	return f
+}
 
-	fsums := make([]float64, NC)
-	m.do(func(c, i int) {
-		f := 1 / (1 + math.Exp((mean-m.fentities[i])/std))
-		m.fentities[i] = f
-		fsums[c] += f
+// measure returns the fitness of e, by the oracle, the memo, the cache, FitnessContext or Fitness.
+func (m *GA) measure(ctx context.Context, e Entity) float64 {
+	if m.oracle != nil {
+		return m.lookup(e)
+	}
+	if m.memo == nil || !comparable(e) {
+		return m.compute(ctx, e)
+	}
+	if f, ok := m.memo.get(e); ok {
+		return f
+	}
+	f := m.compute(ctx, e)
+	if ctx.Err() == nil {
+		m.memo.put(e, f)
+	}
+	return f
+}
+
+// compute returns the fitness of e, by the cache, FitnessContext or Fitness.
+func (m *GA) compute(ctx context.Context, e Entity) float64 {
+	if m.cache != nil {
+		f, ok := m.cache.fitness(e)
+		if ok {
+			atomic.AddInt64(&m.evals, 1)
+		}
+		return f
+	}
+	atomic.AddInt64(&m.evals, 1)
+	return m.guarded(func() float64 {
+		if x, ok := m.parentOf(e); ok {
+			return e.(DeltaEntity).DeltaFitness(x)
+		}
+		if c, ok := e.(FitnessContext); ok {
+			return m.timed(ctx, c)
+		}
+		return fitness(e)
 	})
-	m.fsum = sum(fsums)
+}
+
+// Prepare re-evaluates the current population, and recomputes the statistics, the selection weights and the elite,
+// without producing offspring, counting a generation or adapting the mutation probability.
+// It must be called after any external modification of the population,
+// so that later selections use fresh weights.
+func (m *GA) Prepare() {
+	if m.memo != nil {
+		m.memo.reset()
+	}
+	m.pop.evaluate()
+	m.refresh()
+}
+
+// refresh recomputes the statistics, the selection weights and the elite from the fitnesses.
+func (m *GA) refresh() {
+	m.pop.summarize()
+	if e, f := m.pop.Best(); m.fitter(e, f) {
+		m.fitness, m.elite = f, e
+	}
+	m.reweigh()
+	m.publish()
+}
+
+func (m *GA) normalize() float64 {
+	if m.fallback == RegenerateFallback && m.infeasible() != nil {
+		m.spawn(m.n, func(i int) {
+			m.pop.entities[i] = m.g()
+		})
+		m.pop.evaluate()
+	}
+	m.pop.summarize()
+	if m.relative {
+		m.elite, m.fitness = nil, math.Inf(-1)
+	}
+	if e, f := m.pop.Best(); m.fitter(e, f) {
+		m.fitness, m.elite = f, e
+	}
+	return m.weigh()
+}
+
+// weigh adapts the mutation probability, and computes the selection weights.
+func (m *GA) weigh() float64 {
+	_, std := m.pop.Stats()
+	if std == 0 {
+		std = 1
+	}
+	if m.schedule != nil {
+		m.plan()
+	} else if m.base > 0 && !m.fixed {
+		if m.policy != nil {
+			s := m.snapshot()
+			s.Generation++
+			m.pm = m.policy(s)
+		} else {
+			m.pm *= 0.2*math.Exp(-5*std/m.base) + 0.9
+		}
+		if m.pm > m.pmax {
+			m.pm = m.pmax
+		} else if m.pm < m.pmin {
+			m.pm = m.pmin
+		}
+	}
+	m.reweigh()
 	return std
 }
 
-func (m *GA) select2() (Entity, Entity, float64) {
-	rx, ry := m.rand(), m.rand()
+// reweigh computes the selection weights, without adapting the mutation probability.
+func (m *GA) reweigh() {
+	if m.bloat != nil && m.bloat.parsimony {
+		m.bloat.weigh(&m.pop)
+	} else {
+		m.pop.weigh()
+	}
+	if m.linkage != nil {
+		m.linkage.learn(&m.pop)
+	}
+	if m.pressure != 1 {
+		m.pop.sharpen(m.pressure)
+	}
+	if m.fallback == ViolationFallback {
+		if vs := m.infeasible(); vs != nil {
+			var s moments
+			s.reset(vs)
+			m.pop.scale(neg(vs), -s.mean, math.Sqrt(s.variance()))
+		}
+	}
+	if m.niche != nil {
+		m.niche.apply(&m.pop)
+	}
+	if m.species != nil {
+		m.species.apply(&m.pop)
+	}
+	if m.share > 0 {
+		m.pop.cap(m.share)
+	}
+	if s, ok := m.selector.(PopulationSelector); ok {
+		m.pick = s.SelectionOf(m.pop.entities, m.pop.fitnesses)
+	} else if m.selector != nil {
+		m.pick = m.selector.Selection(m.pop.fitnesses)
+	}
+	m.sorted = nil
+	if m.metric != nil {
+		m.diversity = m.metric(m.pop.entities)
+	}
+}
+
+// replace replaces the entities at idx with es, and updates the statistics incrementally,
+// which evaluates only the new entities, and adapts the mutation probability as a generation.
+func (m *GA) replace(idx []int, es []Entity) float64 {
+	m.admit(idx, es)
+	return m.weigh()
+}
+
+// admit replaces the entities at idx with es like replace, but leaves the selection weights to the caller.
+func (m *GA) admit(idx []int, es []Entity) {
+	m.dropAt(idx)
+	for i, f := range m.pop.replace(idx, es) {
+		if m.fitter(es[i], f) {
+			m.fitness, m.elite = f, es[i]
+		}
+	}
+}
+
+// select2 selects the indices of the parents by the roulette wheel, with the random numbers of u.
+func (m *GA) select2(u func() float64) (int, int, float64) {
+	rx, ry := u(), u()
 	if rx > ry {
 		rx, ry = ry, rx
 	}
-	fz, d, isx := m.fsum*rx, m.fsum*(ry-rx), true
-	x, y, wx, wy := m.entities[0], m.entities[m.n-1], 0.0, 0.0
-	for i, f := range m.fentities {
+	p := &m.pop
+	fz, d, isx := p.fsum*rx, p.fsum*(ry-rx), true
+	x, y, wx, wy := 0, m.n-1, 0.0, 0.0
+	for i, f := range p.weights {
 		if fz <= f {
 			if isx {
-				x, wx, isx = m.entities[i], f, false
+				x, wx, isx = i, f, false
 				fz = fz + d - f*ry
 				continue
 			} else {
-				y, wy = m.entities[i], f
+				y, wy = i, f
 				break
 			}
 		}
@@ -160,24 +786,32 @@ func (m *GA) select2() (Entity, Entity, float64) {
 	return x, y, wx / (wx + wy)
 }
 
-func (m *GA) rand() float64 {
-	m.mutex.Lock()
-	defer m.mutex.Unlock()
-	return m.rnd.Float64()
+func (m *GA) perturb(i int) Entity {
+	e := m.solution
+	if i > 0 {
+		for j := 0; j < m.spread; j++ {
+			e = e.Mutate()
+		}
+	}
+	return e
 }
 
 func (m *GA) do(f func(c, i int)) {
-	var wg sync.WaitGroup
-	wg.Add(NC)
-	for c := 0; c < NC; c++ {
-		go func(c int) {
-			defer wg.Done()
-			for i := c; i < m.n; i += NC {
-				f(c, i)
-			}
-		}(c)
+	m.pop.parallel(m.n, f)
+}
+
+// spawn calls f for the slots [0, n) of the entities generated by the generator,
+// in parallel, or in order if the generator is sequential, e.g. of NewSampled.
+func (m *GA) spawn(n int, f func(i int)) {
+	if m.sequential {
+		for i := 0; i < n; i++ {
+			f(i)
+		}
+		return
 	}
-	wg.Wait()
+	m.pop.parallel(n, func(c, i int) {
+		f(i)
+	})
 }
 
 func sum(xs []float64) float64 {
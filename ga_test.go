@@ -3,7 +3,6 @@ package ga_test
 import (
 	"math/rand"
 	"testing"
-	"time"
 
 	"github.com/ofunc/ga"
 )
@@ -23,10 +22,6 @@ func (b Benchmark) Crossover(e ga.Entity, w float64) ga.Entity {
 	return Benchmark(w*float64(b) + (1-w)*x)
 }
 
-func init() {
-	rand.Seed(time.Now().Unix())
-}
-
 func BenchmarkGA(b *testing.B) {
 	m := ga.New(10000, Benchmark(0).Mutate)
 	for i := 0; i < b.N; i++ {
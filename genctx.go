@@ -0,0 +1,80 @@
+package ga
+
+import "math/rand/v2"
+
+// GenCtx is the context of the creation of an entity, to scale e.g. the step sizes of the generated entities
+// and the mutations with the progress of the search, see NewCtx and ContextMutator.
+type GenCtx struct {
+	// Generation is the number of generations produced since New, i.e. of the parents.
+	Generation int
+	// Rand returns the random numbers in [0, 1) of the model, which is not safe to keep after the call.
+	// They are reproducible by WithSeed with WithReproducibleParallel, or without parallelism.
+	Rand func() float64
+	// Stats is the statistics of the last generation, or zero for the initial population.
+	Stats Stats
+}
+
+// Random returns a generator of the random numbers of Rand, e.g. for IntN, Perm or NormFloat64, which is not safe to keep after the call either,
+// or of the global source of math/rand/v2 if Rand is nil, e.g. for the zero GenCtx.
+func (c GenCtx) Random() *rand.Rand {
+	if c.Rand == nil {
+		return rand.New(global{})
+	}
+	return rand.New(floats(c.Rand))
+}
+
+// floats is a source of the random numbers in [0, 1), which takes 32 bits from each of them.
+type floats func() float64
+
+func (u floats) Uint64() uint64 {
+	return uint64(u()*(1<<32))<<32 | uint64(u()*(1<<32))
+}
+
+// global is the global source of math/rand/v2.
+type global struct{}
+
+func (global) Uint64() uint64 {
+	return rand.Uint64()
+}
+
+// ContextMutator is an entity mutated by MutateCtx with the context of the generation, instead of Mutate.
+type ContextMutator interface {
+	Entity
+	MutateCtx(ctx GenCtx) Entity
+}
+
+// ContextCrossover is an entity crossed over by CrossoverCtx with the context of the generation, instead of Crossover.
+type ContextCrossover interface {
+	Entity
+	CrossoverCtx(ctx GenCtx, e Entity, w float64) Entity
+}
+
+// NewCtx creates a GA model like New, with the generator g of the context of the generation,
+// e.g. for the immigrants of WithRandomImmigrants, or the restarts by Reset.
+// With WithReproducibleParallel, the initial population is generated in the order of the slots,
+// so that it is reproducible by WithSeed too.
+func NewCtx(n int, g func(GenCtx) Entity, opts ...Option) *GA {
+	return newGA(n, nil, nil, append([]Option{withGenerator(g)}, opts...))
+}
+
+// withGenerator sets the generator of the context, which is bound to the model it is applied to,
+// so the copies of the model, e.g. by Clone, get their own contexts.
+func withGenerator(g func(GenCtx) Entity) Option {
+	return func(m *GA) {
+		m.g = func() Entity {
+			return g(m.context(m.draw()))
+		}
+		m.generator, m.indexed = m.g, true
+	}
+}
+
+// context returns the context of the current generation with the random numbers of u.
+func (m *GA) context(u func() float64) GenCtx {
+	return GenCtx{Generation: m.gen, Rand: u, Stats: m.stats}
+}
+
+// draw returns the independent random numbers of a call of the generator, which is safe for concurrent use.
+func (m *GA) draw() func() float64 {
+	s := stream(mix(uint64(m.seed) ^ mix(m.draws.Add(1))))
+	return s.Float64
+}
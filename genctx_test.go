@@ -0,0 +1,131 @@
+package ga_test
+
+import (
+	"bytes"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+// Shrink is a point mutated by a step size shrinking with the generations.
+type Shrink float64
+
+func (s Shrink) Fitness() float64 {
+	return -sqr(float64(s) - 3)
+}
+
+func (s Shrink) Mutate() ga.Entity {
+	panic("Mutate should not be called")
+}
+
+func (s Shrink) MutateCtx(ctx ga.GenCtx) ga.Entity {
+	d := 4 / float64(1+ctx.Generation)
+	return s + Shrink(d*(2*ctx.Rand()-1))
+}
+
+func (s Shrink) Crossover(e ga.Entity, w float64) ga.Entity {
+	return Shrink(w*float64(s) + (1-w)*float64(e.(Shrink)))
+}
+
+func TestNewCtx(t *testing.T) {
+	var mutex sync.Mutex
+	gens := make(map[int]int)
+	m := ga.NewCtx(30, func(ctx ga.GenCtx) ga.Entity {
+		mutex.Lock()
+		gens[ctx.Generation]++
+		mutex.Unlock()
+		if ctx.Generation > 0 && ctx.Stats.Generation != ctx.Generation {
+			t.Error("stats:", ctx.Stats.Generation, ctx.Generation)
+		}
+		return Shrink(20*ctx.Rand() - 10)
+	}, ga.WithRandomImmigrants(0.1), ga.WithSeed(1))
+	_, f := m.EvolveUntil(ga.MaxGenerations(30))
+	if math.Abs(f) > 1e-3 {
+		t.Fatal("fitness:", f)
+	}
+	if gens[0] != 33 || gens[10] != 3 || len(gens) < 30 {
+		t.Fatal("generations:", gens)
+	}
+	if c := m.Clone(); c.EvolveTo(31) == nil || gens[30] != 3 {
+		t.Fatal("clone:", gens[30])
+	}
+}
+
+func TestNewCtxCheckpoint(t *testing.T) {
+	nc := ga.NC
+	ga.NC = 1
+	defer func() { ga.NC = nc }()
+
+	newSaved := func() *ga.GA {
+		return ga.NewCtx(30, func(ctx ga.GenCtx) ga.Entity {
+			return Saved(20*ctx.Rand() - 10)
+		}, ga.WithRandomImmigrants(0.2), ga.WithSeed(5))
+	}
+	m := newSaved()
+	m.EvolveTo(5)
+	c := m.Clone()
+	var buf bytes.Buffer
+	if err := m.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	xs := m.EvolveTo(15)
+
+	r := newSaved()
+	if err := r.Load(&buf, decodeSaved); err != nil {
+		t.Fatal(err)
+	}
+	ys := r.EvolveTo(15)
+	for i := range xs {
+		if xs[i] != ys[i] {
+			t.Fatal("resume:", i, xs[i], ys[i])
+		}
+	}
+	fresh := newSaved().Population()
+	for i, e := range c.EvolveTo(6) {
+		for _, x := range fresh {
+			if e == x {
+				t.Fatal("clone redraws the initial population:", i, e)
+			}
+		}
+	}
+}
+
+// Blend is a Shrink crossed over by a random weight of the context.
+type Blend struct {
+	Shrink
+}
+
+func (b Blend) MutateCtx(ctx ga.GenCtx) ga.Entity {
+	return Blend{b.Shrink.MutateCtx(ctx).(Shrink)}
+}
+
+func (b Blend) Crossover(e ga.Entity, w float64) ga.Entity {
+	panic("Crossover should not be called")
+}
+
+func (b Blend) CrossoverCtx(ctx ga.GenCtx, e ga.Entity, w float64) ga.Entity {
+	w = ctx.Random().Float64()
+	return Blend{Shrink(w*float64(b.Shrink) + (1-w)*float64(e.(Blend).Shrink))}
+}
+
+func TestContextCrossover(t *testing.T) {
+	run := func() []ga.Entity {
+		m := ga.NewCtx(30, func(ctx ga.GenCtx) ga.Entity {
+			return Blend{Shrink(20*ctx.Random().Float64() - 10)}
+		}, ga.WithSeed(3), ga.WithReproducibleParallel())
+		return m.EvolveTo(20)
+	}
+	a, b := run(), run()
+	best := math.Inf(-1)
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatal("not reproducible:", i, a[i], b[i])
+		}
+		best = math.Max(best, a[i].Fitness())
+	}
+	if best < -1e-2 {
+		t.Fatal("fitness:", best)
+	}
+}
@@ -0,0 +1,91 @@
+package ga
+
+import "context"
+
+// Evolution is a resumable run of EvolveContext, which produces one generation at every call of Next,
+// so the caller can interleave its own logic between the generations, e.g. saving checkpoints,
+// changing the parameters or injecting entities, and still stop by the stagnation of the elite:
+//
+//	r := m.Generations(ctx, k, max)
+//	for r.Next() {
+//		// between the generations
+//	}
+//	e, f, ok := r.Result()
+type Evolution struct {
+	m       *GA
+	ctx     context.Context
+	k, max  int
+	i, j    int
+	resets  int
+	fitness float64
+	last    float64
+	err     error
+	done    bool
+}
+
+// Generations starts a resumable run of the GA model like EvolveContext(ctx, k, max).
+func (m *GA) Generations(ctx context.Context, k int, max int) *Evolution {
+	m.halt = false
+	return &Evolution{m: m, ctx: ctx, k: k, max: max, fitness: m.fitness, last: m.fitness}
+}
+
+// Next produces the next generation, and reports whether it is produced.
+// It returns false once the elite k generations have not changed, max generations have been produced,
+// the model is halted, or ctx is done, and then the run is over.
+func (r *Evolution) Next() bool {
+	m := r.m
+	if r.i >= r.k && r.j < r.max && !r.done && !m.halt && m.restarts != nil && r.resets < m.restarts.max {
+		m.restart()
+		r.i, r.resets = 0, r.resets+1
+	}
+	if r.done || r.i >= r.k || r.j >= r.max || m.halt {
+		r.done = true
+		return false
+	}
+	if _, _, err := m.NextContext(r.ctx); err != nil {
+		r.err, r.done = err, true
+		return false
+	}
+	if r.fitness < m.fitness {
+		r.fitness = m.fitness
+	}
+	if m.improved(r.last, m.fitness) {
+		r.i, r.last = 0, m.fitness
+	}
+	r.i, r.j = r.i+1, r.j+1
+	return true
+}
+
+// Generation returns the number of generations produced by the run.
+func (r *Evolution) Generation() int {
+	return r.j
+}
+
+// Stagnation returns the number of generations counted towards k, since the elite last improved, see WithImprovementEpsilon.
+func (r *Evolution) Stagnation() int {
+	return r.i
+}
+
+// Restarts returns the number of restarts of the run by WithAutoRestart.
+func (r *Evolution) Restarts() int {
+	return r.resets
+}
+
+// Err returns the error of ctx if it stopped the run.
+func (r *Evolution) Err() error {
+	return r.err
+}
+
+// Result returns the elite and fitness so far, and whether the run has stopped by the stagnation or a halt,
+// the same as EvolveContext.
+func (r *Evolution) Result() (Entity, float64, bool) {
+	m := r.m
+	if r.err != nil {
+		return m.elite, m.objective(r.fitness), false
+	}
+	fitness := r.fitness
+	if m.noise != nil || m.dynamic {
+		fitness = m.fitness
+	}
+	return m.elite, m.objective(fitness), r.i >= r.k || m.halt
+}
@@ -0,0 +1,36 @@
+package ga_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestGenerations(t *testing.T) {
+	m := ga.New(50, MIN{}.Mutate, ga.WithSeed(1))
+	r := m.Generations(context.Background(), 30, 100)
+	n := 0
+	for r.Next() {
+		if n++; r.Generation() != n || m.Generation() != n || r.Stagnation() > 30 {
+			t.Fatal("generation:", n, r.Generation(), m.Generation(), r.Stagnation())
+		}
+		if n == 5 {
+			es := m.Population()
+			es[0] = MIN{}
+			m.SetPopulation(es)
+		}
+	}
+	e, f, ok := r.Result()
+	if r.Next() || r.Err() != nil || e != m.Elite() || f != 0 || ok != (n < 100) {
+		t.Fatal("result:", n, e, f, ok, r.Err())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r = m.Generations(ctx, 30, 100)
+	r.Next()
+	cancel()
+	if r.Next() || r.Err() != context.Canceled {
+		t.Fatal("context:", r.Err())
+	}
+}
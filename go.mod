@@ -1,3 +1,3 @@
 module github.com/ofunc/ga
 
-go 1.13
+go 1.22
@@ -0,0 +1,347 @@
+// Package gp implements the genetic programming over expression trees on GA model,
+// with subtree crossover, point and subtree mutations, depth limits and an interpreter.
+// The trees implement ga.Sized, so the bloat may be controlled by ga.WithParsimony and ga.WithMaxSize too.
+package gp
+
+import (
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+
+	"github.com/ofunc/ga"
+)
+
+// Op is a function of the expression trees.
+type Op struct {
+	Name  string
+	Arity int
+	F     func(args []float64) float64
+}
+
+// The common arithmetic functions.
+var (
+	Add = Op{"+", 2, func(a []float64) float64 { return a[0] + a[1] }}
+	Sub = Op{"-", 2, func(a []float64) float64 { return a[0] - a[1] }}
+	Mul = Op{"*", 2, func(a []float64) float64 { return a[0] * a[1] }}
+	// Div is the protected division, which returns 1 if the divisor is 0.
+	Div = Op{"/", 2, func(a []float64) float64 {
+		if a[1] == 0 {
+			return 1
+		}
+		return a[0] / a[1]
+	}}
+	Sin = Op{"sin", 1, func(a []float64) float64 { return math.Sin(a[0]) }}
+	Cos = Op{"cos", 1, func(a []float64) float64 { return math.Cos(a[0]) }}
+)
+
+// Space is the space of the expression trees over the functions, the variables and the constants.
+type Space struct {
+	ops      []Op
+	vars     int
+	lo, hi   float64
+	consts   bool
+	depth    int
+	max      int
+	fitness  func(*Tree) float64
+	psubtree float64
+	fair     bool
+}
+
+// Option is an option of Space.
+type Option func(*Space)
+
+// WithConstants enables the ephemeral random constants, uniformly distributed in [lo, hi).
+func WithConstants(lo, hi float64) Option {
+	return func(s *Space) {
+		s.lo, s.hi, s.consts = lo, hi, true
+	}
+}
+
+// WithDepth sets the depth of the initial trees, default to 4, and the maximum depth of all trees, default to 17.
+func WithDepth(initial, max int) Option {
+	return func(s *Space) {
+		s.depth, s.max = initial, max
+	}
+}
+
+// WithSubtreeMutation sets the probability of subtree mutation, otherwise point mutation is applied, default to 0.5.
+func WithSubtreeMutation(p float64) Option {
+	return func(s *Space) {
+		s.psubtree = p
+	}
+}
+
+// WithSizeFairCrossover makes the crossover size-fair, which replaces a subtree of the receiver
+// only by a subtree of the other parent of at most 1+2 times its size, so the trees do not bloat by crossover.
+func WithSizeFairCrossover() Option {
+	return func(s *Space) {
+		s.fair = true
+	}
+}
+
+// NewSpace creates the space of the expression trees over the functions ops and vars variables,
+// with the fitness function f.
+func NewSpace(ops []Op, vars int, f func(*Tree) float64, opts ...Option) *Space {
+	s := &Space{
+		ops:      ops,
+		vars:     vars,
+		depth:    4,
+		max:      17,
+		fitness:  f,
+		psubtree: 0.5,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Random returns a random tree by the ramped half-and-half method, which can be the generator of GA model.
+func (s *Space) Random() ga.Entity {
+	return s.RandomCtx(ga.GenCtx{})
+}
+
+// RandomCtx is Random with the random numbers of ctx, which can be the generator of ga.NewCtx,
+// so that the trees are reproducible by ga.WithSeed.
+func (s *Space) RandomCtx(ctx ga.GenCtx) ga.Entity {
+	r := ctx.Random()
+	d := 1 + r.IntN(s.depth)
+	return &Tree{s.grow(r, d, r.IntN(2) == 0), s}
+}
+
+// node is a node of the expression tree.
+// It is a function if op >= 0, a variable if op == variable, or a constant.
+type node struct {
+	op    int
+	v     int
+	c     float64
+	kids  []*node
+	depth int
+}
+
+const (
+	variable = -1
+	constant = -2
+)
+
+// grow returns a random tree of at most depth d, or exactly d if full, with the random numbers of r.
+func (s *Space) grow(r *rand.Rand, d int, full bool) *node {
+	nt := s.vars
+	if s.consts {
+		nt++
+	}
+	if d <= 1 || len(s.ops) == 0 || !full && r.IntN(len(s.ops)+nt) >= len(s.ops) {
+		return s.terminal(r)
+	}
+	n := &node{op: r.IntN(len(s.ops))}
+	n.kids = make([]*node, s.ops[n.op].Arity)
+	for i := range n.kids {
+		n.kids[i] = s.grow(r, d-1, full)
+	}
+	n.fix()
+	return n
+}
+
+func (s *Space) terminal(r *rand.Rand) *node {
+	if s.consts && (s.vars == 0 || r.IntN(s.vars+1) == 0) {
+		return &node{op: constant, c: s.lo + r.Float64()*(s.hi-s.lo), depth: 1}
+	}
+	return &node{op: variable, v: r.IntN(s.vars), depth: 1}
+}
+
+func (n *node) fix() {
+	n.depth = 1
+	for _, k := range n.kids {
+		if k.depth+1 > n.depth {
+			n.depth = k.depth + 1
+		}
+	}
+}
+
+// Tree is an expression tree of a space, which implements ga.Entity,
+// and ga.ContextMutator and ga.ContextCrossover to draw the random numbers of the model.
+type Tree struct {
+	root *node
+	s    *Space
+}
+
+// Eval evaluates the tree with the values x of the variables.
+func (t *Tree) Eval(x []float64) float64 {
+	return t.s.eval(t.root, x)
+}
+
+func (s *Space) eval(n *node, x []float64) float64 {
+	switch n.op {
+	case variable:
+		return x[n.v]
+	case constant:
+		return n.c
+	}
+	args := make([]float64, len(n.kids))
+	for i, k := range n.kids {
+		args[i] = s.eval(k, x)
+	}
+	return s.ops[n.op].F(args)
+}
+
+// Depth returns the depth of the tree, where a single terminal has the depth 1.
+func (t *Tree) Depth() int {
+	return t.root.depth
+}
+
+// Size returns the number of the nodes of the tree.
+func (t *Tree) Size() int {
+	return size(t.root)
+}
+
+func size(n *node) int {
+	k := 1
+	for _, c := range n.kids {
+		k += size(c)
+	}
+	return k
+}
+
+// String returns the tree in the prefix notation, e.g. (+ x0 (* x0 x0)).
+func (t *Tree) String() string {
+	var b strings.Builder
+	t.s.write(&b, t.root)
+	return b.String()
+}
+
+func (s *Space) write(b *strings.Builder, n *node) {
+	switch n.op {
+	case variable:
+		b.WriteString("x" + strconv.Itoa(n.v))
+	case constant:
+		b.WriteString(strconv.FormatFloat(n.c, 'g', 4, 64))
+	default:
+		b.WriteString("(" + s.ops[n.op].Name)
+		for _, k := range n.kids {
+			b.WriteByte(' ')
+			s.write(b, k)
+		}
+		b.WriteByte(')')
+	}
+}
+
+// Fitness returns the fitness of the tree.
+func (t *Tree) Fitness() float64 {
+	return t.s.fitness(t)
+}
+
+// Mutate replaces a random subtree by a random one, or changes a random node to another of the same arity.
+// The result is never deeper than the maximum depth.
+func (t *Tree) Mutate() ga.Entity {
+	return t.MutateCtx(ga.GenCtx{})
+}
+
+// MutateCtx is Mutate with the random numbers of ctx.
+func (t *Tree) MutateCtx(ctx ga.GenCtx) ga.Entity {
+	s, r := t.s, ctx.Random()
+	path := pick(r, t.root)
+	if r.Float64() < s.psubtree {
+		d := s.max - len(path) + 1
+		if d > s.depth {
+			d = s.depth
+		}
+		return &Tree{replace(t.root, path, s.grow(r, d, false)), s}
+	}
+	n := *at(t.root, path)
+	if n.op < 0 {
+		m := s.terminal(r)
+		n.op, n.v, n.c = m.op, m.v, m.c
+	} else {
+		var same []int
+		for i, op := range s.ops {
+			if op.Arity == len(n.kids) {
+				same = append(same, i)
+			}
+		}
+		n.op = same[r.IntN(len(same))]
+	}
+	return &Tree{replace(t.root, path, &n), s}
+}
+
+// Crossover replaces a random subtree of t by a random subtree of e, see WithSizeFairCrossover.
+// If the result would be deeper than the maximum depth, t itself is returned. The weight is ignored.
+func (t *Tree) Crossover(e ga.Entity, w float64) ga.Entity {
+	return t.CrossoverCtx(ga.GenCtx{}, e, w)
+}
+
+// CrossoverCtx is Crossover with the random numbers of ctx.
+func (t *Tree) CrossoverCtx(ctx ga.GenCtx, e ga.Entity, w float64) ga.Entity {
+	r := ctx.Random()
+	path := pick(r, t.root)
+	var sub *node
+	if root := e.(*Tree).root; t.s.fair {
+		sub = pickAtMost(r, root, 1+2*size(at(t.root, path)))
+	} else {
+		sub = at(root, pick(r, root))
+	}
+	if len(path)-1+sub.depth > t.s.max {
+		return t
+	}
+	return &Tree{replace(t.root, path, sub), t.s}
+}
+
+// pick returns the path of a uniformly random node, as the indices of the children from the root.
+// The first element of the path is always 0, standing for the root.
+func pick(r *rand.Rand, root *node) []int {
+	k := r.IntN(size(root))
+	path := []int{0}
+	for n := root; k > 0; {
+		k--
+		for i, c := range n.kids {
+			if s := size(c); k < s {
+				path, n = append(path, i), c
+				break
+			} else {
+				k -= s
+			}
+		}
+	}
+	return path
+}
+
+// pickAtMost returns a uniformly random subtree of root of at most max nodes, which always exists as max >= 1.
+func pickAtMost(r *rand.Rand, root *node, max int) *node {
+	var sub *node
+	k := 0
+	var walk func(n *node) int
+	walk = func(n *node) int {
+		s := 1
+		for _, c := range n.kids {
+			s += walk(c)
+		}
+		if s <= max {
+			if k++; r.IntN(k) == 0 {
+				sub = n
+			}
+		}
+		return s
+	}
+	walk(root)
+	return sub
+}
+
+func at(root *node, path []int) *node {
+	n := root
+	for _, i := range path[1:] {
+		n = n.kids[i]
+	}
+	return n
+}
+
+// replace returns a copy of root with the node at path replaced by sub, sharing the other subtrees.
+func replace(root *node, path []int, sub *node) *node {
+	if len(path) == 1 {
+		return sub
+	}
+	n := *root
+	n.kids = append([]*node(nil), root.kids...)
+	n.kids[path[1]] = replace(root.kids[path[1]], path[1:], sub)
+	n.fix()
+	return &n
+}
@@ -0,0 +1,56 @@
+package gp_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ofunc/ga"
+	"github.com/ofunc/ga/gp"
+)
+
+func TestSymbolicRegression(t *testing.T) {
+	xs := []float64{-1, -0.5, 0, 0.5, 1, 1.5, 2}
+	s := gp.NewSpace([]gp.Op{gp.Add, gp.Sub, gp.Mul}, 1, func(t *gp.Tree) float64 {
+		e := 0.0
+		for _, x := range xs {
+			d := t.Eval([]float64{x}) - (x*x*x + x)
+			e += d * d
+		}
+		if math.IsNaN(e) {
+			return math.Inf(-1)
+		}
+		return -e / float64(len(xs))
+	}, gp.WithDepth(4, 8))
+	m := ga.New(300, s.Random)
+	e, f, _ := m.Evolve(50, 500)
+	if f < -1e-2 {
+		t.Fatal("fitness:", f, e)
+	}
+}
+
+func TestDepthLimit(t *testing.T) {
+	s := gp.NewSpace([]gp.Op{gp.Add, gp.Sin, gp.Div}, 2, nil, gp.WithConstants(-1, 1), gp.WithDepth(3, 5))
+	x, y := s.Random().(*gp.Tree), s.Random().(*gp.Tree)
+	for i := 0; i < 1000; i++ {
+		for _, e := range []ga.Entity{x.Mutate(), x.Crossover(y, 0.5)} {
+			if d := e.(*gp.Tree).Depth(); d > 5 {
+				t.Fatal("depth:", d, e)
+			}
+		}
+		x, y = x.Crossover(y, 0.5).(*gp.Tree), y.Mutate().(*gp.Tree)
+	}
+	if v := x.Eval([]float64{0.3, 0.7}); math.IsNaN(v) {
+		t.Fatal("eval:", x, v)
+	}
+}
+
+func TestSizeFairCrossover(t *testing.T) {
+	s := gp.NewSpace([]gp.Op{gp.Add, gp.Mul}, 1, nil, gp.WithDepth(2, 17), gp.WithSizeFairCrossover())
+	big := gp.NewSpace([]gp.Op{gp.Add, gp.Mul}, 1, nil, gp.WithDepth(8, 17))
+	for i := 0; i < 200; i++ {
+		x, y := s.Random().(*gp.Tree), big.Random().(*gp.Tree)
+		if z := x.Crossover(y, 0.5).(*gp.Tree); z.Size() > 3*x.Size() {
+			t.Fatal("size:", x.Size(), z.Size(), z)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package ga
+
+// WithRegressionGuard sets a guard against unexpected regressions, which often signal bugs in operators.
+// If the best fitness of a generation is worse than the previous generation's by more than threshold,
+// f is called with the generation number and both best fitnesses.
+// If f returns true, the generation is rolled back to the previous population, though it is still counted.
+// The previous population is already retained by the double buffer of GA model,
+// so the guard costs no extra memory.
+func WithRegressionGuard(threshold float64, f func(gen int, prev, cur float64) bool) Option {
+	return func(m *GA) {
+		m.threshold, m.regression = threshold, f
+	}
+}
+
+func (m *GA) guard(prev float64) {
+	if m.regression == nil {
+		return
+	}
+	if _, best := m.pop.Best(); best < prev-m.threshold && m.regression(m.gen, prev, best) {
+		m.swap()
+		m.normalize()
+	}
+}
@@ -0,0 +1,53 @@
+package ga_test
+
+import (
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+type Buggy struct {
+	MIN
+	Broken bool
+}
+
+func (b Buggy) Fitness() float64 {
+	if b.Broken {
+		return -1e6
+	}
+	return b.MIN.Fitness()
+}
+
+func (b Buggy) Mutate() ga.Entity {
+	return Buggy{b.MIN.Mutate().(MIN), broken}
+}
+
+func (b Buggy) Crossover(e ga.Entity, w float64) ga.Entity {
+	return Buggy{b.MIN.Crossover(e.(Buggy).MIN, w).(MIN), broken}
+}
+
+var broken bool
+
+func TestRegressionGuard(t *testing.T) {
+	calls := 0
+	m := ga.New(50, Buggy{}.Mutate, ga.WithRegressionGuard(1e3, func(gen int, prev, cur float64) bool {
+		calls++
+		if gen != 3 || cur != -1e6 || prev < -50 {
+			t.Fatal("regression:", gen, prev, cur)
+		}
+		return true
+	}))
+	m.Next()
+	m.Next()
+	broken = true
+	m.Next()
+	broken = false
+	if calls != 1 {
+		t.Fatal("calls:", calls)
+	}
+	for _, e := range m.EvolveTo(3) {
+		if e.(Buggy).Broken {
+			t.Fatal("not rolled back")
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package ga
+
+// WithHistory keeps the elite and its fitness of each of the last n generations in a ring buffer, see History,
+// e.g. for the convergence plots, or to roll back to an elite before the fitness function changed.
+func WithHistory(n int) Option {
+	return func(m *GA) {
+		if n > 0 {
+			m.history = &history{records: make([]Record, 0, n)}
+		}
+	}
+}
+
+// Record is the elite of a generation, see WithHistory.
+type Record struct {
+	// Generation is the generation of the record.
+	Generation int
+	// Elite is the elite at the generation.
+	Elite Entity
+	// Fitness is the fitness of the elite.
+	Fitness float64
+}
+
+// History returns the records of the last generations kept by WithHistory, the oldest first,
+// or nil if WithHistory is not set.
+func (m *GA) History() []Record {
+	h := m.history
+	if h == nil {
+		return nil
+	}
+	rs := make([]Record, 0, len(h.records))
+	rs = append(append(rs, h.records[h.next:]...), h.records[:h.next]...)
+	for i := range rs {
+		rs[i].Fitness = m.objective(rs[i].Fitness)
+	}
+	return rs
+}
+
+// EliteAt returns the elite and its fitness at the generation gen, and whether it is still kept by WithHistory.
+func (m *GA) EliteAt(gen int) (Entity, float64, bool) {
+	if m.history == nil {
+		return nil, 0, false
+	}
+	for _, r := range m.history.records {
+		if r.Generation == gen {
+			return r.Elite, m.objective(r.Fitness), true
+		}
+	}
+	return nil, 0, false
+}
+
+// history is the ring buffer of WithHistory, where next is the index of the oldest record once it is full.
+type history struct {
+	records []Record
+	next    int
+}
+
+// record keeps the elite of the generation gen, replacing the record of the same generation, e.g. after Reset.
+func (h *history) record(gen int, e Entity, f float64) {
+	if n := len(h.records); n > 0 {
+		if last := &h.records[(h.next+n-1)%n]; last.Generation == gen {
+			last.Elite, last.Fitness = e, f
+			return
+		}
+	}
+	r := Record{gen, e, f}
+	if len(h.records) < cap(h.records) {
+		h.records = append(h.records, r)
+		return
+	}
+	h.records[h.next] = r
+	h.next = (h.next + 1) % len(h.records)
+}
@@ -0,0 +1,36 @@
+package ga_test
+
+import (
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestHistory(t *testing.T) {
+	m := ga.New(20, MIN{}.Mutate, ga.WithHistory(5))
+	if rs := m.History(); len(rs) != 1 || rs[0].Generation != 0 || rs[0].Elite != m.Elite() {
+		t.Fatal("initial:", rs)
+	}
+	m.EvolveTo(12)
+	rs := m.History()
+	if len(rs) != 5 {
+		t.Fatal("records:", len(rs))
+	}
+	for i, r := range rs {
+		if r.Generation != 8+i || (i > 0 && r.Fitness < rs[i-1].Fitness) {
+			t.Fatal("record:", i, r)
+		}
+	}
+	if r := rs[4]; r.Elite != m.Elite() || r.Fitness != m.Fitness() {
+		t.Fatal("last:", r)
+	}
+	if e, f, ok := m.EliteAt(9); !ok || e != rs[1].Elite || f != rs[1].Fitness {
+		t.Fatal("elite at 9:", e, f, ok)
+	}
+	if _, _, ok := m.EliteAt(7); ok {
+		t.Fatal("elite at 7 kept")
+	}
+	if ga.New(5, MIN{}.Mutate).History() != nil {
+		t.Fatal("history without WithHistory")
+	}
+}
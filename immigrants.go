@@ -0,0 +1,35 @@
+package ga
+
+import "math"
+
+// WithRandomImmigrants replaces the fraction frac of the least fit entities by fresh entities from the generator
+// after every generation, as a cheap source of diversity.
+// The number is rounded half away from zero, and at most n-1, so the fittest is always kept.
+func WithRandomImmigrants(frac float64) Option {
+	return func(m *GA) {
+		m.immigrants = frac
+	}
+}
+
+// immigrate replaces the least fit entities by fresh ones.
+func (m *GA) immigrate() {
+	k := int(math.Round(math.Max(0, m.immigrants) * float64(m.n)))
+	if k > m.n-1 {
+		k = m.n - 1
+	}
+	if k < 1 {
+		return
+	}
+	es := make([]Entity, k)
+	for i := range es {
+		es[i] = m.g()
+	}
+	idx := order(m.pop.fitnesses)[:k]
+	m.dropAt(idx)
+	for i, f := range m.pop.replace(idx, es) {
+		if m.fitter(es[i], f) {
+			m.fitness, m.elite = f, es[i]
+		}
+	}
+	m.reweigh()
+}
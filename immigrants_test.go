@@ -0,0 +1,40 @@
+package ga_test
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestRandomImmigrants(t *testing.T) {
+	var calls int64
+	g := func() ga.Entity {
+		if k := atomic.AddInt64(&calls, 1); k <= 20 {
+			return Walk(k) / 10
+		}
+		return Walk(-50)
+	}
+	m := ga.New(20, g, ga.WithRandomImmigrants(0.2))
+	for i := 0; i < 10; i++ {
+		m.Next()
+	}
+	if calls := atomic.LoadInt64(&calls); calls != 20+10*4 {
+		t.Fatal("calls:", calls)
+	}
+	n := 0
+	for _, e := range m.Population() {
+		if e == Walk(-50) {
+			n++
+		}
+	}
+	if n < 4 {
+		t.Fatal("immigrants:", n)
+	}
+	if f := m.Fitness(); f < -0.01 {
+		t.Fatal("fitness:", f)
+	}
+	if m.SnapshotStats().Worst != Walk(-50).Fitness() {
+		t.Fatal("worst:", m.SnapshotStats())
+	}
+}
@@ -0,0 +1,126 @@
+package ga
+
+import (
+	"context"
+	"math"
+	"sync/atomic"
+	"time"
+)
+
+// partial is the state of a generation bred incrementally by StepBudget.
+type partial struct {
+	i     int
+	k     int
+	made  bool
+	prev  float64
+	best  float64
+	start time.Time
+}
+
+// StepBudget advances the GA model by as many offspring as fit in d, and returns the current elite and fitness,
+// e.g. to evolve within the frame budget of a game loop.
+// The offspring are produced and evaluated one by one on the calling goroutine,
+// and the partial generation is kept across the calls, so a generation is completed over as many calls as needed,
+// and several generations may be completed in a single call.
+// The evaluation running when d elapses is aborted if the entity implements FitnessContext, and retried by the next call
+// without being counted, otherwise it is completed, so d is exceeded by at most one evaluation.
+// The deadline of WithGenerationDeadline runs from the first offspring of the generation, across the calls,
+// and the offspring not evaluated by then get the fitness -Inf, as by Next.
+// The modes which do not breed the offspring independently, e.g. WithSteadyState, WithDifferentialEvolution, WithReplacement,
+// WithBatchEvaluator, WithNoDuplicates or WithValidator, run whole generations by Next while d has not elapsed.
+// Calling Next discards the partial generation.
+func (m *GA) StepBudget(d time.Duration) (Entity, float64) {
+	deadline := time.Now().Add(d)
+	if !m.incremental() {
+		for time.Now().Before(deadline) && !m.halt {
+			m.Next()
+		}
+		return m.elite, m.objective(m.fitness)
+	}
+	base := m.pop.ctx
+	if base == nil {
+		base = context.Background()
+	}
+	ctx, cancel := context.WithDeadline(base, deadline)
+	defer cancel()
+	for ctx.Err() == nil && !m.halt {
+		if m.partial == nil {
+			m.begin()
+		}
+		t := m.partial
+		if t.i >= t.k && !t.made {
+			m.tentities[t.i] = m.offspring(t.i, m.random(0, t.i))
+			t.made = true
+		}
+		f, ok := m.evaluateSlot(ctx, t)
+		if !ok {
+			break
+		}
+		m.tfitnesses[t.i] = f
+		if t.i++; t.i < m.n {
+			t.made = false
+			continue
+		}
+		m.partial = nil
+		m.swap()
+		m.normalize()
+		if m.ops != nil {
+			m.ops.credit(&m.pop)
+		}
+		m.conclude(t.prev, t.best)
+	}
+	return m.elite, m.objective(m.fitness)
+}
+
+// evaluateSlot evaluates the offspring of the slot t.i within ctx and the generation deadline, if any,
+// and reports false if the evaluation is aborted by ctx, which is then not counted.
+func (m *GA) evaluateSlot(ctx context.Context, t *partial) (float64, bool) {
+	e := m.tentities[t.i]
+	if m.pop.deadline <= 0 {
+		f := m.penalize(e, m.pop.eval(ctx, e))
+		return f, !m.interrupted(ctx, e)
+	}
+	end := t.start.Add(m.pop.deadline)
+	if !time.Now().Before(end) {
+		return math.Inf(-1), true
+	}
+	gctx, cancel := context.WithDeadline(ctx, end)
+	defer cancel()
+	f := m.pop.eval(gctx, e)
+	if !time.Now().Before(end) {
+		return math.Inf(-1), true
+	}
+	return m.penalize(e, f), !m.interrupted(ctx, e)
+}
+
+// interrupted reports whether the evaluation of e is aborted by ctx, and uncounts it if so.
+func (m *GA) interrupted(ctx context.Context, e Entity) bool {
+	if _, ok := e.(FitnessContext); !ok || ctx.Err() == nil {
+		return false
+	}
+	atomic.AddInt64(&m.evals, -1)
+	return true
+}
+
+// incremental reports whether the offspring of the GA model can be bred one by one by StepBudget.
+func (m *GA) incremental() bool {
+	return m.steady <= 0 && !m.de && m.crowding == nil && m.archive == nil && m.streaming == nil &&
+		m.mo == nil && m.replacer == nil && m.survivor == nil && m.alps == nil &&
+		m.batch == nil && m.screen == nil && m.dedup == nil && m.validator == nil
+}
+
+// begin starts a generation bred incrementally, with the elites in the first slots, which are evaluated again like by Next.
+func (m *GA) begin() {
+	m.tune()
+	_, best := m.pop.Best()
+	t := &partial{prev: m.fitness, best: best, start: time.Now()}
+	m.fork()
+	if m.ops != nil {
+		m.ops.prepare(&m.pop)
+	}
+	if m.sus && m.pick == nil {
+		m.spin(m.n)
+	}
+	t.k = copy(m.tentities, m.elites())
+	m.partial = t
+}
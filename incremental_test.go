@@ -0,0 +1,93 @@
+package ga_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ofunc/ga"
+)
+
+type Frame float64
+
+func (s Frame) Fitness() float64 {
+	time.Sleep(100 * time.Microsecond)
+	return -sqr(float64(s))
+}
+
+func (s Frame) Mutate() ga.Entity {
+	return Frame(Benchmark(s).Mutate().(Benchmark))
+}
+
+func (s Frame) Crossover(e ga.Entity, w float64) ga.Entity {
+	return Frame(Benchmark(s).Crossover(Benchmark(e.(Frame)), w).(Benchmark))
+}
+
+func TestStepBudget(t *testing.T) {
+	m := ga.New(50, Frame(0).Mutate, ga.WithElitism(2))
+	_, f0 := m.StepBudget(0)
+	if m.Generation() != 0 {
+		t.Fatal("generation:", m.Generation())
+	}
+	// Each call exceeds the budget by at most an evaluation, so the calls take as long as their budgets,
+	// plus an evaluation each, with a generous slack for the scheduling.
+	start := time.Now()
+	Frame(0).Fitness()
+	eval := time.Since(start)
+	calls, elapsed := 0, time.Duration(0)
+	for m.Generation() < 10 && calls < 10000 {
+		start := time.Now()
+		m.StepBudget(time.Millisecond)
+		elapsed += time.Since(start)
+		calls++
+	}
+	if limit := time.Duration(calls)*(time.Millisecond+2*eval) + 100*time.Millisecond; elapsed > limit {
+		t.Fatal("budget:", elapsed, limit)
+	}
+	if m.Generation() < 10 || calls < 10 {
+		t.Fatal("calls:", calls, m.Generation())
+	}
+	if _, f := m.StepBudget(0); f < f0 {
+		t.Fatal("fitness:", f0, f)
+	}
+}
+
+var stalled int64
+
+// Stall is a Frame evaluated by FitnessContext, which counts the evaluations not aborted.
+type Stall float64
+
+func (s Stall) Fitness() float64 {
+	return Frame(s).Fitness()
+}
+
+func (s Stall) FitnessContext(ctx context.Context) float64 {
+	select {
+	case <-time.After(300 * time.Microsecond):
+	case <-ctx.Done():
+	}
+	if ctx.Err() == nil {
+		atomic.AddInt64(&stalled, 1)
+	}
+	return -sqr(float64(s))
+}
+
+func (s Stall) Mutate() ga.Entity {
+	return Stall(Frame(s).Mutate().(Frame))
+}
+
+func (s Stall) Crossover(e ga.Entity, w float64) ga.Entity {
+	return Stall(Frame(s).Crossover(Frame(e.(Stall)), w).(Frame))
+}
+
+func TestStepBudgetAborted(t *testing.T) {
+	m := ga.New(20, Stall(0).Mutate)
+	atomic.StoreInt64(&stalled, 0)
+	for i := 0; i < 100; i++ {
+		m.StepBudget(5 * time.Millisecond)
+	}
+	if n := atomic.LoadInt64(&stalled); m.Evaluations() != 20+n {
+		t.Fatal("evaluations:", m.Evaluations(), n)
+	}
+}
@@ -0,0 +1,132 @@
+package ga
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// Objectives returns the objectives of the entities es, which must implement MultiObjective, e.g. of ParetoFront.
+// They are not counted as evaluations.
+func Objectives(es []Entity) [][]float64 {
+	os := make([][]float64, len(es))
+	for i, e := range es {
+		os[i] = e.(MultiObjective).Objectives()
+	}
+	return os
+}
+
+// Hypervolume returns the volume of the objective space dominated by the points os and dominating the reference point ref,
+// where the objectives are maximized, so ref should be below the worst point of each objective.
+// The points not dominating ref do not contribute. It is exact, by slicing the objectives one by one,
+// and suits the fronts of a few objectives.
+func Hypervolume(os [][]float64, ref []float64) float64 {
+	ps := make([][]float64, 0, len(os))
+	for _, o := range os {
+		if dominates(o, ref) {
+			ps = append(ps, o)
+		}
+	}
+	return hypervolume(ps, ref, len(ref))
+}
+
+// hypervolume returns the hypervolume of ps in the first d objectives.
+func hypervolume(ps [][]float64, ref []float64, d int) float64 {
+	if len(ps) == 0 {
+		return 0
+	}
+	k := d - 1
+	if d == 1 {
+		best := ref[0]
+		for _, p := range ps {
+			best = math.Max(best, p[0])
+		}
+		return best - ref[0]
+	}
+	ps = append([][]float64(nil), ps...)
+	sort.Slice(ps, func(i, j int) bool {
+		return ps[i][k] > ps[j][k]
+	})
+	v := 0.0
+	for i := range ps {
+		lower := ref[k]
+		if i+1 < len(ps) {
+			lower = ps[i+1][k]
+		}
+		if h := ps[i][k] - lower; h > 0 {
+			v += h * hypervolume(ps[:i+1], ref, k)
+		}
+	}
+	return v
+}
+
+// IGD returns the inverted generational distance of the points os to the reference front,
+// which is the mean Euclidean distance from each reference point to the nearest point of os.
+// The less, the closer and the more complete os is. It is +Inf if os is empty.
+func IGD(os [][]float64, front [][]float64) float64 {
+	if len(front) == 0 {
+		return 0
+	}
+	s := 0.0
+	for _, r := range front {
+		d := math.Inf(1)
+		for _, o := range os {
+			e := 0.0
+			for k := range r {
+				e += (o[k] - r[k]) * (o[k] - r[k])
+			}
+			d = math.Min(d, e)
+		}
+		s += math.Sqrt(d)
+	}
+	return s / float64(len(front))
+}
+
+// WriteFront writes the entities es, e.g. of ParetoFront, with their objectives to w in the format.
+// CSV has the columns entity, f0, f1, ..., and JSONLines has the fields entity and objectives,
+// where the entity is described by Describe.
+func WriteFront(w io.Writer, es []Entity, format TraceFormat) error {
+	os := Objectives(es)
+	if format == CSV {
+		c := csv.NewWriter(w)
+		if len(os) > 0 {
+			row := []string{"entity"}
+			for k := range os[0] {
+				row = append(row, "f"+strconv.Itoa(k))
+			}
+			c.Write(row)
+		}
+		for i, o := range os {
+			row := []string{Describe(es[i])}
+			for _, x := range o {
+				row = append(row, strconv.FormatFloat(x, 'g', -1, 64))
+			}
+			c.Write(row)
+		}
+		c.Flush()
+		return c.Error()
+	}
+	b := bufio.NewWriter(w)
+	for i, o := range os {
+		s, _ := json.Marshal(Describe(es[i]))
+		line := append(append([]byte(`{"entity":`), s...), `,"objectives":[`...)
+		for k, x := range o {
+			if k > 0 {
+				line = append(line, ',')
+			}
+			if math.IsNaN(x) || math.IsInf(x, 0) {
+				line = append(line, "null"...)
+			} else {
+				line = strconv.AppendFloat(line, x, 'g', -1, 64)
+			}
+		}
+		if _, err := b.Write(append(line, "]}\n"...)); err != nil {
+			return err
+		}
+	}
+	return b.Flush()
+}
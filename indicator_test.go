@@ -0,0 +1,71 @@
+package ga_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestHypervolume(t *testing.T) {
+	front := [][]float64{{1, 3}, {2, 2}, {3, 1}, {1, 1}, {-1, 5}}
+	if v := ga.Hypervolume(front, []float64{0, 0}); v != 6 {
+		t.Fatal("2d:", v)
+	}
+	cube := [][]float64{{1, 1, 2}, {2, 2, 1}}
+	if v := ga.Hypervolume(cube, []float64{0, 0, 0}); v != 2+4-1 {
+		t.Fatal("3d:", v)
+	}
+	if v := ga.IGD(front[:3], [][]float64{{1, 3}, {2, 2}, {3, 1}}); v != 0 {
+		t.Fatal("igd:", v)
+	}
+	if v := ga.IGD(front[1:2], [][]float64{{1, 3}, {3, 1}}); math.Abs(v-math.Sqrt2) > 1e-12 {
+		t.Fatal("igd:", v)
+	}
+}
+
+func TestWriteFront(t *testing.T) {
+	m := ga.New(50, func() ga.Entity {
+		return Schaffer(20*rand.Float64() - 10)
+	}, ga.WithPareto())
+	m.EvolveTo(30)
+	front := m.ParetoFront()
+	// The true front of Schaffer is x in [0, 2].
+	var ref [][]float64
+	for x := 0.0; x <= 2; x += 0.1 {
+		ref = append(ref, Schaffer(x).Objectives())
+	}
+	os := ga.Objectives(front)
+	if v := ga.IGD(os, ref); v > 0.1 {
+		t.Fatal("igd:", v)
+	}
+	if v := ga.Hypervolume(os, []float64{-4, -4}); v < 0.9*ga.Hypervolume(ref, []float64{-4, -4}) {
+		t.Fatal("hypervolume:", v)
+	}
+
+	var b bytes.Buffer
+	if err := ga.WriteFront(&b, front, ga.CSV); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&b).ReadAll()
+	if err != nil || len(rows) != len(front)+1 || strings.Join(rows[0], ",") != "entity,f0,f1" {
+		t.Fatal("csv:", rows, err)
+	}
+	b.Reset()
+	if err := ga.WriteFront(&b, front, ga.JSONLines); err != nil {
+		t.Fatal(err)
+	}
+	var r struct {
+		Entity     string
+		Objectives []float64
+	}
+	line := strings.SplitN(b.String(), "\n", 2)[0]
+	if err := json.Unmarshal([]byte(line), &r); err != nil || len(r.Objectives) != 2 || r.Entity != ga.Describe(front[0]) {
+		t.Fatal("json:", line, err)
+	}
+}
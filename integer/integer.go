@@ -0,0 +1,313 @@
+// Package integer implements the bounded integer vector genome of GA model,
+// whose genes are recombined in the Gray-coded binary representation,
+// so adjacent integers differ in a single bit and there are no Hamming cliffs,
+// with the standard crossover and mutation operators.
+package integer
+
+import (
+	"math/bits"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+
+	"github.com/ofunc/ga"
+)
+
+// Crossover is a crossover operator of the Gray codes of vectors, which produces a child of x and y into z with the random numbers of r,
+// where n are the numbers of bits of the genes, and w is the weight of x, see ga.Entity.
+type Crossover func(r *rand.Rand, z, x, y []uint64, n []uint, w float64)
+
+// Mutation is a mutation operator of a gene, which returns the mutated x in [lower, upper] with the random numbers of r.
+type Mutation func(r *rand.Rand, x, lower, upper int) int
+
+// Space is a bounded space of integer vectors.
+type Space struct {
+	lower     []int
+	upper     []int
+	n         []uint
+	fitness   func([]int) float64
+	crossover Crossover
+	mutation  Mutation
+	rate      float64
+}
+
+// Option is an option of Space.
+type Option func(*Space)
+
+// WithCrossover sets the crossover operator, default to Uniform.
+func WithCrossover(c Crossover) Option {
+	return func(s *Space) {
+		s.crossover = c
+	}
+}
+
+// WithMutation sets the mutation operator, default to Flip.
+func WithMutation(m Mutation) Option {
+	return func(s *Space) {
+		s.mutation = m
+	}
+}
+
+// WithGeneRate sets the probability that each gene is mutated, when the vector is mutated, default to 1/d.
+// At least one gene is mutated anyway.
+func WithGeneRate(p float64) Option {
+	return func(s *Space) {
+		s.rate = p
+	}
+}
+
+// NewSpace creates the space of integer vectors in [lower, upper] with the fitness function f.
+func NewSpace(lower, upper []int, f func([]int) float64, opts ...Option) *Space {
+	if len(lower) != len(upper) {
+		panic("integer: the bounds have different dimensions")
+	}
+	s := &Space{
+		lower:     lower,
+		upper:     upper,
+		n:         make([]uint, len(lower)),
+		fitness:   f,
+		crossover: Uniform,
+		mutation:  Flip,
+		rate:      1 / float64(len(lower)),
+	}
+	for i := range lower {
+		if lower[i] > upper[i] {
+			panic("integer: the lower bound is greater than the upper bound")
+		}
+		s.n[i] = uint(bits.Len64(uint64(upper[i] - lower[i])))
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Dim returns the dimension of the space.
+func (s *Space) Dim() int {
+	return len(s.lower)
+}
+
+// Bounds returns the lower and upper bounds of the space, which must not be modified.
+func (s *Space) Bounds() (lower, upper []int) {
+	return s.lower, s.upper
+}
+
+// Bits returns the number of bits of the Gray code of the gene i.
+func (s *Space) Bits(i int) int {
+	return int(s.n[i])
+}
+
+// New returns the vector x of the space, clamped into the bounds.
+func (s *Space) New(x []int) *Vector {
+	v := &Vector{make([]int, len(x)), s}
+	for i := range x {
+		v.x[i] = clamp(x[i], s.lower[i], s.upper[i])
+	}
+	return v
+}
+
+// Random returns a vector uniformly distributed in the space, which can be the generator of GA model.
+func (s *Space) Random() ga.Entity {
+	return s.RandomCtx(ga.GenCtx{})
+}
+
+// RandomCtx is Random with the random numbers of ctx, which can be the generator of ga.NewCtx,
+// so that the vectors are reproducible by ga.WithSeed.
+func (s *Space) RandomCtx(ctx ga.GenCtx) ga.Entity {
+	r, v := ctx.Random(), &Vector{make([]int, s.Dim()), s}
+	for i := range v.x {
+		v.x[i] = Jump(r, 0, s.lower[i], s.upper[i])
+	}
+	return v
+}
+
+// Decode returns the vector of the Gray codes g of the offsets from the lower bounds,
+// where the offsets beyond the upper bounds are clamped.
+func (s *Space) Decode(g []uint64) *Vector {
+	v := &Vector{make([]int, len(g)), s}
+	for i := range g {
+		d := Binary(g[i] & (1<<s.n[i] - 1))
+		if d > uint64(s.upper[i]-s.lower[i]) {
+			v.x[i] = s.upper[i]
+		} else {
+			v.x[i] = s.lower[i] + int(d)
+		}
+	}
+	return v
+}
+
+// Vector is an integer vector of a space, which implements ga.Entity,
+// and ga.ContextMutator and ga.ContextCrossover to draw the random numbers of the model.
+type Vector struct {
+	x []int
+	s *Space
+}
+
+// X returns the genes of the vector, which must not be modified.
+func (v *Vector) X() []int {
+	return v.x
+}
+
+// Gray returns the Gray codes of the offsets of the genes from the lower bounds.
+func (v *Vector) Gray() []uint64 {
+	g := make([]uint64, len(v.x))
+	for i, x := range v.x {
+		g[i] = Gray(uint64(x - v.s.lower[i]))
+	}
+	return g
+}
+
+// Fitness returns the fitness of the vector.
+func (v *Vector) Fitness() float64 {
+	return v.s.fitness(v.x)
+}
+
+// Mutate mutates the genes of the vector, each with the gene rate.
+func (v *Vector) Mutate() ga.Entity {
+	return v.MutateCtx(ga.GenCtx{})
+}
+
+// MutateCtx is Mutate with the random numbers of ctx.
+func (v *Vector) MutateCtx(ctx ga.GenCtx) ga.Entity {
+	s, r := v.s, ctx.Random()
+	z := &Vector{append([]int(nil), v.x...), s}
+	mutated := false
+	for i := range z.x {
+		if r.Float64() < s.rate {
+			z.x[i], mutated = s.mutation(r, z.x[i], s.lower[i], s.upper[i]), true
+		}
+	}
+	if !mutated && len(z.x) > 0 {
+		i := r.IntN(len(z.x))
+		z.x[i] = s.mutation(r, z.x[i], s.lower[i], s.upper[i])
+	}
+	return z
+}
+
+// Crossover produces a child of v and e by the crossover operator of the space on the Gray codes.
+func (v *Vector) Crossover(e ga.Entity, w float64) ga.Entity {
+	return v.CrossoverCtx(ga.GenCtx{}, e, w)
+}
+
+// CrossoverCtx is Crossover with the random numbers of ctx.
+func (v *Vector) CrossoverCtx(ctx ga.GenCtx, e ga.Entity, w float64) ga.Entity {
+	s := v.s
+	z := make([]uint64, len(v.x))
+	s.crossover(ctx.Random(), z, v.Gray(), e.(*Vector).Gray(), s.n, w)
+	return s.Decode(z)
+}
+
+// Key returns the key of the vector, which implements ga.Keyer.
+func (v *Vector) Key() string {
+	return v.String()
+}
+
+// String returns the genes of the vector separated by spaces.
+func (v *Vector) String() string {
+	ss := make([]string, len(v.x))
+	for i, x := range v.x {
+		ss[i] = strconv.Itoa(x)
+	}
+	return strings.Join(ss, " ")
+}
+
+// Gray returns the Gray code of x.
+func Gray(x uint64) uint64 {
+	return x ^ x>>1
+}
+
+// Binary returns the integer of the Gray code g, which is the inverse of Gray.
+func Binary(g uint64) uint64 {
+	for s := uint(1); s < 64; s <<= 1 {
+		g ^= g >> s
+	}
+	return g
+}
+
+// Uniform takes each bit of the Gray codes from either parent with the equal probability.
+// The weight is ignored.
+func Uniform(r *rand.Rand, z, x, y []uint64, n []uint, w float64) {
+	for i := range z {
+		m := r.Uint64()
+		z[i] = x[i]&m | y[i]&^m
+	}
+}
+
+// Discrete takes each gene from x with the probability w, or from y otherwise.
+func Discrete(r *rand.Rand, z, x, y []uint64, n []uint, w float64) {
+	for i := range z {
+		if r.Float64() < w {
+			z[i] = x[i]
+		} else {
+			z[i] = y[i]
+		}
+	}
+}
+
+// KPoint returns the k-point crossover, which alternates the parents between k random cut points
+// of the concatenated Gray codes.
+func KPoint(k int) Crossover {
+	return func(r *rand.Rand, z, x, y []uint64, n []uint, w float64) {
+		t := 0
+		for _, b := range n {
+			t += int(b)
+		}
+		cuts := make([]bool, t+1)
+		for j := 0; j < k; j++ {
+			cuts[r.IntN(t+1)] = true
+		}
+		p, q, j := x, y, 0
+		for i := range z {
+			z[i] = 0
+			for b := uint(0); b < n[i]; b, j = b+1, j+1 {
+				if cuts[j] {
+					p, q = q, p
+				}
+				z[i] |= p[i] & (1 << b)
+			}
+		}
+	}
+}
+
+// Flip flips a random bit of the Gray code of the gene, so the mutation is mostly small but sometimes large,
+// and the result beyond the upper bound is clamped.
+func Flip(r *rand.Rand, x, lower, upper int) int {
+	n := bits.Len64(uint64(upper - lower))
+	if n == 0 {
+		return x
+	}
+	d := Binary(Gray(uint64(x-lower)) ^ 1<<uint(r.IntN(n)))
+	if d > uint64(upper-lower) {
+		return upper
+	}
+	return lower + int(d)
+}
+
+// Creep returns the creep mutation, which adds a nonzero uniform step in [-step, step], clamped into the bounds.
+func Creep(step int) Mutation {
+	return func(r *rand.Rand, x, lower, upper int) int {
+		if step <= 0 {
+			return x
+		}
+		d := 1 + r.IntN(step)
+		if r.IntN(2) == 0 {
+			d = -d
+		}
+		return clamp(x+d, lower, upper)
+	}
+}
+
+// Jump replaces the gene by a uniformly random integer in the bounds.
+func Jump(r *rand.Rand, x, lower, upper int) int {
+	return lower + int(r.Int64N(int64(upper-lower)+1))
+}
+
+func clamp(x, lower, upper int) int {
+	if x < lower {
+		return lower
+	}
+	if x > upper {
+		return upper
+	}
+	return x
+}
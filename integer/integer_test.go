@@ -0,0 +1,79 @@
+package integer_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/ofunc/ga"
+	"github.com/ofunc/ga/integer"
+)
+
+func target(x []int) float64 {
+	f := 0.0
+	for i, v := range x {
+		d := float64(v - 7*i)
+		f -= d * d
+	}
+	return f
+}
+
+func TestGray(t *testing.T) {
+	for x := uint64(0); x < 1024; x++ {
+		g := integer.Gray(x)
+		if integer.Binary(g) != x {
+			t.Fatal("binary:", x, g)
+		}
+		if d := g ^ integer.Gray(x+1); d&(d-1) != 0 {
+			t.Fatal("adjacent:", x, g, d)
+		}
+	}
+	if x := uint64(1<<63 | 12345); integer.Binary(integer.Gray(x)) != x {
+		t.Fatal("64 bits")
+	}
+}
+
+func TestOperators(t *testing.T) {
+	lower, upper := []int{-50, -50, -50, -50}, []int{50, 50, 50, 50}
+	for name, opts := range map[string][]integer.Option{
+		"default":  nil,
+		"discrete": {integer.WithCrossover(integer.Discrete), integer.WithMutation(integer.Creep(3))},
+		"2-point":  {integer.WithCrossover(integer.KPoint(2)), integer.WithMutation(integer.Flip)},
+	} {
+		s := integer.NewSpace(lower, upper, target, opts...)
+		m := ga.New(100, s.Random, ga.WithElitism(2))
+		if e, f, _ := m.Evolve(100, 1000); f < -2 {
+			t.Error(name, "fitness:", f, e)
+		}
+	}
+}
+
+func TestBounds(t *testing.T) {
+	lower, upper := []int{0, -3, 5}, []int{4, 2, 5}
+	s := integer.NewSpace(lower, upper, target, integer.WithGeneRate(1))
+	if s.Bits(0) != 3 || s.Bits(1) != 3 || s.Bits(2) != 0 {
+		t.Fatal("bits:", s.Bits(0), s.Bits(1), s.Bits(2))
+	}
+	if v := s.New([]int{9, -9, 0}).X(); v[0] != 4 || v[1] != -3 || v[2] != 5 {
+		t.Fatal("clamp:", v)
+	}
+	if v := s.Decode([]uint64{integer.Gray(7), integer.Gray(2), 0}).X(); v[0] != 4 || v[1] != -1 || v[2] != 5 {
+		t.Fatal("decode:", v)
+	}
+	x, y, r := s.Random(), s.Random(), rand.New(rand.NewPCG(1, 2))
+	for _, m := range []integer.Mutation{integer.Flip, integer.Creep(10), integer.Jump} {
+		for i := 0; i < 1000; i++ {
+			if v := m(r, 4, 0, 4); v < 0 || v > 4 {
+				t.Fatal("mutation out of bounds:", v)
+			}
+		}
+	}
+	for i := 0; i < 1000; i++ {
+		for _, e := range []ga.Entity{x.Mutate(), x.Crossover(y, 0.5)} {
+			for j, v := range e.(*integer.Vector).X() {
+				if v < lower[j] || v > upper[j] {
+					t.Fatal("out of bounds:", j, v)
+				}
+			}
+		}
+	}
+}
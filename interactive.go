@@ -0,0 +1,74 @@
+package ga
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Candidates is a batch of entities awaiting external ratings.
+type Candidates struct {
+	// ID identifies the batch in its ratings.
+	ID int
+	// Generation is the generation of the batch.
+	Generation int
+	// Entities are the entities to be rated, which are a copy owned by the receiver,
+	// so it can keep them after the batch is timed out.
+	Entities []Entity
+}
+
+// Rating is an external fitness of an entity of a batch of candidates.
+type Rating struct {
+	// Batch is the ID of the batch.
+	Batch int
+	// Index is the index of the entity in the batch.
+	Index int
+	// Fitness is the fitness of the entity.
+	Fitness float64
+}
+
+// WithInteractive evaluates the populations by external ratings, e.g. by humans, instead of calling Fitness.
+// Each batch of entities, the whole generation or the new entities of an incremental update,
+// is handed to ask, and then Next waits for their ratings on ratings,
+// until every entity is rated, or timeout has elapsed if timeout > 0, or the budget of EvolveFor is exhausted.
+// The entities not rated by then get the fitness fallback. Ratings of other batches, or of rated entities, are ignored.
+// As with WithBatchEvaluator, the oracle, the fitness cache and the generation deadline are not applied to them.
+func WithInteractive(ask func(Candidates), ratings <-chan Rating, timeout time.Duration, fallback float64) Option {
+	return func(m *GA) {
+		id := 0
+		m.evaluator("WithInteractive", func(es []Entity, fs []float64) {
+			id++
+			atomic.AddInt64(&m.evals, int64(len(es)))
+			ask(Candidates{ID: id, Generation: m.gen, Entities: append([]Entity(nil), es...)})
+			rated := make([]bool, len(es))
+			var expired <-chan time.Time
+			if timeout > 0 {
+				t := time.NewTimer(timeout)
+				defer t.Stop()
+				expired = t.C
+			}
+			var done <-chan struct{}
+			if m.pop.ctx != nil {
+				done = m.pop.ctx.Done()
+			}
+		wait:
+			for n := 0; n < len(es); {
+				select {
+				case r := <-ratings:
+					if r.Batch == id && r.Index >= 0 && r.Index < len(es) && !rated[r.Index] {
+						fs[r.Index], rated[r.Index] = m.objective(r.Fitness), true
+						n++
+					}
+				case <-expired:
+					break wait
+				case <-done:
+					break wait
+				}
+			}
+			for i, ok := range rated {
+				if !ok {
+					fs[i] = m.objective(fallback)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package ga_test
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/ofunc/ga"
+)
+
+func TestInteractive(t *testing.T) {
+	ratings := make(chan ga.Rating)
+	ask := func(c ga.Candidates) {
+		go func() {
+			ratings <- ga.Rating{Batch: c.ID - 1, Index: 0, Fitness: 100}
+			for i, e := range c.Entities {
+				if i%4 != 3 {
+					ratings <- ga.Rating{Batch: c.ID, Index: i, Fitness: e.Fitness()}
+				}
+			}
+		}()
+	}
+	m := ga.New(20, func() ga.Entity {
+		return Walk(rand.Float64()*10 - 5)
+	}, ga.WithInteractive(ask, ratings, 10*time.Millisecond, -1000))
+	for i := 0; i < 50; i++ {
+		m.Next()
+	}
+	if f := m.Fitness(); f < -0.1 || f > 0 || f != m.Elite().Fitness() {
+		t.Fatal("fitness:", f, m.Elite())
+	}
+}
@@ -0,0 +1,69 @@
+package ga
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// InvariantError is a violation of the invariants checked by WithInvariantCheck,
+// reported by Err as the Err of an OperatorError, whose Op is the operation producing the entity.
+type InvariantError struct {
+	// Entity is the invalid entity.
+	Entity Entity
+	// Err is the error returned by the check.
+	Err error
+}
+
+func (e *InvariantError) Error() string {
+	return fmt.Sprintf("invalid entity %s: %v", Describe(e.Entity), e.Err)
+}
+
+// Unwrap returns the error returned by the check.
+func (e *InvariantError) Unwrap() error {
+	return e.Err
+}
+
+// WithInvariantCheck checks the entities produced by the generator, the crossover and the mutation by check,
+// and reports the violations by Err with the operations producing them, e.g. to track down an operator emitting invalid genomes.
+// Only one of every n entities is checked to limit the overhead, or all of them if n <= 1,
+// and the sampling does not draw from the random source, so the evolution is the same as without it.
+// It is meant for the development, and the invalid entities stay in the population, see WithValidator to veto them.
+// check must be safe for concurrent use.
+func WithInvariantCheck(check func(Entity) error, n int) Option {
+	if n < 1 {
+		n = 1
+	}
+	return func(m *GA) {
+		m.invariants = &invariants{check: check, every: int64(n)}
+	}
+}
+
+type invariants struct {
+	check func(Entity) error
+	every int64
+	count int64
+}
+
+// inspect checks e produced by the operation op, if it is sampled.
+func (m *GA) inspect(op string, e Entity) {
+	v := m.invariants
+	if (atomic.AddInt64(&v.count, 1)-1)%v.every != 0 {
+		return
+	}
+	if err := v.check(e); err != nil {
+		m.fail(op, &InvariantError{e, err})
+	}
+}
+
+// checkGenerator checks the initial population, and the entities generated later.
+func (m *GA) checkGenerator() {
+	for _, e := range m.pop.entities {
+		m.inspect("generate", e)
+	}
+	g := m.g
+	m.g = func() Entity {
+		e := g()
+		m.inspect("generate", e)
+		return e
+	}
+}
@@ -0,0 +1,75 @@
+package ga_test
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+type Unit float64
+
+func (u Unit) Fitness() float64 {
+	return float64(u)
+}
+
+func (u Unit) Mutate() ga.Entity {
+	// The bug: the mutation does not clamp into [0, 1].
+	return u + Unit(0.2*rand.Float64()-0.1)
+}
+
+func (u Unit) Crossover(e ga.Entity, w float64) ga.Entity {
+	return Unit(w*float64(u) + (1-w)*float64(e.(Unit)))
+}
+
+var errRange = errors.New("out of [0, 1]")
+
+func checkUnit(e ga.Entity) error {
+	if u := e.(Unit); u < 0 || u > 1 {
+		return errRange
+	}
+	return nil
+}
+
+func TestInvariantCheck(t *testing.T) {
+	k := 0
+	g := func() ga.Entity {
+		if k++; k == 1 {
+			return Unit(2)
+		}
+		return Unit(rand.Float64())
+	}
+	m := ga.New(20, g, ga.WithInvariantCheck(checkUnit, 1), ga.WithFixedMutationRate(1), ga.WithSequentialEval())
+	errs, ok := m.Err().(ga.Errors)
+	if !ok || len(errs) != 1 || errs[0].Op != "generate" || !errors.Is(errs[0], errRange) {
+		t.Fatal("generate:", m.Err())
+	}
+	m.EvolveTo(10)
+	errs, _ = m.Err().(ga.Errors)
+	if len(errs) == 0 {
+		t.Fatal("no violations")
+	}
+	ops := make(map[string]int)
+	for _, err := range errs {
+		var ie *ga.InvariantError
+		if !errors.As(err, &ie) || checkUnit(ie.Entity) == nil {
+			t.Fatal("violation:", err)
+		}
+		ops[err.Op]++
+	}
+	// The crossover of an invalid parent may be invalid too, but the mutation is the source.
+	if ops["mutate"] == 0 || ops["generate"] != 0 {
+		t.Fatal("operators:", ops)
+	}
+
+	k = 0
+	m = ga.New(20, g, ga.WithInvariantCheck(checkUnit, 1000))
+	if m.Err() == nil {
+		t.Fatal("first entity not checked")
+	}
+	m.EvolveTo(10)
+	if err := m.Err(); err != nil {
+		t.Fatal("sampling:", err)
+	}
+}
@@ -0,0 +1,138 @@
+// Package landscape implements the diagnostics of the fitness landscapes of GA models,
+// built on the operators of the entities, to tell whether a problem is suitable for GA before a long run:
+// the random walk autocorrelation for the ruggedness, the fitness distance correlation for the deceptiveness,
+// and the sampling of local optima for the modality,
+// and after a run, the sensitivity of the fitness to the genes of an entity, to explain the evolved solution.
+package landscape
+
+import (
+	"math"
+	"sort"
+
+	"github.com/ofunc/ga"
+)
+
+// Autocorrelation walks randomly from start by steps mutations, and returns the autocorrelations
+// of the fitnesses along the walk at the lags 1, 2, ..., lags.
+// The autocorrelations near 1 indicate a smooth landscape, and near 0 a rugged one for the mutation.
+func Autocorrelation(start ga.Entity, steps, lags int) []float64 {
+	fs := make([]float64, 0, steps+1)
+	e := start
+	fs = append(fs, e.Fitness())
+	for i := 0; i < steps; i++ {
+		e = e.Mutate()
+		fs = append(fs, e.Fitness())
+	}
+	mean, variance := moments(fs)
+	rs := make([]float64, lags)
+	for k := range rs {
+		lag := k + 1
+		if lag >= len(fs) || variance == 0 {
+			rs[k] = math.NaN()
+			continue
+		}
+		s := 0.0
+		for i := 0; i+lag < len(fs); i++ {
+			s += (fs[i] - mean) * (fs[i+lag] - mean)
+		}
+		rs[k] = s / float64(len(fs)-lag) / variance
+	}
+	return rs
+}
+
+// CorrelationLength returns the correlation length -1/ln|r| of the autocorrelation r at the lag 1,
+// which is the typical number of mutations over which the fitnesses are correlated.
+func CorrelationLength(r float64) float64 {
+	return -1 / math.Log(math.Abs(r))
+}
+
+// FitnessDistanceCorrelation samples n entities by the generator g, and returns the correlation
+// between their fitnesses and their distances to optimum by dist.
+// If optimum is nil, the fittest sample is used.
+// Since the fitnesses are maximized, a correlation near -1 indicates an easy landscape, where fitter entities are closer,
+// near 0 a difficult one, and a positive one a deceptive one.
+func FitnessDistanceCorrelation(g func() ga.Entity, n int, optimum ga.Entity, dist func(x, y ga.Entity) float64) float64 {
+	es, fs := make([]ga.Entity, n), make([]float64, n)
+	for i := range es {
+		es[i] = g()
+		fs[i] = es[i].Fitness()
+	}
+	if optimum == nil {
+		k := 0
+		for i, f := range fs {
+			if f > fs[k] {
+				k = i
+			}
+		}
+		optimum = es[k]
+	}
+	ds := make([]float64, n)
+	for i, e := range es {
+		ds[i] = dist(e, optimum)
+	}
+	return correlation(fs, ds)
+}
+
+// Optimum is a local optimum found by LocalOptima.
+type Optimum struct {
+	Entity  ga.Entity
+	Fitness float64
+	// Count is the number of searches which ended at the optimum, which estimates the relative size of its basin.
+	Count int
+}
+
+// LocalOptima samples n entities by the generator g, climbs from each by the mutation,
+// accepting the fitter mutants, until patience mutants in a row are not fitter,
+// and returns the distinct local optima found, in the descending order of fitness.
+// The optima are identified by ga.Keyer if implemented, or ga.Describe.
+func LocalOptima(g func() ga.Entity, n, patience int) []Optimum {
+	index := make(map[string]int)
+	var os []Optimum
+	for i := 0; i < n; i++ {
+		e := g()
+		f := e.Fitness()
+		for k := 0; k < patience; k++ {
+			if z := e.Mutate(); z.Fitness() > f {
+				e, f, k = z, z.Fitness(), -1
+			}
+		}
+		key := ga.Describe(e)
+		if x, ok := e.(ga.Keyer); ok {
+			key = x.Key()
+		}
+		if j, ok := index[key]; ok {
+			os[j].Count++
+		} else {
+			index[key] = len(os)
+			os = append(os, Optimum{e, f, 1})
+		}
+	}
+	sort.SliceStable(os, func(i, j int) bool {
+		return os[i].Fitness > os[j].Fitness
+	})
+	return os
+}
+
+func moments(xs []float64) (mean, variance float64) {
+	for _, x := range xs {
+		mean += x
+	}
+	mean /= float64(len(xs))
+	for _, x := range xs {
+		variance += (x - mean) * (x - mean)
+	}
+	return mean, variance / float64(len(xs))
+}
+
+func correlation(xs, ys []float64) float64 {
+	mx, vx := moments(xs)
+	my, vy := moments(ys)
+	if vx == 0 || vy == 0 {
+		return math.NaN()
+	}
+	s := 0.0
+	for i := range xs {
+		s += (xs[i] - mx) * (ys[i] - my)
+	}
+	return s / float64(len(xs)) / math.Sqrt(vx*vy)
+}
@@ -0,0 +1,87 @@
+package landscape_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/ofunc/ga"
+	"github.com/ofunc/ga/integer"
+	"github.com/ofunc/ga/landscape"
+)
+
+func line(f func(x int) float64, m integer.Mutation) *integer.Space {
+	return integer.NewSpace([]int{0}, []int{100}, func(x []int) float64 {
+		return f(x[0])
+	}, integer.WithMutation(m))
+}
+
+func distance(x, y ga.Entity) float64 {
+	return math.Abs(float64(x.(*integer.Vector).X()[0] - y.(*integer.Vector).X()[0]))
+}
+
+func TestAutocorrelation(t *testing.T) {
+	smooth := line(func(x int) float64 {
+		return -float64((x - 50) * (x - 50))
+	}, integer.Creep(1))
+	rs := landscape.Autocorrelation(smooth.Random(), 2000, 3)
+	if len(rs) != 3 || rs[0] < 0.9 || rs[0] < rs[2] || landscape.CorrelationLength(rs[0]) < 10 {
+		t.Fatal("smooth:", rs)
+	}
+
+	rugged := line(func(x int) float64 {
+		return math.Sin(float64(x) * 12345.678)
+	}, integer.Jump)
+	if rs := landscape.Autocorrelation(rugged.Random(), 2000, 1); math.Abs(rs[0]) > 0.1 {
+		t.Fatal("rugged:", rs)
+	}
+}
+
+func TestFitnessDistanceCorrelation(t *testing.T) {
+	s := line(func(x int) float64 {
+		return -float64((x - 50) * (x - 50))
+	}, integer.Creep(1))
+	if r := landscape.FitnessDistanceCorrelation(s.Random, 500, s.New([]int{50}), distance); r > -0.9 {
+		t.Fatal("easy:", r)
+	}
+	deceptive := line(func(x int) float64 {
+		if x == 0 {
+			return 200
+		}
+		return float64(x)
+	}, integer.Creep(1))
+	if r := landscape.FitnessDistanceCorrelation(deceptive.Random, 500, deceptive.New([]int{0}), distance); r < 0.5 {
+		t.Fatal("deceptive:", r)
+	}
+}
+
+func TestLocalOptima(t *testing.T) {
+	s := line(func(x int) float64 {
+		return math.Max(-math.Abs(float64(x-20)), -math.Abs(float64(x-80))-5)
+	}, integer.Creep(1))
+	os := landscape.LocalOptima(s.Random, 100, 50)
+	if len(os) != 2 || os[0].Fitness != 0 || os[1].Fitness != -5 || os[0].Count+os[1].Count != 100 {
+		t.Fatal("optima:", os)
+	}
+	if x := os[0].Entity.(*integer.Vector).X()[0]; x != 20 {
+		t.Fatal("global:", x)
+	}
+}
+
+func TestSensitivity(t *testing.T) {
+	s := integer.NewSpace([]int{0, 0, 0}, []int{100, 100, 100}, func(x []int) float64 {
+		return -10*math.Abs(float64(x[0]-50)) - math.Abs(float64(x[1]-50))
+	})
+	e := s.New([]int{50, 50, 7})
+	ls := landscape.Sensitivity(e, 3, 50, func(e ga.Entity, locus int) ga.Entity {
+		x := append([]int(nil), e.(*integer.Vector).X()...)
+		x[locus] = rand.Intn(101)
+		return s.New(x)
+	})
+	if len(ls) != 3 || ls[0].Index != 0 || ls[1].Index != 1 || ls[2].Index != 2 {
+		t.Fatal("order:", ls)
+	}
+	if ls[0].Max > 0 || ls[0].Impact <= ls[1].Impact || ls[2].Impact != 0 || ls[2].Std != 0 {
+		t.Fatal("sensitivity:", ls)
+	}
+}
@@ -0,0 +1,54 @@
+package landscape
+
+import (
+	"math"
+	"sort"
+
+	"github.com/ofunc/ga"
+)
+
+// Locus is the fitness sensitivity of a gene position of an entity, found by Sensitivity.
+// The changes are of the fitness of the mutants from the fitness of the entity, so the negative changes are losses.
+type Locus struct {
+	// Index is the gene position.
+	Index int
+	// Mean, Std, Min and Max are the statistics of the changes.
+	Mean float64
+	Std  float64
+	Min  float64
+	Max  float64
+	// Impact is the mean absolute change, which measures how much the fitness depends on the gene.
+	Impact float64
+}
+
+// Sensitivity mutates the entity e at each of the gene positions 0, 1, ..., loci-1 by samples mutations of mutate,
+// which mutates only the given position of an entity, and returns the sensitivities of the positions,
+// in the descending order of impact, to explain which genes make the entity, e.g. the elite, fit.
+// The genes of high impact and negative Max are essential, where every change is a loss,
+// and the genes of near zero impact are neutral, which could be anything.
+func Sensitivity(e ga.Entity, loci, samples int, mutate func(e ga.Entity, locus int) ga.Entity) []Locus {
+	f := e.Fitness()
+	ls := make([]Locus, loci)
+	ds := make([]float64, samples)
+	for i := range ls {
+		l := Locus{Index: i, Min: math.Inf(1), Max: math.Inf(-1)}
+		for k := range ds {
+			d := mutate(e, i).Fitness() - f
+			ds[k] = d
+			l.Min, l.Max = math.Min(l.Min, d), math.Max(l.Max, d)
+			l.Impact += math.Abs(d)
+		}
+		if samples > 0 {
+			var variance float64
+			l.Mean, variance = moments(ds)
+			l.Std, l.Impact = math.Sqrt(variance), l.Impact/float64(samples)
+		} else {
+			l.Mean, l.Std, l.Min, l.Max = math.NaN(), math.NaN(), math.NaN(), math.NaN()
+		}
+		ls[i] = l
+	}
+	sort.SliceStable(ls, func(i, j int) bool {
+		return ls[i].Impact > ls[j].Impact
+	})
+	return ls
+}
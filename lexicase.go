@@ -0,0 +1,124 @@
+package ga
+
+import (
+	"math"
+	"sort"
+)
+
+// CaseEntity is an entity with the fitnesses of the test cases, e.g. of a program, for LexicaseSelector.
+// The case fitnesses are maximized as the fitness, regardless of WithMinimize, so negate the errors.
+type CaseEntity interface {
+	Entity
+	// Cases returns the number of test cases.
+	Cases() int
+	// CaseFitness returns the fitness of the test case i.
+	CaseFitness(i int) float64
+}
+
+// PopulationSelector is a selector which also needs the entities of the population.
+// The model calls SelectionOf instead of Selection.
+type PopulationSelector interface {
+	Selector
+	// SelectionOf is Selection with the entities es of the fitnesses fs.
+	SelectionOf(es []Entity, fs []float64) func(rand func() float64) int
+}
+
+type lexicaseSelector bool
+
+// LexicaseSelector chooses each parent by filtering the population with the test cases in a random order,
+// keeping only the best on each case, until one is left or the cases run out, and then uniformly.
+// It preserves the specialists of a few cases, which the aggregate fitness loses.
+// The entities should implement CaseEntity, otherwise the fitness is the only case.
+func LexicaseSelector() PopulationSelector {
+	return lexicaseSelector(false)
+}
+
+// EpsilonLexicaseSelector is LexicaseSelector keeping those within epsilon of the best on each case,
+// where epsilon is the median absolute deviation of the case in the population, for the continuous case fitnesses.
+func EpsilonLexicaseSelector() PopulationSelector {
+	return lexicaseSelector(true)
+}
+
+func (s lexicaseSelector) Selection(fs []float64) func(func() float64) int {
+	return s.SelectionOf(nil, fs)
+}
+
+func (s lexicaseSelector) SelectionOf(es []Entity, fs []float64) func(func() float64) int {
+	n := len(fs)
+	cs := [][]float64{fs}
+	if len(es) == n && n > 0 {
+		if e, ok := es[0].(CaseEntity); ok {
+			cs = make([][]float64, e.Cases())
+			for c := range cs {
+				cs[c] = make([]float64, n)
+			}
+			for i, e := range es {
+				e := e.(CaseEntity)
+				for c := range cs {
+					cs[c][i] = e.CaseFitness(c)
+				}
+			}
+		}
+	}
+	eps := make([]float64, len(cs))
+	if s {
+		for c, xs := range cs {
+			eps[c] = mad(xs)
+		}
+	}
+	return func(rand func() float64) int {
+		pool := make([]int, n)
+		for i := range pool {
+			pool[i] = i
+		}
+		order := make([]int, len(cs))
+		for i := range order {
+			j := index(rand(), i+1)
+			order[i], order[j] = order[j], i
+		}
+		for _, c := range order {
+			if len(pool) <= 1 {
+				break
+			}
+			xs, best := cs[c], math.Inf(-1)
+			for _, i := range pool {
+				best = math.Max(best, xs[i])
+			}
+			k := 0
+			for _, i := range pool {
+				if xs[i] >= best-eps[c] {
+					pool[k], k = i, k+1
+				}
+			}
+			pool = pool[:k]
+		}
+		return pool[index(rand(), len(pool))]
+	}
+}
+
+// mad returns the median absolute deviation of xs.
+func mad(xs []float64) float64 {
+	ys := append([]float64(nil), xs...)
+	sort.Float64s(ys)
+	m := median(ys)
+	for i, y := range ys {
+		ys[i] = math.Abs(y - m)
+	}
+	sort.Float64s(ys)
+	if d := median(ys); !math.IsNaN(d) && !math.IsInf(d, 0) {
+		return d
+	}
+	return 0
+}
+
+// median returns the median of the sorted xs.
+func median(xs []float64) float64 {
+	n := len(xs)
+	if n == 0 {
+		return 0
+	}
+	if n%2 == 1 {
+		return xs[n/2]
+	}
+	return (xs[n/2-1] + xs[n/2]) / 2
+}
@@ -0,0 +1,80 @@
+package ga_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+// Cases is a point whose cases are the distances of the coordinates to 1.
+type Cases []float64
+
+func (x Cases) Fitness() float64 {
+	f := 0.0
+	for i := range x {
+		f += x.CaseFitness(i)
+	}
+	return f
+}
+
+func (x Cases) Cases() int {
+	return len(x)
+}
+
+func (x Cases) CaseFitness(i int) float64 {
+	return -math.Abs(x[i] - 1)
+}
+
+func (x Cases) Mutate() ga.Entity {
+	y := append(Cases(nil), x...)
+	y[rand.Intn(len(y))] += rand.Float64() - 0.5
+	return y
+}
+
+func (x Cases) Crossover(e ga.Entity, w float64) ga.Entity {
+	y := append(Cases(nil), x...)
+	for i, v := range e.(Cases) {
+		if rand.Float64() > w {
+			y[i] = v
+		}
+	}
+	return y
+}
+
+func TestLexicaseSelector(t *testing.T) {
+	es := []ga.Entity{Cases{1, -9, -9}, Cases{-9, 1, -9}, Cases{0, 0, 0}, Cases{0.1, 0.1, 0.1}}
+	fs := make([]float64, len(es))
+	for i, e := range es {
+		fs[i] = e.Fitness()
+	}
+	counts := make([]int, len(es))
+	pick := ga.LexicaseSelector().SelectionOf(es, fs)
+	for i := 0; i < 3000; i++ {
+		counts[pick(rand.Float64)]++
+	}
+	if counts[0] < 800 || counts[1] < 800 || counts[2] != 0 || counts[3] < 800 {
+		t.Fatal("lexicase:", counts)
+	}
+
+	counts = make([]int, len(es))
+	pick = ga.EpsilonLexicaseSelector().SelectionOf(es, fs)
+	for i := 0; i < 3000; i++ {
+		counts[pick(rand.Float64)]++
+	}
+	if counts[2] == 0 {
+		t.Fatal("epsilon-lexicase:", counts)
+	}
+
+	m := ga.New(50, func() ga.Entity {
+		x := make(Cases, 5)
+		for i := range x {
+			x[i] = rand.Float64()*4 - 2
+		}
+		return x
+	}, ga.WithSelector(ga.LexicaseSelector()))
+	if _, f, _ := m.Evolve(50, 1000); f < -0.5 {
+		t.Fatal("fitness:", f)
+	}
+}
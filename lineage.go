@@ -0,0 +1,253 @@
+package ga
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Origin is the record of how an entity was created, see WithLineage.
+type Origin struct {
+	// Generation is the generation the entity was born into, 0 for the initial population.
+	Generation int
+	// Operator is the operator which created the entity, one of "generator", "crossover", "mutation"
+	// and "crossover+mutation", where the operators of WithAdaptiveOperators are suffixed by their indices, e.g. "mutation[1]".
+	// The entities of unknown origin, e.g. by the generator or SetPopulation, are "generator".
+	Operator string
+	// Parents are the parents of the entity, which are empty for "generator".
+	Parents []Entity
+	// Fitness is the fitness of the entity, or NaN if it has not been evaluated in a population yet.
+	Fitness float64
+}
+
+// WithLineage records the origin of every entity, i.e. its parents, operator and birth generation,
+// to analyze e.g. which operators produce the improvements, by Lineage and WriteLineage.
+// The records are kept for the entities of the current population and their ancestors only.
+// The entities are identified by ==, so they must be comparable, e.g. pointers,
+// and they must not be reused, e.g. by MutableEntity or WithRecycle.
+func WithLineage() Option {
+	return func(m *GA) {
+		m.lineage = &lineage{origins: make(map[Entity]*origin)}
+	}
+}
+
+// lineage is the records of WithLineage.
+type lineage struct {
+	mutex   sync.Mutex
+	origins map[Entity]*origin
+	id      int
+}
+
+type origin struct {
+	Origin
+	id      int
+	parents []*origin
+}
+
+// record records the origin of e, unless it is one of its parents or not comparable.
+func (l *lineage) record(e Entity, gen int, op string, parents ...Entity) {
+	if !comparable(e) {
+		return
+	}
+	for _, p := range parents {
+		if p == e {
+			return
+		}
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	o := &origin{Origin: Origin{Generation: gen, Operator: op, Parents: parents, Fitness: math.NaN()}}
+	for _, p := range parents {
+		if comparable(p) {
+			o.parents = append(o.parents, l.origins[p])
+		}
+	}
+	l.id++
+	o.id = l.id
+	l.origins[e] = o
+}
+
+// trace records the origin of the offspring z of the slot i, by the parents x and y.
+func (m *GA) trace(i int, z, x, y Entity, crossed, mutated bool) {
+	var ops []string
+	parents := []Entity{x}
+	if crossed {
+		op := "crossover"
+		if m.ops != nil && len(m.ops.cs) > 0 && i < len(m.ops.ci) {
+			op += "[" + strconv.Itoa(m.ops.ci[i]) + "]"
+		}
+		ops, parents = append(ops, op), append(parents, y)
+	}
+	if mutated {
+		op := "mutation"
+		if m.ops != nil && len(m.ops.ms) > 0 && i < len(m.ops.mi) {
+			op += "[" + strconv.Itoa(m.ops.mi[i]) + "]"
+		}
+		ops = append(ops, op)
+	}
+	if len(ops) > 0 {
+		m.lineage.record(z, m.gen+1, strings.Join(ops, "+"), parents...)
+	}
+}
+
+// settle records the fitnesses of the population of m, and the entities of unknown origin,
+// and forgets the records which are not the ancestors of the population.
+func (l *lineage) settle(m *GA) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	p := &m.pop
+	alive := make(map[*origin]bool, len(l.origins))
+	var visit func(o *origin)
+	visit = func(o *origin) {
+		if o == nil || alive[o] {
+			return
+		}
+		alive[o] = true
+		for _, q := range o.parents {
+			visit(q)
+		}
+	}
+	for i, e := range p.entities {
+		if !comparable(e) {
+			continue
+		}
+		o := l.origins[e]
+		if o == nil {
+			l.id++
+			o = &origin{Origin: Origin{Generation: m.gen, Operator: "generator"}, id: l.id}
+			l.origins[e] = o
+		}
+		o.Fitness = m.objective(p.fitnesses[i])
+		visit(o)
+	}
+	for e, o := range l.origins {
+		if !alive[o] {
+			delete(l.origins, e)
+		}
+	}
+}
+
+// Lineage returns the origin of e, which is a member of the current population or one of its ancestors,
+// and false if it is not recorded, e.g. without WithLineage.
+func (m *GA) Lineage(e Entity) (Origin, bool) {
+	if m.lineage == nil || !comparable(e) {
+		return Origin{}, false
+	}
+	m.lineage.mutex.Lock()
+	defer m.lineage.mutex.Unlock()
+	o, ok := m.lineage.origins[e]
+	if !ok {
+		return Origin{}, false
+	}
+	return o.Origin, true
+}
+
+// nodes returns the records of lineage sorted by their ids, with the described entities.
+func (m *GA) nodes() ([]*origin, []string) {
+	if m.lineage == nil {
+		return nil, nil
+	}
+	l := m.lineage
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	os, ds := make([]*origin, 0, len(l.origins)), make(map[*origin]string, len(l.origins))
+	for e, o := range l.origins {
+		os = append(os, o)
+		ds[o] = Describe(e)
+	}
+	sort.Slice(os, func(i, j int) bool {
+		return os[i].id < os[j].id
+	})
+	ss := make([]string, len(os))
+	for i, o := range os {
+		ss[i] = ds[o]
+	}
+	return os, ss
+}
+
+// ids returns the ids of the recorded parents of o.
+func (o *origin) ids() []int {
+	var ids []int
+	for _, p := range o.parents {
+		if p != nil {
+			ids = append(ids, p.id)
+		}
+	}
+	return ids
+}
+
+// WriteLineage writes the ancestry graph recorded by WithLineage to w in the format, one record per entity.
+// CSV has the columns id, generation, operator, fitness, parents and entity, where the parents are the ids separated by spaces,
+// and JSONLines has the same fields, where the entity is described by Describe.
+// The parents which were not recorded, e.g. before the lineage is settled, are omitted.
+func (m *GA) WriteLineage(w io.Writer, format TraceFormat) error {
+	os, ds := m.nodes()
+	if format == CSV {
+		c := csv.NewWriter(w)
+		c.Write([]string{"id", "generation", "operator", "fitness", "parents", "entity"})
+		for i, o := range os {
+			ps := make([]string, 0, len(o.parents))
+			for _, id := range o.ids() {
+				ps = append(ps, strconv.Itoa(id))
+			}
+			c.Write([]string{
+				strconv.Itoa(o.id), strconv.Itoa(o.Generation), o.Operator,
+				strconv.FormatFloat(o.Fitness, 'g', -1, 64), strings.Join(ps, " "), ds[i],
+			})
+		}
+		c.Flush()
+		return c.Error()
+	}
+	b := bufio.NewWriter(w)
+	for i, o := range os {
+		line := strconv.AppendInt([]byte(`{"id":`), int64(o.id), 10)
+		line = strconv.AppendInt(append(line, `,"generation":`...), int64(o.Generation), 10)
+		s, _ := json.Marshal(o.Operator)
+		line = append(append(line, `,"operator":`...), s...)
+		line = append(line, `,"fitness":`...)
+		if math.IsNaN(o.Fitness) || math.IsInf(o.Fitness, 0) {
+			line = append(line, "null"...)
+		} else {
+			line = strconv.AppendFloat(line, o.Fitness, 'g', -1, 64)
+		}
+		line = append(line, `,"parents":[`...)
+		for k, id := range o.ids() {
+			if k > 0 {
+				line = append(line, ',')
+			}
+			line = strconv.AppendInt(line, int64(id), 10)
+		}
+		s, _ = json.Marshal(ds[i])
+		line = append(append(append(line, `],"entity":`...), s...), "}\n"...)
+		if _, err := b.Write(line); err != nil {
+			return err
+		}
+	}
+	return b.Flush()
+}
+
+// WriteLineageDOT writes the ancestry graph recorded by WithLineage to w in the DOT language of Graphviz,
+// where the edges point from the parents to the children, and are labeled by the operators.
+func (m *GA) WriteLineageDOT(w io.Writer) error {
+	os, ds := m.nodes()
+	b := bufio.NewWriter(w)
+	fmt.Fprintln(b, "digraph lineage {")
+	for i, o := range os {
+		label := strings.TrimSuffix(strconv.Quote(ds[i]), `"`)
+		fmt.Fprintf(b, "\tn%d [label=%s\\ngen %d, fitness %g\"];\n", o.id, label, o.Generation, o.Fitness)
+	}
+	for _, o := range os {
+		for _, id := range o.ids() {
+			fmt.Fprintf(b, "\tn%d -> n%d [label=%s];\n", id, o.id, strconv.Quote(o.Operator))
+		}
+	}
+	fmt.Fprintln(b, "}")
+	return b.Flush()
+}
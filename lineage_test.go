@@ -0,0 +1,87 @@
+package ga_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+type Node struct {
+	X float64
+}
+
+func (n *Node) Fitness() float64 {
+	return -n.X * n.X
+}
+
+func (n *Node) Mutate() ga.Entity {
+	return &Node{n.X + rand.NormFloat64()}
+}
+
+func (n *Node) Crossover(e ga.Entity, w float64) ga.Entity {
+	return &Node{w*n.X + (1-w)*e.(*Node).X}
+}
+
+func TestLineage(t *testing.T) {
+	g := func() ga.Entity {
+		return &Node{10*rand.Float64() - 5}
+	}
+	m := ga.New(20, g, ga.WithLineage(), ga.WithFixedMutationRate(0.5))
+	if o, ok := m.Lineage(m.Population()[0]); !ok || o.Operator != "generator" || o.Generation != 0 {
+		t.Fatal("initial:", o, ok)
+	}
+	es := m.EvolveTo(10)
+	ops := make(map[string]int)
+	for _, e := range es {
+		o, ok := m.Lineage(e)
+		if !ok || o.Fitness != e.Fitness() || o.Generation > 10 {
+			t.Fatal("origin:", o, ok)
+		}
+		ops[o.Operator]++
+		for _, p := range o.Parents {
+			if q, ok := m.Lineage(p); !ok || q.Generation >= o.Generation || math.IsNaN(q.Fitness) {
+				t.Fatal("parent:", o, q, ok)
+			}
+		}
+	}
+	if ops["crossover"] == 0 || ops["crossover+mutation"] == 0 {
+		t.Fatal("operators:", ops)
+	}
+
+	var csv, lines, dot bytes.Buffer
+	if err := m.WriteLineage(&csv, ga.CSV); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.WriteLineage(&lines, ga.JSONLines); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.WriteLineageDOT(&dot); err != nil {
+		t.Fatal(err)
+	}
+	rows := strings.Split(strings.TrimSpace(csv.String()), "\n")
+	records := strings.Split(strings.TrimSpace(lines.String()), "\n")
+	if len(rows) != len(records)+1 || len(records) < len(es) {
+		t.Fatal("records:", len(rows), len(records))
+	}
+	for _, r := range records {
+		var x struct {
+			ID      int
+			Parents []int
+		}
+		if err := json.Unmarshal([]byte(r), &x); err != nil || x.ID <= 0 {
+			t.Fatal("json:", r, err)
+		}
+	}
+	if !strings.HasPrefix(dot.String(), "digraph lineage {") || !strings.Contains(dot.String(), "->") {
+		t.Fatal("dot:", dot.String())
+	}
+
+	if _, ok := ga.New(5, g).Lineage(es[0]); ok {
+		t.Fatal("without lineage")
+	}
+}
@@ -0,0 +1,126 @@
+package ga
+
+import (
+	"math"
+	"sort"
+)
+
+// MaskedCrossover is an entity of a fixed-length genome supporting the crossover by a mask, see WithLinkage.
+type MaskedCrossover interface {
+	Entity
+	// MaskedCrossover produces the offspring taking the genes at the positions where mask is true from the receiver,
+	// and the others from e.
+	MaskedCrossover(e Entity, mask []bool) Entity
+}
+
+// WithLinkage learns the linkage of the gene positions, i.e. which positions improve together,
+// and crosses the entities implementing MaskedCrossover by masks preserving the linked groups as building blocks.
+// extract returns the gene values of an entity, e.g. 0 or 1 for each allele, and all entities must have the same number of genes.
+// Every generation, two positions are linked if the absolute correlation of their values
+// among the fitter half of the population is at least threshold, and the groups are the connected positions.
+// Each group is inherited from the first parent with the crossover weight as the probability, or else from the second.
+func WithLinkage(extract func(Entity) []float64, threshold float64) Option {
+	return func(m *GA) {
+		m.linkage = &linkage{extract: extract, threshold: threshold}
+	}
+}
+
+// Linkage returns the groups of the linked gene positions learned from the current population,
+// each in the ascending order, or nil if WithLinkage is not set.
+func (m *GA) Linkage() [][]int {
+	if m.linkage == nil {
+		return nil
+	}
+	gs := make([][]int, len(m.linkage.groups))
+	for i, g := range m.linkage.groups {
+		gs[i] = append([]int(nil), g...)
+	}
+	return gs
+}
+
+type linkage struct {
+	extract   func(Entity) []float64
+	threshold float64
+	groups    [][]int
+	size      int
+}
+
+// learn groups the gene positions by their correlations among the fitter half of p.
+func (l *linkage) learn(p *Population) {
+	idx := order(p.fitnesses)
+	idx = idx[len(idx)/2:]
+	if len(idx) == 0 {
+		l.groups, l.size = nil, 0
+		return
+	}
+	xs := make([][]float64, len(idx))
+	for i, j := range idx {
+		xs[i] = l.extract(p.entities[j])
+	}
+	n, k := float64(len(xs)), len(xs[0])
+	means, stds := make([]float64, k), make([]float64, k)
+	for _, x := range xs {
+		for j, v := range x {
+			means[j] += v / n
+		}
+	}
+	for _, x := range xs {
+		for j, v := range x {
+			stds[j] += (v - means[j]) * (v - means[j]) / n
+		}
+	}
+	for j := range stds {
+		stds[j] = math.Sqrt(stds[j])
+	}
+	parents := make([]int, k)
+	for j := range parents {
+		parents[j] = j
+	}
+	var root func(j int) int
+	root = func(j int) int {
+		for parents[j] != j {
+			parents[j] = parents[parents[j]]
+			j = parents[j]
+		}
+		return j
+	}
+	for a := 0; a < k; a++ {
+		for b := a + 1; b < k; b++ {
+			if stds[a] == 0 || stds[b] == 0 || root(a) == root(b) {
+				continue
+			}
+			c := 0.0
+			for _, x := range xs {
+				c += (x[a] - means[a]) * (x[b] - means[b])
+			}
+			if math.Abs(c/n/stds[a]/stds[b]) >= l.threshold {
+				parents[root(b)] = root(a)
+			}
+		}
+	}
+	groups := make(map[int][]int)
+	for j := range parents {
+		r := root(j)
+		groups[r] = append(groups[r], j)
+	}
+	l.groups, l.size = l.groups[:0], k
+	for _, g := range groups {
+		l.groups = append(l.groups, g)
+	}
+	sort.Slice(l.groups, func(i, j int) bool {
+		return l.groups[i][0] < l.groups[j][0]
+	})
+}
+
+// mask returns a crossover mask inheriting each group from the first parent with the probability w.
+func (l *linkage) mask(w float64, u func() float64) []bool {
+	mask := make([]bool, l.size)
+	for _, g := range l.groups {
+		if u() < w {
+			for _, j := range g {
+				mask[j] = true
+			}
+		}
+	}
+	return mask
+}
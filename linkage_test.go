@@ -0,0 +1,67 @@
+package ga_test
+
+import (
+	"math/rand/v2"
+	"reflect"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+// Blocks is a genome of 4 blocks of 2 bits, where a block scores only if both of its bits are set,
+// so the bits of a block are linked.
+type Blocks [8]float64
+
+func (b Blocks) Fitness() float64 {
+	f := 0.0
+	for j := 0; j < len(b); j += 2 {
+		f += b[j] * b[j+1]
+	}
+	return f
+}
+
+func (b Blocks) Mutate() ga.Entity {
+	b[rand.IntN(len(b))] = float64(rand.IntN(2))
+	return b
+}
+
+func (b Blocks) Crossover(e ga.Entity, w float64) ga.Entity {
+	panic("uniform crossover")
+}
+
+func (b Blocks) MaskedCrossover(e ga.Entity, mask []bool) ga.Entity {
+	c := e.(Blocks)
+	for j, ok := range mask {
+		if ok {
+			c[j] = b[j]
+		}
+	}
+	return c
+}
+
+func TestLinkage(t *testing.T) {
+	// The unfit half has the blocks broken, and the fit half has the blocks of the bits of i.
+	es := make([]ga.Entity, 32)
+	for i := 0; i < 16; i++ {
+		var u, v Blocks
+		for j := 0; j < 4; j++ {
+			u[2*j+i%2] = 1
+			v[2*j], v[2*j+1] = float64(i>>j&1), float64(i>>j&1)
+		}
+		es[i], es[16+i] = u, v
+	}
+	extract := func(e ga.Entity) []float64 {
+		b := e.(Blocks)
+		return b[:]
+	}
+	m := ga.NewFrom(32, es, nil, ga.WithLinkage(extract, 0.9))
+	if gs := m.Linkage(); !reflect.DeepEqual(gs, [][]int{{0, 1}, {2, 3}, {4, 5}, {6, 7}}) {
+		t.Fatal("groups:", gs)
+	}
+	if _, f, _ := m.Evolve(20, 200); f != 4 {
+		t.Fatal("fitness:", f)
+	}
+	if ga.New(5, MIN{}.Mutate).Linkage() != nil {
+		t.Fatal("linkage without WithLinkage")
+	}
+}
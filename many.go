@@ -0,0 +1,39 @@
+package ga
+
+import "sync/atomic"
+
+// Outcome is the result of a model of EvolveMany.
+type Outcome struct {
+	Elite     Entity
+	Fitness   float64
+	Converged bool
+}
+
+// EvolveMany runs each of the models by Evolve(k, max), and returns their results in the same order.
+// It is for many small models, e.g. thousands of tiny independent problems:
+// each model runs on a single goroutine, with the concurrency 1 during the run,
+// and the workers of the first model, as many as its concurrency, take the next model as soon as they are done,
+// instead of every model running its own workers.
+// The models must be distinct.
+func EvolveMany(models []*GA, k int, max int) []Outcome {
+	rs := make([]Outcome, len(models))
+	if len(models) == 0 {
+		return rs
+	}
+	p, next := &models[0].pop, int64(-1)
+	nc := p.concurrency()
+	if nc > len(models) {
+		nc = len(models)
+	}
+	p.parallel(nc, func(c, _ int) {
+		for i := int(atomic.AddInt64(&next, 1)); i < len(models); i = int(atomic.AddInt64(&next, 1)) {
+			m := models[i]
+			nc := m.pop.nc
+			m.pop.nc = 1
+			e, f, ok := m.Evolve(k, max)
+			m.pop.nc = nc
+			rs[i] = Outcome{e, f, ok}
+		}
+	})
+	return rs
+}
@@ -0,0 +1,29 @@
+package ga_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestEvolveMany(t *testing.T) {
+	ms := make([]*ga.GA, 200)
+	for i := range ms {
+		ms[i] = ga.New(10, func() ga.Entity {
+			return Walk(20*rand.Float64() - 10)
+		}, ga.WithElitism(1))
+	}
+	rs := ga.EvolveMany(ms, 50, 500)
+	if len(rs) != len(ms) {
+		t.Fatal("outcomes:", len(rs))
+	}
+	for i, r := range rs {
+		if r.Elite != ms[i].Elite() || r.Fitness != ms[i].Fitness() || r.Fitness < -1e-2 || !r.Converged {
+			t.Fatal("outcome:", i, r)
+		}
+	}
+	if rs := ga.EvolveMany(nil, 20, 200); len(rs) != 0 {
+		t.Fatal("empty:", rs)
+	}
+}
@@ -0,0 +1,124 @@
+package ga
+
+import (
+	"math"
+	"sort"
+)
+
+// WithMapElites switches GA model to the quality-diversity mode of MAP-Elites,
+// which keeps the fittest entity found in each cell of a discretized behavior space, instead of a single champion.
+// The behavior of an entity is described by descriptor, whose dimension d is the length of lo and hi,
+// and each dimension is divided into bins equal intervals between lo and hi, clamping the descriptors outside.
+// Each generation, the n offspring are produced by crossover and mutation of the parents drawn uniformly from the archive,
+// and each of them takes its cell if the cell is empty or it is fitter than the occupant.
+// The mutation probability still adapts to the offspring, so the mutation is usually fixed by WithFixedMutationRate(1)
+// to keep exploring the behavior space. The population is the offspring of the last generation,
+// and the archive is read by Archive.
+// There is no selection by fitness, and the elite is the fittest of the archive.
+func WithMapElites(descriptor func(Entity) []float64, lo, hi []float64, bins int) Option {
+	return func(m *GA) {
+		if bins < 1 {
+			bins = 1
+		}
+		m.archive = &archive{descriptor: descriptor, lo: lo, hi: hi, bins: bins, cells: make(map[int]int)}
+	}
+}
+
+// Cell is an occupied cell of the archive of WithMapElites.
+type Cell struct {
+	// Index is the coordinates of the cell, one bin per dimension of the behavior space.
+	Index []int
+	// Entity is the fittest entity found in the cell.
+	Entity Entity
+	// Fitness is the fitness of the entity.
+	Fitness float64
+}
+
+// Archive returns the occupied cells of the archive of WithMapElites in the order of their coordinates,
+// or nil if WithMapElites is not set.
+func (m *GA) Archive() []Cell {
+	a := m.archive
+	if a == nil {
+		return nil
+	}
+	keys := append([]int(nil), a.keys...)
+	sort.Ints(keys)
+	cs := make([]Cell, len(keys))
+	for j, k := range keys {
+		i := a.cells[k]
+		cs[j] = Cell{a.coordinates(k), a.entities[i], m.objective(a.fitnesses[i])}
+	}
+	return cs
+}
+
+// archive is the state of WithMapElites.
+type archive struct {
+	descriptor func(Entity) []float64
+	lo, hi     []float64
+	bins       int
+	// cells maps the key of a cell to the index of its occupant, and keys are the keys in the order of occupation.
+	cells     map[int]int
+	keys      []int
+	entities  []Entity
+	fitnesses []float64
+}
+
+// key returns the key of the cell of e.
+func (a *archive) key(e Entity) int {
+	ds, k := a.descriptor(e), 0
+	for j := range a.lo {
+		b := 0
+		if w := a.hi[j] - a.lo[j]; w > 0 && j < len(ds) {
+			b = int(math.Floor((ds[j] - a.lo[j]) / w * float64(a.bins)))
+		}
+		if b < 0 {
+			b = 0
+		} else if b >= a.bins {
+			b = a.bins - 1
+		}
+		k = k*a.bins + b
+	}
+	return k
+}
+
+// coordinates returns the coordinates of the cell of the key k.
+func (a *archive) coordinates(k int) []int {
+	idx := make([]int, len(a.lo))
+	for j := len(idx) - 1; j >= 0; j-- {
+		idx[j], k = k%a.bins, k/a.bins
+	}
+	return idx
+}
+
+// insert puts the entities es with the fitnesses fs into their cells, if they are empty or es are fitter.
+func (a *archive) insert(es []Entity, fs []float64) {
+	for i, e := range es {
+		if math.IsNaN(fs[i]) {
+			continue
+		}
+		k := a.key(e)
+		if j, ok := a.cells[k]; !ok {
+			a.cells[k] = len(a.entities)
+			a.keys = append(a.keys, k)
+			a.entities, a.fitnesses = append(a.entities, e), append(a.fitnesses, fs[i])
+		} else if fs[i] > a.fitnesses[j] {
+			a.entities[j], a.fitnesses[j] = e, fs[i]
+		}
+	}
+}
+
+// illuminate produces the next generation by MAP-Elites into the previous generation's buffers.
+func (m *GA) illuminate() {
+	a, p := m.archive, &m.pop
+	if len(a.entities) == 0 {
+		a.insert(p.entities, p.fitnesses)
+	}
+	m.fork()
+	m.do(func(c, i int) {
+		u := m.random(c, i)
+		x, y := a.entities[index(u(), len(a.entities))], a.entities[index(u(), len(a.entities))]
+		m.tentities[i] = m.vary(i, x, y, 0.5, u)
+	})
+	p.evaluateInto(m.tentities, m.tfitnesses)
+	a.insert(m.tentities, m.tfitnesses)
+}
@@ -0,0 +1,40 @@
+package ga_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestMapElites(t *testing.T) {
+	descriptor := func(e ga.Entity) []float64 {
+		return []float64{float64(e.(Walk))}
+	}
+	m := ga.New(20, func() ga.Entity {
+		return Walk(rand.Float64()*0.5 - 2)
+	}, ga.WithMapElites(descriptor, []float64{-2}, []float64{2}, 4), ga.WithFixedMutationRate(1))
+	m.EvolveTo(100)
+	cs := m.Archive()
+	if len(cs) != 4 {
+		t.Fatal("cells:", len(cs))
+	}
+	best := cs[0].Fitness
+	for i, c := range cs {
+		if c.Index[0] != i {
+			t.Fatal("index:", i, c.Index)
+		}
+		if w := float64(c.Entity.(Walk)); i < 3 && (w < float64(i-2) || w >= float64(i-1)) {
+			t.Fatal("cell:", i, w)
+		}
+		if c.Fitness > best {
+			best = c.Fitness
+		}
+	}
+	if m.Fitness() != best || best < -1e-2 {
+		t.Fatal("elite:", m.Fitness(), best)
+	}
+	if ga.New(5, MIN{}.Mutate).Archive() != nil {
+		t.Fatal("archive without MAP-Elites")
+	}
+}
@@ -0,0 +1,22 @@
+package ga
+
+// WithMatingRestriction restricts the crossover to the pairs of parents which are compatible,
+// e.g. of the same species of variable-length genomes, so the incompatible parents are never crossed.
+// If the parents of an offspring are incompatible, they are reselected up to retries times,
+// and if they are still incompatible, the offspring is reproduced asexually from the first parent,
+// i.e. it is a copy of the first parent, which is mutated as usual.
+func WithMatingRestriction(compatible func(x, y Entity) bool, retries int) Option {
+	return func(m *GA) {
+		m.mating = &mating{compatible, retries}
+	}
+}
+
+type mating struct {
+	compatible func(x, y Entity) bool
+	retries    int
+}
+
+// compatible reports whether x and y may be crossed.
+func (m *GA) compatible(x, y Entity) bool {
+	return m.mating == nil || m.mating.compatible(x, y)
+}
@@ -0,0 +1,46 @@
+package ga_test
+
+import (
+	"math/rand/v2"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+type Kind struct {
+	Walk
+	Tag int
+}
+
+var crossed int32
+
+func (x Kind) Mutate() ga.Entity {
+	return Kind{x.Walk.Mutate().(Walk), x.Tag}
+}
+
+func (x Kind) Crossover(e ga.Entity, w float64) ga.Entity {
+	y := e.(Kind)
+	if x.Tag != y.Tag {
+		atomic.AddInt32(&crossed, 1)
+	}
+	return Kind{x.Walk.Crossover(y.Walk, w).(Walk), x.Tag}
+}
+
+func TestMatingRestriction(t *testing.T) {
+	compatible := func(x, y ga.Entity) bool {
+		return x.(Kind).Tag == y.(Kind).Tag
+	}
+	for _, retries := range []int{0, 3} {
+		atomic.StoreInt32(&crossed, 0)
+		m := ga.New(40, func() ga.Entity {
+			return Kind{Walk(rand.Float64()*4 - 2), rand.IntN(3)}
+		}, ga.WithMatingRestriction(compatible, retries))
+		if _, f, _ := m.Evolve(50, 500); f < -1e-2 {
+			t.Fatal("fitness:", retries, f)
+		}
+		if n := atomic.LoadInt32(&crossed); n > 0 {
+			t.Fatal("incompatible crossovers:", retries, n)
+		}
+	}
+}
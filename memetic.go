@@ -0,0 +1,41 @@
+package ga
+
+import "math"
+
+// WithLocalSearch combines the GA model with the local search ls, as a memetic algorithm.
+// Every generation, ls is applied to the fittest entity and to the fraction frac of the others chosen randomly,
+// and the results replace them, whether or not they are fitter.
+// ls should return a refined entity, e.g. by hill climbing, and runs in parallel.
+func WithLocalSearch(ls func(Entity) Entity, frac float64) Option {
+	return func(m *GA) {
+		m.ls, m.lfrac = ls, frac
+	}
+}
+
+// refine applies the local search to the population.
+func (m *GA) refine() {
+	p := &m.pop
+	if p.ibest < 0 {
+		return
+	}
+	idx := []int{p.ibest}
+	k := int(math.Round(math.Max(0, math.Min(1, m.lfrac)) * float64(m.n-1)))
+	for _, i := range m.rnd.Perm(m.n)[:k+1] {
+		if len(idx) > k {
+			break
+		}
+		if i != p.ibest {
+			idx = append(idx, i)
+		}
+	}
+	es := make([]Entity, len(idx))
+	m.pop.parallel(len(idx), func(c, j int) {
+		es[j] = m.ls(p.entities[idx[j]])
+	})
+	for j, f := range p.replace(idx, es) {
+		if m.fitter(es[j], f) {
+			m.fitness, m.elite = f, es[j]
+		}
+	}
+	m.reweigh()
+}
@@ -0,0 +1,25 @@
+package ga_test
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestLocalSearch(t *testing.T) {
+	var calls int64
+	climb := func(e ga.Entity) ga.Entity {
+		atomic.AddInt64(&calls, 1)
+		x := e.(MIN)
+		return MIN{x.X / 2, x.Y / 2}
+	}
+	m := ga.New(20, MIN{}.Mutate, ga.WithLocalSearch(climb, 0.25))
+	m.EvolveTo(30)
+	if n := atomic.LoadInt64(&calls); n != 30*6 {
+		t.Fatal("calls:", n)
+	}
+	if f := m.Fitness(); f < -1e-4 {
+		t.Fatal("fitness:", f)
+	}
+}
@@ -0,0 +1,50 @@
+package ga
+
+import "sync"
+
+// WithFitnessMemo memoizes the fitnesses of the population by the identity of the entities,
+// so the entities carried over unchanged into the next generation, e.g. the elites, the survivors and the copies without variation,
+// are not evaluated again, and not counted as evaluations.
+// Only comparable entities, e.g. values or pointers, are memoized, and pointed entities must not be modified once evaluated.
+// Unlike WithCache, it needs neither Keyer nor Hasher, and only keeps the current population.
+// It is ignored with WithNoisyFitness or WithDynamicFitness, whose fitnesses must be re-evaluated.
+func WithFitnessMemo() Option {
+	return func(m *GA) {
+		m.memo = &memo{values: make(map[Entity]float64)}
+	}
+}
+
+// memo is the fitness memo of WithFitnessMemo.
+type memo struct {
+	mutex  sync.RWMutex
+	values map[Entity]float64
+}
+
+func (c *memo) get(e Entity) (float64, bool) {
+	c.mutex.RLock()
+	f, ok := c.values[e]
+	c.mutex.RUnlock()
+	return f, ok
+}
+
+func (c *memo) put(e Entity, f float64) {
+	c.mutex.Lock()
+	c.values[e] = f
+	c.mutex.Unlock()
+}
+
+// retain forgets the fitnesses of the entities other than es.
+func (c *memo) retain(es []Entity) {
+	values := make(map[Entity]float64, len(es))
+	for _, e := range es {
+		if f, ok := c.values[e]; ok {
+			values[e] = f
+		}
+	}
+	c.values = values
+}
+
+// reset forgets all the fitnesses.
+func (c *memo) reset() {
+	c.values = make(map[Entity]float64)
+}
@@ -0,0 +1,53 @@
+package ga_test
+
+import (
+	"math/rand"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+var calls int64
+
+// Counted counts the calls of Fitness.
+type Counted float64
+
+func (x Counted) Fitness() float64 {
+	atomic.AddInt64(&calls, 1)
+	return -float64(x * x)
+}
+
+func (x Counted) Mutate() ga.Entity {
+	return x + Counted(rand.Float64()-0.5)
+}
+
+func (x Counted) Crossover(e ga.Entity, w float64) ga.Entity {
+	return Counted(w)*x + Counted(1-w)*e.(Counted)
+}
+
+func TestFitnessMemo(t *testing.T) {
+	atomic.StoreInt64(&calls, 0)
+	m := ga.New(50, func() ga.Entity {
+		return Counted(rand.Float64()*10 - 5)
+	}, ga.WithFitnessMemo(), ga.WithElitism(5), ga.WithCrossoverRate(0), ga.WithFixedMutationRate(0.5))
+	m.EvolveTo(20)
+	n := atomic.LoadInt64(&calls)
+	if n != m.Evaluations() || n > 50+20*35 {
+		t.Fatal("evaluations:", n, m.Evaluations())
+	}
+	for _, e := range m.Population() {
+		if f := e.Fitness(); f > m.Fitness() {
+			t.Fatal("elite:", f, m.Fitness())
+		}
+	}
+
+	atomic.StoreInt64(&calls, 0)
+	m = ga.New(50, func() ga.Entity {
+		return Counted(rand.Float64()*10 - 5)
+	}, ga.WithFitnessMemo(), ga.WithDynamicFitness())
+	m.EvolveTo(5)
+	if n := atomic.LoadInt64(&calls); n < 300 {
+		t.Fatal("dynamic:", n)
+	}
+}
@@ -0,0 +1,76 @@
+package ga
+
+// Merge merges the population of other into this GA model, e.g. to consolidate parallel explorations.
+// The pooled entities compete by the survivor selection set by WithSurvivorSelection, or truncation by default,
+// and the keep survivors form the new population, so the population size becomes keep.
+// If keep <= 0, the population size is not changed.
+// It returns how many survivors came from this and other respectively.
+// The entities of both models must be compatible, which is the responsibility of the caller.
+// The elite of other is adopted if it is fitter.
+// The entities of other implementing MutableEntity are copied by CloneInto, since other reuses them in place.
+func (m *GA) Merge(other *GA, keep int) (mine, theirs int) {
+	if keep <= 0 {
+		keep = m.n
+	}
+	pool := append(m.pop.entities[:m.n:m.n], other.pop.entities...)
+	fs := append(m.pop.fitnesses[:m.n:m.n], other.pop.fitnesses...)
+	s := m.survivor
+	if s == nil {
+		s = TruncationSurvivor()
+	}
+	idx := s.Survive(fs, keep, m.rnd)
+
+	m.resize(keep)
+	seen := make(map[int]bool, len(idx))
+	for j, i := range idx {
+		e := pool[i]
+		if i < len(pool)-other.n {
+			mine++
+		} else {
+			theirs++
+		}
+		if seen[i] || i >= len(pool)-other.n {
+			e = copyOf(e)
+		}
+		seen[i] = true
+		m.pop.entities[j], m.pop.fitnesses[j] = e, fs[i]
+	}
+	if m.fitter(other.elite, other.fitness) {
+		m.fitness, m.elite = other.fitness, copyOf(other.elite)
+	}
+	m.refresh()
+	return mine, theirs
+}
+
+// resize changes the population size to n, and the entities should be filled then.
+func (m *GA) resize(n int) {
+	if n == m.n {
+		return
+	}
+	m.n = n
+	m.pop.init(make([]Entity, n))
+	m.tentities, m.tfitnesses = make([]Entity, n), make([]float64, n)
+}
+
+// Population returns a snapshot of the current population.
+// The entities implementing MutableEntity are not copied, so they are overwritten by the later generations,
+// and must be copied by CloneInto to be kept.
+func (m *GA) Population() []Entity {
+	es := make([]Entity, m.n)
+	copy(es, m.pop.entities)
+	return es
+}
+
+// SetPopulation replaces the population by the entities es, e.g. to warm start from a previous run,
+// so the population size becomes len(es), which must be positive.
+// The entities are evaluated, and the elite is kept if it is fitter than all of them.
+func (m *GA) SetPopulation(es []Entity) {
+	m.resize(len(es))
+	copy(m.pop.entities, es)
+	if m.mo != nil {
+		m.rank()
+	} else {
+		m.pop.evaluate()
+	}
+	m.refresh()
+}
@@ -0,0 +1,81 @@
+package ga_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestMerge(t *testing.T) {
+	a := ga.New(30, MIN{}.Mutate)
+	b := ga.New(20, func() ga.Entity {
+		return MIN{1e3, 1e3}
+	})
+	b.Next()
+	mine, theirs := a.Merge(b, 40)
+	if mine != 30 || theirs != 10 {
+		t.Fatal("survivors:", mine, theirs)
+	}
+	if s := a.SnapshotStats(); s.Worst != -2e6 {
+		t.Fatal("worst:", s.Worst)
+	}
+	if len(a.EvolveTo(0)) != 40 {
+		t.FailNow()
+	}
+	a.Next()
+	if len(a.EvolveTo(0)) != 40 {
+		t.FailNow()
+	}
+	if mine, theirs := a.Merge(b, 0); mine+theirs != 40 || theirs > 20 {
+		t.Fatal("survivors:", mine, theirs)
+	}
+}
+
+func TestSetPopulation(t *testing.T) {
+	m := ga.New(20, func() ga.Entity {
+		return Walk(-10)
+	})
+	es := m.Population()
+	if len(es) != 20 || es[0] != Walk(-10) {
+		t.Fatal("population:", es)
+	}
+	es[0] = Walk(5)
+	if m.Population()[0] != Walk(-10) {
+		t.Fatal("not a snapshot")
+	}
+	m.SetPopulation([]ga.Entity{Walk(3), Walk(1), Walk(2)})
+	if m.Size() != 3 || m.Elite() != Walk(1) || m.Fitness() != 0 {
+		t.Fatal("warm start:", m.Size(), m.Elite(), m.Fitness())
+	}
+	if s := m.SnapshotStats(); s.Worst != -4 {
+		t.Fatal("worst:", s.Worst)
+	}
+	m.Next()
+	if len(m.Population()) != 3 {
+		t.Fatal("size:", len(m.Population()))
+	}
+}
+
+func TestMergeMutable(t *testing.T) {
+	g := func() ga.Entity {
+		b := newBuffer(nil)
+		for i := range b.X {
+			b.X[i] = rand.Float64()
+		}
+		return b
+	}
+	a := ga.New(20, g, ga.WithFixedMutationRate(1))
+	b := ga.New(20, g, ga.WithFixedMutationRate(1))
+	b.EvolveTo(5)
+	a.Merge(b, 0)
+	for i := 0; i < 5; i++ {
+		a.Next()
+		b.Next()
+		for _, m := range []*ga.GA{a, b} {
+			if f := m.Elite().Fitness(); f != m.Fitness() {
+				t.Fatal("elite overwritten:", i, f, m.Fitness())
+			}
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Package metrics exports the per-generation metrics of GA models via expvar.
+package metrics
+
+import (
+	"expvar"
+	"time"
+
+	"github.com/ofunc/ga"
+)
+
+// Publish publishes the metrics of the model m as the expvar map named name, and returns the map.
+// The map is updated after every generation, and contains:
+// generations and evaluations, the counters since New;
+// best, fitness, mean, std, worst, pm and diversity, the statistics of the last generation;
+// generations_per_second, the rate since Publish;
+// evaluation_seconds, the wall time of the last generation per evaluation, an upper bound of the evaluation latency.
+// Like expvar.NewMap, Publish panics if the name is already registered.
+func Publish(name string, m *ga.GA) *expvar.Map {
+	v := expvar.NewMap(name)
+	Observe(v, m)
+	return v
+}
+
+// Observe updates the metrics of the model m into the map v after every generation, as Publish does.
+func Observe(v *expvar.Map, m *ga.GA) {
+	start, last := time.Now(), time.Now()
+	s0 := m.SnapshotStats()
+	gen, evals := s0.Generation, s0.Evaluations
+	m.OnGeneration(func(_ int, _ ga.Entity, _ float64, s ga.Stats) {
+		now := time.Now()
+		v.Set("generations", integer(int64(s.Generation)))
+		v.Set("evaluations", integer(s.Evaluations))
+		v.Set("fitness", float(s.Fitness))
+		v.Set("best", float(s.Best))
+		v.Set("mean", float(s.Mean))
+		v.Set("std", float(s.Std))
+		v.Set("worst", float(s.Worst))
+		v.Set("pm", float(s.PM))
+		v.Set("diversity", float(s.Diversity))
+		if d := now.Sub(start).Seconds(); d > 0 {
+			v.Set("generations_per_second", float(float64(s.Generation-gen)/d))
+		}
+		if n := s.Evaluations - evals; n > 0 {
+			v.Set("evaluation_seconds", float(now.Sub(last).Seconds()/float64(n)))
+		}
+		last, evals = now, s.Evaluations
+	})
+}
+
+func integer(x int64) *expvar.Int {
+	v := new(expvar.Int)
+	v.Set(x)
+	return v
+}
+
+func float(x float64) *expvar.Float {
+	v := new(expvar.Float)
+	v.Set(x)
+	return v
+}
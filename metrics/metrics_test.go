@@ -0,0 +1,36 @@
+package metrics_test
+
+import (
+	"expvar"
+	"testing"
+
+	"github.com/ofunc/ga"
+	"github.com/ofunc/ga/metrics"
+	"github.com/ofunc/ga/vector"
+)
+
+func TestPublish(t *testing.T) {
+	s := vector.NewSpace([]float64{-1, -1}, []float64{1, 1}, func(x []float64) float64 {
+		return -x[0]*x[0] - x[1]*x[1]
+	})
+	m := ga.New(20, s.Random)
+	v := metrics.Publish("ga_test", m)
+	m.EvolveTo(10)
+	if x := v.Get("generations").(*expvar.Int).Value(); x != 10 {
+		t.Fatal("generations:", x)
+	}
+	if x := v.Get("evaluations").(*expvar.Int).Value(); x < 200 {
+		t.Fatal("evaluations:", x)
+	}
+	if x := v.Get("best").(*expvar.Float).Value(); x != m.SnapshotStats().Best {
+		t.Fatal("best:", x)
+	}
+	for _, k := range []string{"mean", "pm", "generations_per_second", "evaluation_seconds"} {
+		if v.Get(k) == nil {
+			t.Fatal("missing:", k)
+		}
+	}
+	if expvar.Get("ga_test") != v {
+		t.Fatal("not published")
+	}
+}
@@ -0,0 +1,19 @@
+package ga
+
+// WithMinimize minimizes the fitnesses instead of maximizing them, e.g. for costs.
+// The model maximizes the negated fitnesses internally, so the adaptation of the mutation probability is unaffected,
+// and reports the true fitnesses by Fitness, the drivers, the statistics and the hall of fame.
+// It does not apply to the objectives of WithPareto.
+func WithMinimize() Option {
+	return func(m *GA) {
+		m.minimize = true
+	}
+}
+
+// objective converts between the internal fitness f, which is maximized, and the fitness reported to users.
+func (m *GA) objective(f float64) float64 {
+	if m.minimize {
+		return -f
+	}
+	return f
+}
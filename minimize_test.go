@@ -0,0 +1,39 @@
+package ga_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+// Cost is minimized at 1.
+type Cost float64
+
+func (c Cost) Fitness() float64 {
+	return sqr(float64(c) - 1)
+}
+
+func (c Cost) Mutate() ga.Entity {
+	return c + Cost(rand.Float64()-0.5)
+}
+
+func (c Cost) Crossover(e ga.Entity, w float64) ga.Entity {
+	return Cost(w*float64(c) + (1-w)*float64(e.(Cost)))
+}
+
+func TestMinimize(t *testing.T) {
+	m := ga.New(50, func() ga.Entity {
+		return Cost(20*rand.Float64() - 10)
+	}, ga.WithMinimize())
+	if s := m.SnapshotStats(); !s.Minimize || s.Best > s.Mean || s.Mean > s.Worst || s.Fitness != s.Best {
+		t.Fatal("stats:", s)
+	}
+	e, f := m.EvolveUntil(ga.Any(ga.Target(1e-4), ga.MaxGenerations(500)))
+	if f > 1e-4 || f < 0 || f != e.Fitness() || f != m.Fitness() {
+		t.Fatal("minimum:", e, f, m.Fitness())
+	}
+	if _, f, _ := m.Evolve(5, 10); f > 1e-4 || f < 0 {
+		t.Fatal("evolve:", f)
+	}
+}
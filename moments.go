@@ -0,0 +1,43 @@
+package ga
+
+// moments are the running moments of fitnesses, which can be updated by replacement in O(1).
+type moments struct {
+	n     int
+	mean  float64
+	m2    float64
+	count int
+}
+
+func (s *moments) set(n int, mean, m2 float64) {
+	*s = moments{n: n, mean: mean, m2: m2}
+}
+
+// reset computes the moments of fs exactly.
+func (s *moments) reset(fs []float64) {
+	mean, m2 := sum(fs)/float64(len(fs)), 0.0
+	for _, f := range fs {
+		m2 += (f - mean) * (f - mean)
+	}
+	s.set(len(fs), mean, m2)
+}
+
+// replace replaces the fitness x with y.
+func (s *moments) replace(x, y float64) {
+	d := y - x
+	mean := s.mean + d/float64(s.n)
+	s.m2 += d * (y - mean + x - s.mean)
+	s.mean = mean
+	s.count++
+}
+
+// stale reports whether the rounding errors of replacements should be cleared by reset.
+func (s *moments) stale() bool {
+	return s.count >= s.n
+}
+
+func (s *moments) variance() float64 {
+	if s.n == 0 || s.m2 < 0 {
+		return 0
+	}
+	return s.m2 / float64(s.n)
+}
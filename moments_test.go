@@ -0,0 +1,71 @@
+package ga
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+type point float64
+
+func (p point) Fitness() float64 {
+	return -float64(p * p)
+}
+
+func (p point) Mutate() Entity {
+	return point(rand.NormFloat64())
+}
+
+func (p point) Crossover(e Entity, w float64) Entity {
+	return point(w*float64(p) + (1-w)*float64(e.(point)))
+}
+
+func TestMomentsReplace(t *testing.T) {
+	fs := make([]float64, 100)
+	for i := range fs {
+		fs[i] = 1e6 + rand.NormFloat64()
+	}
+	var s moments
+	s.reset(fs)
+	for k := 0; k < 99; k++ {
+		i, f := rand.Intn(len(fs)), 1e6+3*rand.NormFloat64()
+		s.replace(fs[i], f)
+		fs[i] = f
+	}
+	var r moments
+	r.reset(fs)
+	if math.Abs(s.mean-r.mean) > 1e-6 || math.Abs(s.variance()-r.variance()) > 1e-6 {
+		t.Fatal("moments:", s.mean, r.mean, s.variance(), r.variance())
+	}
+}
+
+func TestReplace(t *testing.T) {
+	m := New(50, point(0).Mutate)
+	idx, es := []int{3, 7, 11}, []Entity{point(0.1), point(2), point(-3)}
+	m.replace(idx, es)
+	p := &m.pop
+	mean, std := p.Stats()
+	fsum, ws := p.fsum, append([]float64(nil), p.weights...)
+	_, best := p.Best()
+	_, worst := p.Worst()
+
+	m.adjust()
+	mean0, std0 := p.Stats()
+	if math.Abs(mean-mean0) > 1e-9 || math.Abs(std-std0) > 1e-9 || math.Abs(fsum-p.fsum) > 1e-9 {
+		t.Fatal("stats:", mean, mean0, std, std0, fsum, p.fsum)
+	}
+	for i := range ws {
+		if math.Abs(ws[i]-p.weights[i]) > 1e-9 {
+			t.Fatal("weight:", i, ws[i], p.weights[i])
+		}
+	}
+	if _, f := p.Best(); f != best {
+		t.Fatal("best:", best, f)
+	}
+	if _, f := p.Worst(); f != worst {
+		t.Fatal("worst:", worst, f)
+	}
+	if m.fitness < -0.01-1e-12 {
+		t.Fatal("elite:", m.fitness)
+	}
+}
@@ -0,0 +1,75 @@
+package ga
+
+// MultiCrossover is an entity supporting the crossover of more than two parents, see WithParents.
+type MultiCrossover interface {
+	Entity
+	// MultiCrossover produces the offspring of the parents es, the first of which is the receiver,
+	// where ws are the weights of es, which sum to 1, regardless of WithWeightConvention.
+	MultiCrossover(es []Entity, ws []float64) Entity
+}
+
+// WithParents sets the number of parents of each offspring to k, default to 2.
+// If k > 2 and the first parent implements MultiCrossover, the other parents are drawn from the whole population
+// by the selection, and are recombined by MultiCrossover instead of Crossover.
+// The weights of the parents are their selection weights, normalized.
+// With WithMatingRestriction, each other parent is reselected up to retries times until it is compatible with the first parent,
+// and is left out if it is still incompatible, so MultiCrossover may get fewer than k parents.
+func WithParents(k int) Option {
+	return func(m *GA) {
+		m.parents = k
+	}
+}
+
+// recombine crosses x, y and the other parents drawn by the selection, where w is the weight of x in the pair.
+func (m *GA) recombine(x MultiCrossover, y Entity, w float64, u func() float64) Entity {
+	p, k := &m.pop, m.parents
+	if m.convention == OtherWeight {
+		w = 1 - w
+	}
+	choose := func() int {
+		if m.pick != nil {
+			return m.pick(u)
+		}
+		return m.select1(u)
+	}
+	es, ws := make([]Entity, 2, k), make([]float64, 2, k)
+	es[0], es[1] = x, y
+	sum := 0.0
+	for j := 2; j < k; j++ {
+		c := choose()
+		if m.mating != nil {
+			for r := 0; r < m.mating.retries && !m.mating.compatible(x, p.entities[c]); r++ {
+				c = choose()
+			}
+			if !m.mating.compatible(x, p.entities[c]) {
+				continue
+			}
+		}
+		es, ws = append(es, p.entities[c]), append(ws, p.weights[c])
+		sum += p.weights[c]
+	}
+	k = len(es)
+	// The pair shares 2/k of the total, and the others share the rest by their weights.
+	for j := 2; j < k; j++ {
+		if sum > 0 {
+			ws[j] *= float64(k-2) / float64(k) / sum
+		} else {
+			ws[j] = 1 / float64(k)
+		}
+	}
+	ws[0], ws[1] = 2*w/float64(k), 2*(1-w)/float64(k)
+	return x.MultiCrossover(es, ws)
+}
+
+// select1 chooses a parent by the roulette of the selection weights.
+func (m *GA) select1(u func() float64) int {
+	p := &m.pop
+	r := u() * p.fsum
+	for i, w := range p.weights {
+		if r < w {
+			return i
+		}
+		r -= w
+	}
+	return m.n - 1
+}
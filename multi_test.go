@@ -0,0 +1,102 @@
+package ga_test
+
+import (
+	"math"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+// Genes is a genome of 16 genes in [0, 10), whose fitness is the sum of the genes.
+type Genes [16]int
+
+func (x Genes) Fitness() float64 {
+	f := 0
+	for _, g := range x {
+		f += g
+	}
+	return float64(f)
+}
+
+func (x Genes) Mutate() ga.Entity {
+	x[rand.Intn(len(x))] = rand.Intn(10)
+	return x
+}
+
+func (x Genes) Crossover(e ga.Entity, w float64) ga.Entity {
+	panic("two parents")
+}
+
+func (x Genes) MultiCrossover(es []ga.Entity, ws []float64) ga.Entity {
+	sum := 0.0
+	for _, w := range ws {
+		sum += w
+	}
+	if len(es) != 4 || es[0] != x || math.Abs(sum-1) > 1e-9 {
+		panic("parents")
+	}
+	var z Genes
+	for i := range z {
+		z[i] = es[rand.Intn(len(es))].(Genes)[i]
+	}
+	return z
+}
+
+func TestMultiCrossover(t *testing.T) {
+	m := ga.New(50, func() ga.Entity {
+		var x Genes
+		for i := range x {
+			x[i] = rand.Intn(5)
+		}
+		return x
+	}, ga.WithParents(4), ga.WithFixedMutationRate(0.5), ga.WithElitism(1))
+	if _, f, _ := m.Evolve(100, 1000); f < 130 {
+		t.Fatal("fitness:", f)
+	}
+}
+
+// Clan is a point of a tribe, which may only be recombined within its tribe.
+type Clan struct {
+	Tribe, X int
+}
+
+var mixed int64
+
+func (c Clan) Fitness() float64 {
+	return -math.Abs(float64(c.X - 50))
+}
+
+func (c Clan) Mutate() ga.Entity {
+	c.X += rand.Intn(5) - 2
+	return c
+}
+
+func (c Clan) Crossover(e ga.Entity, w float64) ga.Entity {
+	panic("two parents")
+}
+
+func (c Clan) MultiCrossover(es []ga.Entity, ws []float64) ga.Entity {
+	x := 0.0
+	for i, e := range es {
+		if e.(Clan).Tribe != c.Tribe {
+			atomic.AddInt64(&mixed, 1)
+		}
+		x += ws[i] * float64(e.(Clan).X)
+	}
+	return Clan{c.Tribe, int(math.Round(x))}
+}
+
+func TestMultiCrossoverMating(t *testing.T) {
+	atomic.StoreInt64(&mixed, 0)
+	m := ga.New(50, func() ga.Entity {
+		return Clan{rand.Intn(2), rand.Intn(100)}
+	}, ga.WithParents(4), ga.WithMatingRestriction(func(x, y ga.Entity) bool {
+		return x.(Clan).Tribe == y.(Clan).Tribe
+	}, 3))
+	m.EvolveTo(20)
+	if n := atomic.LoadInt64(&mixed); n != 0 {
+		t.Fatal("parents of different tribes:", n)
+	}
+}
@@ -0,0 +1,93 @@
+package ga
+
+// MutableEntity is an entity with in-place operators, e.g. a large genome,
+// whose offspring are written into the discarded entities of the previous generations instead of allocated.
+// The model detects it from the initial population, and the discarded entities are found as by WithRecycle.
+// The destination dst is a discarded entity of the same type, or nil if there is none,
+// and the operators return dst, or a new entity if dst is nil, but never the receiver or e.
+type MutableEntity interface {
+	Entity
+	// CloneInto copies the receiver into dst, and returns the copy.
+	CloneInto(dst Entity) Entity
+	// MutateInPlace mutates the receiver in place, which is always a copy owned by the model.
+	MutateInPlace()
+	// CrossoverInto writes the offspring of the receiver and e with the weight w into dst, and returns it.
+	CrossoverInto(dst Entity, e Entity, w float64) Entity
+}
+
+// copyOf returns a copy of e if it is a MutableEntity, which the operators may overwrite in place, or e itself.
+func copyOf(e Entity) Entity {
+	if x, ok := e.(MutableEntity); ok {
+		return x.CloneInto(nil)
+	}
+	return e
+}
+
+// spareAt returns the spare entity for the slot i, or nil.
+func (m *GA) spareAt(i int) Entity {
+	if s := m.recycler.spare; i < len(s) {
+		return s[i]
+	}
+	return nil
+}
+
+// crossover crosses x and y with the weight w for the slot i, and reports whether the offspring is a new copy.
+func (m *GA) crossover(i int, x, y Entity, w float64, u func() float64) (Entity, bool) {
+	if m.ops != nil && len(m.ops.cs) > 0 {
+		return m.ops.crossover(i, x, y, w, u), false
+	}
+	if lx, ok := x.(MaskedCrossover); ok && m.linkage != nil {
+		if m.convention == OtherWeight {
+			w = 1 - w
+		}
+		return lx.MaskedCrossover(y, m.linkage.mask(w, u)), false
+	}
+	if mx, ok := x.(MultiCrossover); ok && m.parents > 2 {
+		return m.recombine(mx, y, w, u), false
+	}
+	if cx, ok := x.(ContextCrossover); ok {
+		return cx.CrossoverCtx(m.context(u), y, w), false
+	}
+	if cx, ok := x.(CheckedEntity); ok {
+		z, err := cx.TryCrossover(y, w)
+		if err != nil {
+			m.fail("crossover", err)
+			return x, false
+		}
+		return z, false
+	}
+	if mx, ok := x.(MutableEntity); ok {
+		return mx.CrossoverInto(m.spareAt(i), y, w), true
+	}
+	if wx, ok := x.(WeightlessCrossover); ok {
+		return wx.Crossover2(y), false
+	}
+	return x.Crossover(y, w), false
+}
+
+// mutate mutates z for the slot i, in place if z is a new copy.
+func (m *GA) mutate(i int, z Entity, fresh bool, u func() float64) Entity {
+	if m.ops != nil && len(m.ops.ms) > 0 {
+		return m.ops.mutate(i, z, u)
+	}
+	if cz, ok := z.(ContextMutator); ok {
+		return cz.MutateCtx(m.context(u))
+	}
+	if cz, ok := z.(CheckedEntity); ok {
+		e, err := cz.TryMutate()
+		if err != nil {
+			m.fail("mutate", err)
+			return z
+		}
+		return e
+	}
+	mz, ok := z.(MutableEntity)
+	if !ok {
+		return z.Mutate()
+	}
+	if !fresh {
+		mz = mz.CloneInto(m.spareAt(i)).(MutableEntity)
+	}
+	mz.MutateInPlace()
+	return mz
+}
@@ -0,0 +1,40 @@
+package ga
+
+import "math"
+
+// WithFitnessSharing maintains multiple niches by fitness sharing with the distance dist between entities.
+// The selection weight of each entity is divided by its niche count Σ sh(d),
+// where sh(d) = 1 - (d/sigma)^alpha for d < sigma, and 0 otherwise,
+// so crowded peaks lose selection pressure to the isolated ones.
+// It costs n² distances every generation.
+func WithFitnessSharing(dist func(x, y Entity) float64, sigma, alpha float64) Option {
+	return func(m *GA) {
+		m.niche = &sharing{dist, sigma, alpha}
+	}
+}
+
+type sharing struct {
+	dist  func(x, y Entity) float64
+	sigma float64
+	alpha float64
+}
+
+// apply divides the selection weights of p by the niche counts.
+func (s *sharing) apply(p *Population) {
+	n := len(p.entities)
+	counts := make([]float64, n)
+	p.parallel(n, func(c, i int) {
+		for j := 0; j < n; j++ {
+			if d := s.dist(p.entities[i], p.entities[j]); d < s.sigma {
+				counts[i] += 1 - math.Pow(d/s.sigma, s.alpha)
+			}
+		}
+	})
+	p.fsum = 0
+	for i, c := range counts {
+		if c > 0 {
+			p.weights[i] /= c
+		}
+		p.fsum += p.weights[i]
+	}
+}
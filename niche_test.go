@@ -0,0 +1,48 @@
+package ga_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+// Twin has two equal peaks at -2 and 2.
+type Twin float64
+
+func (x Twin) Fitness() float64 {
+	return -math.Min(sqr(float64(x)-2), sqr(float64(x)+2))
+}
+
+func (x Twin) Mutate() ga.Entity {
+	return x + Twin(rand.NormFloat64()/10)
+}
+
+func (x Twin) Crossover(e ga.Entity, w float64) ga.Entity {
+	if rand.Intn(2) == 0 {
+		return x
+	}
+	return e
+}
+
+func TestFitnessSharing(t *testing.T) {
+	dist := func(x, y ga.Entity) float64 {
+		return math.Abs(float64(x.(Twin) - y.(Twin)))
+	}
+	m := ga.New(100, func() ga.Entity {
+		return Twin(8*rand.Float64() - 4)
+	}, ga.WithFitnessSharing(dist, 1, 1))
+	left, right := 0, 0
+	for _, e := range m.EvolveTo(50) {
+		switch x := float64(e.(Twin)); {
+		case math.Abs(x+2) < 0.5:
+			left++
+		case math.Abs(x-2) < 0.5:
+			right++
+		}
+	}
+	if left < 20 || right < 20 {
+		t.Fatal("niches:", left, right)
+	}
+}
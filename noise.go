@@ -0,0 +1,81 @@
+package ga
+
+import (
+	"context"
+	"math"
+)
+
+// WithNoisyFitness handles stochastic fitnesses: every evaluation averages samples calls of Fitness,
+// and the elite is re-evaluated every generation, so its fitness is the running mean of all its evaluations,
+// which may decrease, instead of a single lucky evaluation locking in a bogus elite.
+// The elite is tracked by its identity, see WithHallOfFame, and a challenger replaces it only if the mean of
+// its first two evaluations is higher than the running mean of the elite, re-evaluated for the comparison.
+// Evolve and EvolveContext return the running mean of the final elite.
+// The fitness cache must not be used together, since it would return the same sample.
+func WithNoisyFitness(samples int) Option {
+	return func(m *GA) {
+		if samples < 1 {
+			samples = 1
+		}
+		m.noise = &noise{samples: samples}
+	}
+}
+
+// EliteEstimate returns the running mean of the fitness of the elite, the half width of its 95% confidence interval,
+// and the number of evaluations, by WithNoisyFitness. Without it, the half width is 0 and n is 1.
+func (m *GA) EliteEstimate() (mean, half float64, n int) {
+	x := m.noise
+	if x == nil || x.n == 0 {
+		return m.objective(m.fitness), 0, 1
+	}
+	if x.n > 1 {
+		v := math.Max(0, (x.sum2-x.sum*x.sum/float64(x.n))/float64(x.n-1))
+		half = 1.96 * math.Sqrt(v/float64(x.n))
+	}
+	return m.objective(x.sum / float64(x.n)), half, x.n * x.samples
+}
+
+type noise struct {
+	samples   int
+	id        interface{}
+	elite     Entity
+	n         int
+	sum, sum2 float64
+}
+
+// sample returns the mean of the samples evaluations of e.
+func (x *noise) sample(ctx context.Context, m *GA, e Entity) float64 {
+	f := 0.0
+	for i := 0; i < x.samples; i++ {
+		f += m.measure(ctx, e)
+	}
+	return f / float64(x.samples)
+}
+
+// resample re-evaluates the elite, and sets its fitness to the running mean.
+// A new elite is re-evaluated along with the previous one, and replaces it only if its mean is higher.
+func (m *GA) resample() {
+	x := m.noise
+	if m.elite == nil {
+		return
+	}
+	if id := identity(m.elite); id == nil || id != x.id {
+		f := m.eval(m.elite)
+		y := noise{id: id, elite: m.elite, n: 2, sum: m.fitness + f, sum2: m.fitness*m.fitness + f*f}
+		if x.elite != nil {
+			x.add(m.eval(x.elite))
+			if x.sum/float64(x.n) >= y.sum/float64(y.n) {
+				m.elite, m.fitness = x.elite, x.sum/float64(x.n)
+				return
+			}
+		}
+		x.id, x.elite, x.n, x.sum, x.sum2 = y.id, y.elite, y.n, y.sum, y.sum2
+	} else {
+		x.add(m.eval(m.elite))
+	}
+	m.fitness = x.sum / float64(x.n)
+}
+
+func (x *noise) add(f float64) {
+	x.n, x.sum, x.sum2 = x.n+1, x.sum+f, x.sum2+f*f
+}
@@ -0,0 +1,42 @@
+package ga_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+// Noisy is Walk with the standard normal noise.
+type Noisy float64
+
+func (x Noisy) Fitness() float64 {
+	return Walk(x).Fitness() + rand.NormFloat64()
+}
+
+func (x Noisy) Mutate() ga.Entity {
+	return Noisy(Walk(x).Mutate().(Walk))
+}
+
+func (x Noisy) Crossover(e ga.Entity, w float64) ga.Entity {
+	return Noisy(Walk(x).Crossover(Walk(e.(Noisy)), w).(Walk))
+}
+
+func TestNoisyFitness(t *testing.T) {
+	g := func() ga.Entity {
+		return Noisy(4*rand.Float64() - 2)
+	}
+	m := ga.New(50, g, ga.WithNoisyFitness(4))
+	m.EvolveTo(50)
+	mean, half, n := m.EliteEstimate()
+	bias := mean - Walk(m.Elite().(Noisy)).Fitness()
+	if mean != m.Fitness() || n < 8 || half <= 0 {
+		t.Fatal("estimate:", mean, half, n)
+	}
+	if bias > 1.5 {
+		t.Fatal("bias:", bias)
+	}
+	if _, f, _ := m.Evolve(3, 5); f != m.Fitness() {
+		t.Fatal("evolve:", f, m.Fitness())
+	}
+}
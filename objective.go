@@ -0,0 +1,24 @@
+package ga
+
+// Objective is an optional interface of Entity, which reports its raw objective, i.e. the true metric of the problem,
+// separately from its fitness, which may be shaped for the selection, e.g. by penalties, bonuses or normalization.
+// The model selects by the fitness as usual, and reports the raw objective of the elite by GA.Objective,
+// the statistics and the sinks, so the true metric is not conflated with the selection fitness in logs.
+type Objective interface {
+	Objective() float64
+}
+
+// Objective returns the raw objective of the elite, which is neither penalized by WithPenalty nor negated by WithMinimize.
+// If the elite does not implement Objective, it is the same as Fitness.
+// It is safe to call concurrently with Next, and is of the last completed generation then, as Fitness.
+func (m *GA) Objective() float64 {
+	return m.SnapshotStats().Objective
+}
+
+// rawObjective returns the raw objective of the current elite.
+func (m *GA) rawObjective() float64 {
+	if o, ok := m.elite.(Objective); ok {
+		return o.Objective()
+	}
+	return m.objective(m.fitness)
+}
@@ -0,0 +1,76 @@
+package ga_test
+
+import (
+	"bytes"
+	"math"
+	"math/rand/v2"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+// Shaped is a Walk whose fitness is shaped by a bonus, and its objective is the distance to 1.
+type Shaped struct {
+	Walk
+}
+
+func (x Shaped) Fitness() float64 {
+	return 10 + x.Walk.Fitness()
+}
+
+func (x Shaped) Objective() float64 {
+	return -x.Walk.Fitness()
+}
+
+func (x Shaped) Mutate() ga.Entity {
+	return Shaped{x.Walk.Mutate().(Walk)}
+}
+
+func (x Shaped) Crossover(e ga.Entity, w float64) ga.Entity {
+	return Shaped{x.Walk.Crossover(e.(Shaped).Walk, w).(Walk)}
+}
+
+func TestObjective(t *testing.T) {
+	var b bytes.Buffer
+	m := ga.New(20, func() ga.Entity {
+		return Shaped{Walk(rand.Float64()*4 - 2)}
+	}, ga.WithSink(ga.NewLineProtocol(&b, "shaped")))
+	m.Evolve(20, 200)
+	if o, f := m.Objective(), m.Fitness(); o < 0 || math.Abs(o+f-10) > 1e-9 || m.Stats().Objective != o {
+		t.Fatal("objective:", o, f)
+	}
+	if !strings.Contains(b.String(), ",objective=") {
+		t.Fatal("scalars:", b.String())
+	}
+	if m := ga.New(5, MIN{}.Mutate); m.Objective() != m.Fitness() {
+		t.Fatal("fallback:", m.Objective(), m.Fitness())
+	}
+}
+
+func TestObjectiveConcurrent(t *testing.T) {
+	m := ga.New(20, func() ga.Entity {
+		return Shaped{Walk(rand.Float64()*4 - 2)}
+	})
+	stop, done := make(chan bool), make(chan bool)
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			if o := m.Objective(); math.IsNaN(o) {
+				t.Error("objective:", o)
+			}
+		}
+	}()
+	for i := 0; i < 20; i++ {
+		m.Next()
+		runtime.Gosched()
+	}
+	close(stop)
+	<-done
+}
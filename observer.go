@@ -0,0 +1,33 @@
+package ga
+
+// OnGeneration registers the observer f, which is called after every generation produced by Next,
+// and so by Evolve and the other drivers, with the generation, the elite, its fitness, and the statistics.
+// The observers are called in the order of registration, on the goroutine driving the model.
+func (m *GA) OnGeneration(f func(gen int, elite Entity, fitness float64, stats Stats)) {
+	m.observers = append(m.observers, f)
+}
+
+func (m *GA) notify() {
+	if len(m.observers) == 0 {
+		return
+	}
+	s := m.SnapshotStats()
+	for _, f := range m.observers {
+		f(m.gen, m.elite, s.Fitness, s)
+	}
+}
+
+// Progress returns a channel receiving the statistics of every generation produced after the call,
+// for consumers on other goroutines such as dashboards.
+// The sends never block the model: an update is dropped if the channel buffer is full.
+// The channel is never closed.
+func (m *GA) Progress() <-chan Stats {
+	ch := make(chan Stats, 16)
+	m.OnGeneration(func(gen int, elite Entity, fitness float64, s Stats) {
+		select {
+		case ch <- s:
+		default:
+		}
+	})
+	return ch
+}
@@ -0,0 +1,56 @@
+package ga_test
+
+import (
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestOnGeneration(t *testing.T) {
+	m := ga.New(20, MIN{}.Mutate)
+	var gens []int
+	calls := 0
+	m.OnGeneration(func(gen int, elite ga.Entity, fitness float64, s ga.Stats) {
+		gens = append(gens, gen)
+		if elite != m.Elite() || fitness != m.Fitness() || s.Generation != gen {
+			t.Error("observer:", gen, fitness, s)
+		}
+	})
+	m.OnGeneration(func(int, ga.Entity, float64, ga.Stats) {
+		if calls++; calls != len(gens) {
+			t.Error("order:", calls, len(gens))
+		}
+	})
+	m.Next()
+	m.Evolve(3, 5)
+	if len(gens) < 4 || gens[0] != 1 || calls != len(gens) {
+		t.Fatal("generations:", gens, calls)
+	}
+	for i, g := range gens {
+		if g != i+1 {
+			t.Fatal("generations:", gens)
+		}
+	}
+}
+
+func TestProgress(t *testing.T) {
+	m := ga.New(20, MIN{}.Mutate)
+	ch := m.Progress()
+	done := make(chan int)
+	go func() {
+		n := 0
+		for s := range ch {
+			if n++; s.Generation != n {
+				t.Error("update:", n, s)
+			}
+			if s.Generation == 5 {
+				break
+			}
+		}
+		done <- n
+	}()
+	m.EvolveTo(5)
+	if n := <-done; n != 5 {
+		t.Fatal("updates:", n)
+	}
+}
@@ -0,0 +1,118 @@
+package ga
+
+// WithAdaptiveOperators registers the crossover and mutation operators, used instead of Crossover and Mutate,
+// and allocates the trials among them adaptively by probability matching.
+// The reward of an offspring is how much its fitness exceeds the mean fitness of its parents' generation,
+// the quality of an operator is the exponential moving average of its mean rewards with the rate 0.3,
+// and each operator is chosen with a probability of at least 0.2/K, where K is the number of the operators,
+// and the rest in proportion to the qualities.
+// Either slice may be empty, then the method of the entities is used.
+// The rewards are credited in the default generational replacement only.
+func WithAdaptiveOperators(crossovers []func(x, y Entity, w float64) Entity, mutations []func(Entity) Entity) Option {
+	return func(m *GA) {
+		m.ops = &operators{
+			cs: crossovers, ms: mutations,
+			cq: make([]float64, len(crossovers)), mq: make([]float64, len(mutations)),
+			cp: uniform(len(crossovers)), mp: uniform(len(mutations)),
+		}
+	}
+}
+
+// OperatorProbabilities returns the current probabilities of the operators registered by WithAdaptiveOperators.
+func (m *GA) OperatorProbabilities() (crossovers, mutations []float64) {
+	if m.ops == nil {
+		return nil, nil
+	}
+	return append([]float64(nil), m.ops.cp...), append([]float64(nil), m.ops.mp...)
+}
+
+type operators struct {
+	cs     []func(x, y Entity, w float64) Entity
+	ms     []func(Entity) Entity
+	cq, mq []float64
+	cp, mp []float64
+	ci, mi []int
+	mean   float64
+}
+
+// prepare resets the operators of the slots before the variation of p.
+func (o *operators) prepare(p *Population) {
+	n := len(p.entities)
+	if len(o.ci) != n {
+		o.ci, o.mi = make([]int, n), make([]int, n)
+	}
+	for i := range o.ci {
+		o.ci[i], o.mi[i] = -1, -1
+	}
+	o.mean, _ = p.Stats()
+}
+
+func (o *operators) crossover(i int, x, y Entity, w float64, u func() float64) Entity {
+	k := choose(o.cp, u())
+	o.ci[i] = k
+	return o.cs[k](x, y, w)
+}
+
+func (o *operators) mutate(i int, z Entity, u func() float64) Entity {
+	k := choose(o.mp, u())
+	o.mi[i] = k
+	return o.ms[k](z)
+}
+
+// credit credits the rewards of the offspring in p to their operators, and updates the probabilities.
+func (o *operators) credit(p *Population) {
+	if len(o.ci) != len(p.fitnesses) {
+		return
+	}
+	update(o.cq, o.cp, o.ci, p.fitnesses, o.mean)
+	update(o.mq, o.mp, o.mi, p.fitnesses, o.mean)
+}
+
+// update updates the qualities qs and the probabilities ps by the rewards of the slots, whose operators are ks.
+func update(qs, ps []float64, ks []int, fs []float64, mean float64) {
+	if len(qs) == 0 {
+		return
+	}
+	rs, ns := make([]float64, len(qs)), make([]int, len(qs))
+	for i, k := range ks {
+		if k >= 0 && fs[i] > mean {
+			rs[k] += fs[i] - mean
+		}
+		if k >= 0 {
+			ns[k]++
+		}
+	}
+	total := 0.0
+	for k := range qs {
+		if ns[k] > 0 {
+			qs[k] += 0.3 * (rs[k]/float64(ns[k]) - qs[k])
+		}
+		total += qs[k]
+	}
+	pmin := 0.2 / float64(len(qs))
+	for k := range ps {
+		if total > 0 {
+			ps[k] = pmin + (1-pmin*float64(len(qs)))*qs[k]/total
+		} else {
+			ps[k] = 1 / float64(len(qs))
+		}
+	}
+}
+
+// choose chooses an index by the probabilities ps, with the uniform random number r.
+func choose(ps []float64, r float64) int {
+	for k, p := range ps {
+		if r -= p; r < 0 {
+			return k
+		}
+	}
+	return len(ps) - 1
+}
+
+func uniform(n int) []float64 {
+	ps := make([]float64, n)
+	for k := range ps {
+		ps[k] = 1 / float64(n)
+	}
+	return ps
+}
@@ -0,0 +1,35 @@
+package ga_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestAdaptiveOperators(t *testing.T) {
+	good := func(e ga.Entity) ga.Entity {
+		w := e.(Walk)
+		return w + (1-w)*Walk(rand.Float64())
+	}
+	bad := func(e ga.Entity) ga.Entity {
+		return e.(Walk) - 10
+	}
+	m := ga.New(50, func() ga.Entity {
+		return Walk(10 * rand.Float64())
+	}, ga.WithAdaptiveOperators(nil, []func(ga.Entity) ga.Entity{bad, good}), ga.WithFixedMutationRate(1))
+	if cs, ms := m.OperatorProbabilities(); len(cs) != 0 || len(ms) != 2 || ms[0] != 0.5 {
+		t.Fatal("initial:", cs, ms)
+	}
+	m.EvolveTo(20)
+	_, ms := m.OperatorProbabilities()
+	if ms[1] < 0.8 || ms[0] < 0.1-1e-9 {
+		t.Fatal("probabilities:", ms)
+	}
+	if f := m.Fitness(); f < -1e-3 {
+		t.Fatal("fitness:", f)
+	}
+	if cs, ms := ga.New(10, MIN{}.Mutate).OperatorProbabilities(); cs != nil || ms != nil {
+		t.Fatal("without operators:", cs, ms)
+	}
+}
@@ -0,0 +1,125 @@
+package ga
+
+import (
+	"math"
+	"math/rand/v2"
+)
+
+// Option is an option of GA model.
+type Option func(*GA)
+
+// WithSeed sets the seed of the random source.
+// By default, the seed is derived from the current time, and is distinct for each GA model.
+func WithSeed(seed int64) Option {
+	return func(m *GA) {
+		m.seed = seed
+	}
+}
+
+// WithSource sets the random source of the model, e.g. rand.NewChaCha8, instead of the PCG seeded by the seed.
+// The source is not safe for concurrent use, so it must not be shared between models.
+// Its state is saved by Save only if it implements encoding.BinaryMarshaler and encoding.BinaryUnmarshaler.
+func WithSource(src rand.Source) Option {
+	return func(m *GA) {
+		m.src = src
+	}
+}
+
+// WithDiversityMetric sets the diversity metric, which is called on the population each generation.
+// The population passed to the metric must not be retained or modified.
+func WithDiversityMetric(f func(pop []Entity) float64) Option {
+	return func(m *GA) {
+		m.metric = f
+	}
+}
+
+// WithSequentialEval evaluates the fitnesses in index order on a single goroutine,
+// while the variation is still concurrent.
+// It is for fitness functions with ordered side effects, e.g. evaluation logs which should be reproducible,
+// at the cost of losing all the parallelism of evaluation, which is usually the bottleneck.
+func WithSequentialEval() Option {
+	return func(m *GA) {
+		m.pop.sequential = true
+	}
+}
+
+// WithMaxSelectionShare caps the selection share of any single entity to frac,
+// and redistributes the excess proportionally among the others,
+// which prevents a super individual from dominating the reproduction.
+// It is applied after the sigmoid scaling, and frac is at least 1/n.
+func WithMaxSelectionShare(frac float64) Option {
+	return func(m *GA) {
+		m.share = frac
+	}
+}
+
+// WithElitism copies the k fittest entities unchanged into the next generation,
+// and the others are produced by selection, crossover and mutation as usual.
+// By default, the elite is only remembered, and the population may drift away from it.
+func WithElitism(k int) Option {
+	return func(m *GA) {
+		m.elitism = k
+	}
+}
+
+// WithCrossoverRate sets the probability of crossover, default to 1.
+// Without crossover, the offspring is a copy of the first parent, which may be mutated then.
+func WithCrossoverRate(p float64) Option {
+	return func(m *GA) {
+		m.pc = p
+	}
+}
+
+// WithMutationBounds sets the bounds of the adaptive mutation probability, default to [0.0001, 0.1].
+// The initial mutation probability 0.1 is clamped into the bounds.
+func WithMutationBounds(min, max float64) Option {
+	return func(m *GA) {
+		m.pmin, m.pmax = min, max
+		m.pm = math.Max(min, math.Min(max, m.pm))
+	}
+}
+
+// WithFixedMutationRate fixes the mutation probability to p, instead of adapting it.
+func WithFixedMutationRate(p float64) Option {
+	return func(m *GA) {
+		m.pm, m.fixed = p, true
+	}
+}
+
+// WithSeedSolution builds the initial population from the seed solution e, instead of the generator.
+// The first entity is e itself, and each of the other n-1 entities is e mutated spread times,
+// so the population explores the neighborhood of e, and its size does not depend on spread.
+// Mutate should perturb its receiver for this to be meaningful.
+func WithSeedSolution(e Entity, spread int) Option {
+	return func(m *GA) {
+		m.solution, m.spread = e, spread
+	}
+}
+
+// WithSecondaryGenerator sets a secondary generator g2, e.g. a greedy heuristic,
+// which generates the first round(frac*n) entities of the initial population,
+// and the primary generator generates the rest.
+// The rounding is half away from zero, and frac is clamped to [0, 1].
+func WithSecondaryGenerator(frac float64, g2 func() Entity) Option {
+	return func(m *GA) {
+		m.frac, m.g2 = frac, g2
+	}
+}
+
+// WeightConvention is the convention of the weight passed to Crossover.
+type WeightConvention int
+
+const (
+	// SelfWeight means the weight is of the receiver, the default.
+	SelfWeight WeightConvention = iota
+	// OtherWeight means the weight is of the argument.
+	OtherWeight
+)
+
+// WithWeightConvention declares which parent the weight passed to Crossover refers to.
+// The weight is the relative selection weight of that parent, so offspring lean towards the fitter parent.
+func WithWeightConvention(c WeightConvention) Option {
+	return func(m *GA) {
+		m.convention = c
+	}
+}
@@ -0,0 +1,60 @@
+package ga
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// oracle is the state of WithFitnessOracle.
+// The table is indexed once, so the lookups are lock-free, and the queried genotypes are flagged by their indices,
+// which bounds the state by the size of the table however long the model runs.
+type oracle struct {
+	table   map[string]int
+	fs      []float64
+	seen    []uint32
+	key     func(Entity) string
+	queried int64
+	missing int64
+}
+
+// WithFitnessOracle looks up the fitnesses in the precomputed table by key, instead of calling Fitness,
+// e.g. to study the search dynamics on tabulated landscapes.
+// If a key is missing, Fitness is called instead, and the first missing key is reported by Err.
+// The lookups are not counted as evaluations.
+func WithFitnessOracle(table map[string]float64, key func(Entity) string) Option {
+	return func(m *GA) {
+		o := &oracle{table: make(map[string]int, len(table)), fs: make([]float64, 0, len(table)), key: key}
+		for k, f := range table {
+			o.table[k] = len(o.fs)
+			o.fs = append(o.fs, f)
+		}
+		o.seen = make([]uint32, len(o.fs))
+		m.oracle = o
+	}
+}
+
+// OracleQueries returns the number of unique genotypes of the table queried from the fitness oracle,
+// and the number of the lookups of the keys missing in its table.
+func (m *GA) OracleQueries() (queried, missing int) {
+	if m.oracle == nil {
+		return 0, 0
+	}
+	return int(atomic.LoadInt64(&m.oracle.queried)), int(atomic.LoadInt64(&m.oracle.missing))
+}
+
+// lookup returns the fitness of e by the oracle.
+func (m *GA) lookup(e Entity) float64 {
+	o := m.oracle
+	k := o.key(e)
+	i, ok := o.table[k]
+	if !ok {
+		if atomic.AddInt64(&o.missing, 1) == 1 {
+			m.fail("oracle", fmt.Errorf("ga: fitness oracle misses key %q", k))
+		}
+		return fitness(e)
+	}
+	if atomic.LoadUint32(&o.seen[i]) == 0 && atomic.CompareAndSwapUint32(&o.seen[i], 0, 1) {
+		atomic.AddInt64(&o.queried, 1)
+	}
+	return o.fs[i]
+}
@@ -0,0 +1,45 @@
+package ga_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestFitnessOracle(t *testing.T) {
+	table := make(map[string]float64)
+	for x := 0; x < 10; x++ {
+		for y := 0; y < 10; y++ {
+			k := ILP{float64(x), float64(y)}
+			table[Keyed{k}.Key()] = k.Fitness()
+		}
+	}
+	m := ga.New(20, ILP{}.Mutate, ga.WithFitnessOracle(table, func(e ga.Entity) string {
+		return Keyed{e.(ILP)}.Key()
+	}))
+	_, f, _ := m.Evolve(30, 100)
+	if f < 35 {
+		t.Fatal("fitness(40):", f)
+	}
+	queried, missing := m.OracleQueries()
+	if queried == 0 || queried > 100 || missing != 0 {
+		t.Fatal("queries:", queried, missing)
+	}
+	if s := m.SnapshotStats(); s.Evaluations != 0 {
+		t.Fatal("evaluations:", s.Evaluations)
+	}
+}
+
+func TestFitnessOracleMissing(t *testing.T) {
+	m := ga.New(20, ILP{}.Mutate, ga.WithFitnessOracle(map[string]float64{"": 0}, func(e ga.Entity) string {
+		return Keyed{e.(ILP)}.Key()
+	}))
+	m.Next()
+	if queried, missing := m.OracleQueries(); queried != 0 || missing < 20 {
+		t.Fatal("queries:", queried, missing)
+	}
+	if err := m.Err(); err == nil || !strings.Contains(err.Error(), "misses key") {
+		t.Fatal("error:", err)
+	}
+}
@@ -0,0 +1,140 @@
+package ga
+
+import (
+	"fmt"
+	"math"
+	"sync"
+)
+
+// params are the names of the parameters which can be tuned by SetParam.
+var params = [...]string{"pm", "pmin", "pmax", "pc", "pressure", "elitism"}
+
+// tuning is the parameters set by SetParam and not applied yet,
+// and the parameters of the last completed generation.
+type tuning struct {
+	mutex   sync.Mutex
+	pending map[string]float64
+	current [len(params)]float64
+}
+
+// SetParam sets the parameter of the name to value, e.g. to nudge a long-running model without restarting it.
+// The names are:
+//
+//	pm: the mutation probability, clamped into the bounds unless it is fixed, see WithFixedMutationRate
+//	pmin, pmax: the bounds of the adaptive mutation probability, see WithMutationBounds
+//	pc: the crossover probability, see WithCrossoverRate
+//	pressure: the exponent of the selection weights, see Schedule
+//	elitism: the number of the elites copied into the next generation, see WithElitism
+//
+// The bounds pmin and pmax are checked against each other, as set by the calls before, so a pmin above pmax is rejected.
+// It is safe to call concurrently with Next, and takes effect from the next generation,
+// where the pressure applies to the selection weights computed after it.
+// The parameters controlled by WithSchedule are overridden by the schedule.
+func (m *GA) SetParam(name string, value float64) error {
+	if err := checkParam(name, value); err != nil {
+		return err
+	}
+	t := &m.tuning
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	switch name {
+	case "pmin":
+		if pmax := t.get("pmax"); value > pmax {
+			return fmt.Errorf("ga: invalid parameter pmin: %v above pmax %v", value, pmax)
+		}
+	case "pmax":
+		if pmin := t.get("pmin"); value < pmin {
+			return fmt.Errorf("ga: invalid parameter pmax: %v below pmin %v", value, pmin)
+		}
+	}
+	if t.pending == nil {
+		t.pending = make(map[string]float64)
+	}
+	t.pending[name] = value
+	return nil
+}
+
+// Param returns the parameter of the name, see SetParam.
+// It is safe to call concurrently with Next, and is of the last completed generation then,
+// or the value set by SetParam if it is not applied yet.
+func (m *GA) Param(name string) (float64, error) {
+	t := &m.tuning
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if v := t.get(name); !math.IsNaN(v) {
+		return v, nil
+	}
+	return 0, fmt.Errorf("ga: unknown parameter %q", name)
+}
+
+// get returns the pending or the current parameter of the name, or NaN if it is unknown, with the mutex locked.
+func (t *tuning) get(name string) float64 {
+	if v, ok := t.pending[name]; ok {
+		return v
+	}
+	for i, p := range params {
+		if p == name {
+			return t.current[i]
+		}
+	}
+	return math.NaN()
+}
+
+func checkParam(name string, value float64) error {
+	var ok bool
+	switch name {
+	case "pm", "pmin", "pmax", "pc":
+		ok = value >= 0 && value <= 1
+	case "pressure":
+		ok = value >= 0 && !math.IsInf(value, 1)
+	case "elitism":
+		ok = value >= 0 && value == math.Trunc(value) && !math.IsInf(value, 1)
+	default:
+		return fmt.Errorf("ga: unknown parameter %q", name)
+	}
+	if !ok {
+		return fmt.Errorf("ga: invalid parameter %s: %v", name, value)
+	}
+	return nil
+}
+
+// tune applies the parameters set by SetParam, before breeding the next generation.
+func (m *GA) tune() {
+	t := &m.tuning
+	t.mutex.Lock()
+	pending := t.pending
+	t.pending = nil
+	t.mutex.Unlock()
+	if len(pending) == 0 {
+		return
+	}
+	clamp := false
+	for name, v := range pending {
+		switch name {
+		case "pm":
+			m.pm, clamp = v, true
+		case "pmin":
+			m.pmin, clamp = v, true
+		case "pmax":
+			m.pmax, clamp = v, true
+		case "pc":
+			m.pc = v
+		case "pressure":
+			m.pressure = v
+		case "elitism":
+			m.elitism = int(v)
+		}
+	}
+	if clamp && !m.fixed {
+		m.pm = math.Max(m.pmin, math.Min(m.pmax, m.pm))
+	}
+	m.capture()
+}
+
+// capture makes the parameters of the current generation visible to Param.
+func (m *GA) capture() {
+	t := &m.tuning
+	t.mutex.Lock()
+	t.current = [len(params)]float64{m.pm, m.pmin, m.pmax, m.pc, m.pressure, float64(m.elitism)}
+	t.mutex.Unlock()
+}
@@ -0,0 +1,64 @@
+package ga_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestSetParam(t *testing.T) {
+	m := ga.New(30, MIN{}.Mutate)
+	if v, err := m.Param("elitism"); err != nil || v != 0 {
+		t.Fatal("elitism:", v, err)
+	}
+	if err := m.SetParam("elitism", 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.SetParam("pmax", 0.01); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := m.Param("elitism"); v != 3 {
+		t.Fatal("pending:", v)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			m.Param("pm")
+			m.SetParam("pc", 0.9)
+		}
+	}()
+	for i := 0; i < 10; i++ {
+		best := m.Elite()
+		found := false
+		for _, e := range m.EvolveTo(m.Stats().Generation + 1) {
+			found = found || e == best
+		}
+		if i > 0 && !found {
+			t.Fatal("elite lost:", i, best)
+		}
+	}
+	wg.Wait()
+	m.Next()
+	if v, _ := m.Param("pm"); v > 0.01 {
+		t.Fatal("pm:", v)
+	}
+	if v, _ := m.Param("pc"); v != 0.9 {
+		t.Fatal("pc:", v)
+	}
+
+	if _, err := m.Param("unknown"); err == nil {
+		t.Fatal("unknown parameter")
+	}
+	for _, c := range []struct {
+		name  string
+		value float64
+	}{{"unknown", 0}, {"pm", 2}, {"pressure", -1}, {"elitism", 1.5}, {"pmin", 0.5}, {"pmax", 0}} {
+		if err := m.SetParam(c.name, c.value); err == nil {
+			t.Fatal("invalid parameter:", c)
+		}
+	}
+}
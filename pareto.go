@@ -0,0 +1,194 @@
+package ga
+
+import (
+	"math"
+	"sort"
+	"sync/atomic"
+)
+
+// MultiObjective is an entity with several objectives, which are all maximized.
+type MultiObjective interface {
+	Entity
+	// Objectives returns the values of the objectives, which must have the same length for all entities.
+	Objectives() []float64
+}
+
+// pareto is the state of the Pareto mode.
+type pareto struct {
+	objectives [][]float64
+	front      []Entity
+}
+
+// WithPareto switches the GA model to the multi-objective Pareto mode in the style of NSGA-II,
+// where the entities must implement MultiObjective, and Fitness is not used.
+// Each generation, the parents and offspring are pooled, sorted into non-dominated fronts,
+// and the next generation is filled front by front, preferring the less crowded entities of the last front.
+// The fitness used for selection is -rank+c, where rank is the index of the front of the entity,
+// and c in [0, 1) increases with its crowding distance.
+// So the elite is the most isolated entity of the first front, e.g. an extreme of one of the objectives.
+// WithSelector(TournamentSelector(2)) gives the binary tournament of NSGA-II.
+func WithPareto() Option {
+	return func(m *GA) {
+		m.mo = &pareto{}
+	}
+}
+
+// ParetoFront returns the non-dominated entities of the current population, or nil if not in the Pareto mode.
+func (m *GA) ParetoFront() []Entity {
+	if m.mo == nil {
+		return nil
+	}
+	es := make([]Entity, len(m.mo.front))
+	copy(es, m.mo.front)
+	return es
+}
+
+// objectives evaluates the objectives of es.
+func (m *GA) objectives(es []Entity) [][]float64 {
+	os := make([][]float64, len(es))
+	f := func(c, i int) {
+		atomic.AddInt64(&m.evals, 1)
+		os[i] = es[i].(MultiObjective).Objectives()
+		if m.parchive != nil {
+			m.parchive.Add(es[i], os[i])
+		}
+	}
+	m.pop.run(len(es), f)
+	return os
+}
+
+// rank evaluates the population in the Pareto mode, and scores it into the fitnesses.
+func (m *GA) rank() {
+	m.mo.objectives = m.objectives(m.pop.entities)
+	m.score()
+}
+
+// score scores the population by the fronts and the crowding distances,
+// and resets the elite, since the scores of different generations are not comparable.
+func (m *GA) score() {
+	p, os := &m.pop, m.mo.objectives
+	fronts := fronts(os)
+	for r, front := range fronts {
+		for j, d := range crowding(os, front) {
+			c := math.Nextafter(1, 0)
+			if !math.IsInf(d, 1) {
+				c = math.Min(d/(1+d), c)
+			}
+			p.fitnesses[front[j]] = float64(-r) + c
+		}
+	}
+	m.mo.front = m.mo.front[:0]
+	for _, i := range fronts[0] {
+		m.mo.front = append(m.mo.front, p.entities[i])
+	}
+	m.elite, m.fitness = nil, math.Inf(-1)
+}
+
+// paretoSurvive selects the next generation from the parents and the offspring in the Pareto mode.
+func (m *GA) paretoSurvive() {
+	n := m.n
+	pool := append(m.pop.entities[:n:n], m.tentities...)
+	os := append(m.mo.objectives[:n:n], m.objectives(m.tentities)...)
+	idx := make([]int, 0, 2*n)
+	for _, front := range fronts(os) {
+		if len(idx)+len(front) > n {
+			ds := crowding(os, front)
+			order := make([]int, len(front))
+			for j := range order {
+				order[j] = j
+			}
+			sort.SliceStable(order, func(a, b int) bool {
+				return ds[order[a]] > ds[order[b]]
+			})
+			for _, j := range order[:n-len(idx)] {
+				idx = append(idx, front[j])
+			}
+			break
+		}
+		idx = append(idx, front...)
+	}
+	next := make([][]float64, n)
+	for j, i := range idx {
+		m.tentities[j], next[j] = pool[i], os[i]
+	}
+	m.swap()
+	m.mo.objectives = next
+	m.score()
+	m.normalize()
+}
+
+// dominates reports whether x Pareto dominates y.
+func dominates(x, y []float64) bool {
+	better := false
+	for k := range x {
+		if x[k] < y[k] {
+			return false
+		}
+		if x[k] > y[k] {
+			better = true
+		}
+	}
+	return better
+}
+
+// fronts sorts the indices of os into non-dominated fronts, by the fast non-dominated sorting.
+func fronts(os [][]float64) [][]int {
+	n := len(os)
+	counts, dominated := make([]int, n), make([][]int, n)
+	var front []int
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if dominates(os[i], os[j]) {
+				dominated[i] = append(dominated[i], j)
+				counts[j]++
+			} else if dominates(os[j], os[i]) {
+				dominated[j] = append(dominated[j], i)
+				counts[i]++
+			}
+		}
+		if counts[i] == 0 {
+			front = append(front, i)
+		}
+	}
+	var fs [][]int
+	for len(front) > 0 {
+		fs = append(fs, front)
+		var next []int
+		for _, i := range front {
+			for _, j := range dominated[i] {
+				if counts[j]--; counts[j] == 0 {
+					next = append(next, j)
+				}
+			}
+		}
+		front = next
+	}
+	return fs
+}
+
+// crowding returns the crowding distances of the entities of front,
+// where the extremes of any objective get +Inf.
+func crowding(os [][]float64, front []int) []float64 {
+	ds := make([]float64, len(front))
+	if len(front) == 0 {
+		return ds
+	}
+	order := make([]int, len(front))
+	for k := range os[front[0]] {
+		for j := range order {
+			order[j] = j
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			return os[front[order[a]]][k] < os[front[order[b]]][k]
+		})
+		lo, hi := os[front[order[0]]][k], os[front[order[len(order)-1]]][k]
+		ds[order[0]], ds[order[len(order)-1]] = math.Inf(1), math.Inf(1)
+		if hi == lo {
+			continue
+		}
+		for j := 1; j < len(order)-1; j++ {
+			ds[order[j]] += (os[front[order[j+1]]][k] - os[front[order[j-1]]][k]) / (hi - lo)
+		}
+	}
+	return ds
+}
@@ -0,0 +1,61 @@
+package ga_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+type Schaffer float64
+
+func (s Schaffer) Fitness() float64 {
+	panic("Fitness should not be called")
+}
+
+func (s Schaffer) Objectives() []float64 {
+	x := float64(s)
+	return []float64{-sqr(x), -sqr(x - 2)}
+}
+
+func (s Schaffer) Mutate() ga.Entity {
+	return s + Schaffer(rand.NormFloat64())
+}
+
+func (s Schaffer) Crossover(e ga.Entity, w float64) ga.Entity {
+	return Schaffer(w*float64(s) + (1-w)*float64(e.(Schaffer)))
+}
+
+func TestPareto(t *testing.T) {
+	for _, opts := range [][]ga.Option{{ga.WithPareto(), ga.WithSelector(ga.TournamentSelector(2))}, {ga.WithPareto()}} {
+		m := ga.New(50, func() ga.Entity {
+			return Schaffer(20*rand.Float64() - 10)
+		}, opts...)
+		for i := 0; i < 50; i++ {
+			m.Next()
+		}
+		front := m.ParetoFront()
+		if len(front) < 40 {
+			t.Fatal("front size:", len(front))
+		}
+		lo, hi := 2.0, 0.0
+		for _, e := range front {
+			x := float64(e.(Schaffer))
+			if !(x >= -0.1 && x <= 2.1) {
+				t.Fatal("dominated:", x)
+			}
+			if x < lo {
+				lo = x
+			}
+			if x > hi {
+				hi = x
+			}
+		}
+		if lo > 0.2 || hi < 1.8 {
+			t.Fatal("spread:", lo, hi)
+		}
+	}
+	if ga.New(5, MIN{}.Mutate).ParetoFront() != nil {
+		t.Fatal("front without Pareto mode")
+	}
+}
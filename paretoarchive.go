@@ -0,0 +1,159 @@
+package ga
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+)
+
+// ParetoArchive is a bounded archive of the non-dominated entities ever seen, see WithParetoArchive.
+// It is safe for concurrent use: the candidates dominated by the archive are rejected without a lock,
+// so only the accepted ones are serialized, and the snapshots are read without a lock.
+type ParetoArchive struct {
+	capacity int
+	eps      []float64
+	mutex    sync.Mutex
+	current  atomic.Pointer[archived]
+}
+
+// archived is an immutable state of a ParetoArchive.
+type archived struct {
+	es []Entity
+	os [][]float64
+}
+
+// NewParetoArchive creates an empty archive of at most capacity entities, or unbounded if capacity <= 0.
+// If eps is not empty, the archive keeps at most one entity per box of the sizes eps of the objectives,
+// by the ε-dominance of the boxes, which bounds the archive by the resolution of the front.
+// Otherwise, it keeps the entities dominated by none.
+// If the archive exceeds capacity, the most crowded entity is dropped, by the crowding distance of NSGA-II.
+func NewParetoArchive(capacity int, eps []float64) *ParetoArchive {
+	a := &ParetoArchive{capacity: capacity, eps: append([]float64(nil), eps...)}
+	a.current.Store(&archived{})
+	return a
+}
+
+// WithParetoArchive adds every entity evaluated in the Pareto mode into the archive a, during the evaluation,
+// so a keeps the best trade-offs of the whole run, not only of the current population, see WithPareto.
+// The archive can be shared by several models.
+func WithParetoArchive(a *ParetoArchive) Option {
+	return func(m *GA) {
+		m.parchive = a
+	}
+}
+
+// Add adds the entity e of the objectives os, and reports whether it is accepted.
+func (a *ParetoArchive) Add(e Entity, os []float64) bool {
+	if a.rejects(a.current.Load(), os) {
+		return false
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	s := a.current.Load()
+	if a.rejects(s, os) {
+		return false
+	}
+	os = append([]float64(nil), os...)
+	next := &archived{es: make([]Entity, 0, len(s.es)+1), os: make([][]float64, 0, len(s.os)+1)}
+	for i, o := range s.os {
+		if !a.replaces(os, o) {
+			next.es, next.os = append(next.es, s.es[i]), append(next.os, o)
+		}
+	}
+	next.es, next.os = append(next.es, e), append(next.os, os)
+	for a.capacity > 0 && len(next.es) > a.capacity {
+		next.prune()
+	}
+	a.current.Store(next)
+	return true
+}
+
+// Snapshot returns the entities of the archive and their objectives.
+func (a *ParetoArchive) Snapshot() ([]Entity, [][]float64) {
+	s := a.current.Load()
+	es, os := make([]Entity, len(s.es)), make([][]float64, len(s.os))
+	copy(es, s.es)
+	for i, o := range s.os {
+		os[i] = append([]float64(nil), o...)
+	}
+	return es, os
+}
+
+// Len returns the number of entities of the archive.
+func (a *ParetoArchive) Len() int {
+	return len(a.current.Load().es)
+}
+
+// rejects reports whether the objectives x are rejected by the state s.
+func (a *ParetoArchive) rejects(s *archived, x []float64) bool {
+	for _, o := range s.os {
+		if len(a.eps) == 0 {
+			if dominates(o, x) || equal(o, x) {
+				return true
+			}
+			continue
+		}
+		bo, bx := a.box(o), a.box(x)
+		if dominates(bo, bx) {
+			return true
+		}
+		if equal(bo, bx) && !dominates(x, o) && (dominates(o, x) || a.corner(o) <= a.corner(x)) {
+			return true
+		}
+	}
+	return false
+}
+
+// replaces reports whether the accepted objectives x replace the archived objectives o.
+func (a *ParetoArchive) replaces(x, o []float64) bool {
+	if len(a.eps) == 0 {
+		return dominates(x, o)
+	}
+	bx, bo := a.box(x), a.box(o)
+	return dominates(bx, bo) || equal(bx, bo)
+}
+
+// box returns the box of the objectives x.
+func (a *ParetoArchive) box(x []float64) []float64 {
+	b := make([]float64, len(x))
+	for k := range x {
+		b[k] = math.Floor(x[k] / a.eps[k])
+	}
+	return b
+}
+
+// corner returns the distance of the objectives x to the best corner of its box, in the units of the box.
+func (a *ParetoArchive) corner(x []float64) float64 {
+	d := 0.0
+	for k := range x {
+		v := x[k]/a.eps[k] - math.Floor(x[k]/a.eps[k]) - 1
+		d += v * v
+	}
+	return d
+}
+
+// prune drops the most crowded entity.
+func (s *archived) prune() {
+	idx := make([]int, len(s.os))
+	for i := range idx {
+		idx[i] = i
+	}
+	ds := crowding(s.os, idx)
+	j := 0
+	for i, d := range ds {
+		if d < ds[j] {
+			j = i
+		}
+	}
+	s.es, s.os = append(s.es[:j], s.es[j+1:]...), append(s.os[:j], s.os[j+1:]...)
+}
+
+// equal reports whether x and y are equal.
+func equal(x, y []float64) bool {
+	for k := range x {
+		if x[k] != y[k] {
+			return false
+		}
+	}
+	return true
+}
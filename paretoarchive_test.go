@@ -0,0 +1,45 @@
+package ga_test
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestParetoArchive(t *testing.T) {
+	for _, a := range []*ga.ParetoArchive{ga.NewParetoArchive(20, nil), ga.NewParetoArchive(0, []float64{0.5, 0.5})} {
+		var wg sync.WaitGroup
+		for c := 0; c < 4; c++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 2000; i++ {
+					s := Schaffer(6*rand.Float64() - 2)
+					a.Add(s, s.Objectives())
+				}
+			}()
+		}
+		wg.Wait()
+		es, os := a.Snapshot()
+		if len(es) < 3 || len(es) > 20 || len(es) != len(os) || a.Len() != len(es) {
+			t.Fatal("size:", len(es), a.Len())
+		}
+		// The ε-dominance keeps the entities ε-dominated only.
+		for i, e := range es {
+			if x := float64(e.(Schaffer)); x < -0.1 || x > 2.1 {
+				t.Fatal("dominated:", x, os[i])
+			}
+		}
+	}
+
+	a := ga.NewParetoArchive(10, nil)
+	m := ga.New(30, func() ga.Entity {
+		return Schaffer(20*rand.Float64() - 10)
+	}, ga.WithPareto(), ga.WithParetoArchive(a))
+	m.EvolveTo(20)
+	if es, _ := a.Snapshot(); len(es) != 10 {
+		t.Fatal("archive:", len(es))
+	}
+}
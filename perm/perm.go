@@ -0,0 +1,202 @@
+// Package perm implements the permutation genome of GA model, e.g. for routing and scheduling problems,
+// with the standard crossover and mutation operators which always produce valid permutations.
+package perm
+
+import (
+	"math/rand/v2"
+
+	"github.com/ofunc/ga"
+)
+
+// Crossover is a crossover operator of permutations, which produces a child of x and y into z with the random numbers of r.
+type Crossover func(r *rand.Rand, z, x, y []int)
+
+// Mutation is a mutation operator of permutations, which mutates p in place with the random numbers of r.
+type Mutation func(r *rand.Rand, p []int)
+
+// Space is the space of the permutations of [0, n).
+type Space struct {
+	n         int
+	fitness   func([]int) float64
+	crossover Crossover
+	mutation  Mutation
+}
+
+// Option is an option of Space.
+type Option func(*Space)
+
+// WithCrossover sets the crossover operator, default to OX.
+func WithCrossover(c Crossover) Option {
+	return func(s *Space) {
+		s.crossover = c
+	}
+}
+
+// WithMutation sets the mutation operator, default to Inversion.
+func WithMutation(m Mutation) Option {
+	return func(s *Space) {
+		s.mutation = m
+	}
+}
+
+// NewSpace creates the space of the permutations of [0, n) with the fitness function f.
+func NewSpace(n int, f func([]int) float64, opts ...Option) *Space {
+	s := &Space{
+		n:         n,
+		fitness:   f,
+		crossover: OX,
+		mutation:  Inversion,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Len returns the length of the permutations.
+func (s *Space) Len() int {
+	return s.n
+}
+
+// New returns the permutation p of the space, or nil if p is not a permutation of [0, n).
+func (s *Space) New(p []int) *Perm {
+	if len(p) != s.n {
+		return nil
+	}
+	seen := make([]bool, s.n)
+	for _, i := range p {
+		if i < 0 || i >= s.n || seen[i] {
+			return nil
+		}
+		seen[i] = true
+	}
+	return &Perm{append([]int(nil), p...), s}
+}
+
+// Random returns a uniformly random permutation, which can be the generator of GA model.
+func (s *Space) Random() ga.Entity {
+	return s.RandomCtx(ga.GenCtx{})
+}
+
+// RandomCtx is Random with the random numbers of ctx, which can be the generator of ga.NewCtx,
+// so that the permutations are reproducible by ga.WithSeed.
+func (s *Space) RandomCtx(ctx ga.GenCtx) ga.Entity {
+	return &Perm{ctx.Random().Perm(s.n), s}
+}
+
+// Perm is a permutation of a space, which implements ga.Entity,
+// and ga.ContextMutator and ga.ContextCrossover to draw the random numbers of the model.
+type Perm struct {
+	p []int
+	s *Space
+}
+
+// P returns the permutation, which must not be modified.
+func (p *Perm) P() []int {
+	return p.p
+}
+
+// Fitness returns the fitness of the permutation.
+func (p *Perm) Fitness() float64 {
+	return p.s.fitness(p.p)
+}
+
+// Mutate mutates a copy of the permutation by the mutation operator of the space.
+func (p *Perm) Mutate() ga.Entity {
+	return p.MutateCtx(ga.GenCtx{})
+}
+
+// MutateCtx is Mutate with the random numbers of ctx.
+func (p *Perm) MutateCtx(ctx ga.GenCtx) ga.Entity {
+	z := &Perm{append([]int(nil), p.p...), p.s}
+	p.s.mutation(ctx.Random(), z.p)
+	return z
+}
+
+// Crossover produces a child of p and e by the crossover operator of the space.
+// The weight is ignored, since the operators do not blend.
+func (p *Perm) Crossover(e ga.Entity, w float64) ga.Entity {
+	return p.CrossoverCtx(ga.GenCtx{}, e, w)
+}
+
+// CrossoverCtx is Crossover with the random numbers of ctx.
+func (p *Perm) CrossoverCtx(ctx ga.GenCtx, e ga.Entity, w float64) ga.Entity {
+	z := &Perm{make([]int, len(p.p)), p.s}
+	p.s.crossover(ctx.Random(), z.p, p.p, e.(*Perm).p)
+	return z
+}
+
+// cut returns a random segment [i, j) of a permutation of length n, with the random numbers of r.
+func cut(r *rand.Rand, n int) (int, int) {
+	i, j := r.IntN(n+1), r.IntN(n+1)
+	if i > j {
+		i, j = j, i
+	}
+	return i, j
+}
+
+// OX is the order crossover, which copies a random segment of x,
+// and fills the rest in the order of y, starting after the segment.
+func OX(r *rand.Rand, z, x, y []int) {
+	n := len(z)
+	if n == 0 {
+		return
+	}
+	a, b := cut(r, n)
+	used := make([]bool, n)
+	for i := a; i < b; i++ {
+		z[i], used[x[i]] = x[i], true
+	}
+	k := b % n
+	for j := 0; j < n; j++ {
+		if v := y[(b+j)%n]; !used[v] {
+			z[k], used[v] = v, true
+			k = (k + 1) % n
+		}
+	}
+}
+
+// PMX is the partially mapped crossover, which copies a random segment of x,
+// and takes the rest from y, resolving the conflicts by the mapping of the segment.
+func PMX(r *rand.Rand, z, x, y []int) {
+	n := len(z)
+	a, b := cut(r, n)
+	pos := make([]int, n)
+	for i, v := range x {
+		pos[v] = i
+	}
+	in := func(i int) bool {
+		return a <= i && i < b
+	}
+	for i := range z {
+		if in(i) {
+			z[i] = x[i]
+			continue
+		}
+		v := y[i]
+		for in(pos[v]) {
+			v = y[pos[v]]
+		}
+		z[i] = v
+	}
+}
+
+// Swap swaps two random positions.
+func Swap(r *rand.Rand, p []int) {
+	if len(p) < 2 {
+		return
+	}
+	i, j := r.IntN(len(p)), r.IntN(len(p)-1)
+	if j >= i {
+		j++
+	}
+	p[i], p[j] = p[j], p[i]
+}
+
+// Inversion reverses a random segment, which is the 2-opt move for tours.
+func Inversion(r *rand.Rand, p []int) {
+	i, j := cut(r, len(p))
+	for j--; i < j; i, j = i+1, j-1 {
+		p[i], p[j] = p[j], p[i]
+	}
+}
@@ -0,0 +1,67 @@
+package perm_test
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/ofunc/ga"
+	"github.com/ofunc/ga/perm"
+)
+
+func valid(p []int) bool {
+	seen := make([]bool, len(p))
+	for _, i := range p {
+		if i < 0 || i >= len(p) || seen[i] {
+			return false
+		}
+		seen[i] = true
+	}
+	return true
+}
+
+func TestOperators(t *testing.T) {
+	s, r := perm.NewSpace(9, nil), rand.New(rand.NewPCG(1, 2))
+	for name, c := range map[string]perm.Crossover{"ox": perm.OX, "pmx": perm.PMX} {
+		z := make([]int, 9)
+		for i := 0; i < 1000; i++ {
+			x, y := s.Random().(*perm.Perm).P(), s.Random().(*perm.Perm).P()
+			c(r, z, x, y)
+			if !valid(z) {
+				t.Fatal(name, x, y, z)
+			}
+		}
+	}
+	for name, m := range map[string]perm.Mutation{"swap": perm.Swap, "inversion": perm.Inversion} {
+		p := s.Random().(*perm.Perm).P()
+		for i := 0; i < 1000; i++ {
+			if m(r, p); !valid(p) {
+				t.Fatal(name, p)
+			}
+		}
+	}
+	if s.New([]int{0, 1, 1, 2, 3, 4, 5, 6, 7}) != nil {
+		t.Fatal("invalid permutation accepted")
+	}
+}
+
+func TestTSP(t *testing.T) {
+	const n = 12
+	length := func(p []int) float64 {
+		d := 0.0
+		for i := range p {
+			a := 2 * math.Pi * float64(p[i]) / n
+			b := 2 * math.Pi * float64(p[(i+1)%n]) / n
+			d += math.Hypot(math.Cos(a)-math.Cos(b), math.Sin(a)-math.Sin(b))
+		}
+		return -d
+	}
+	best := -length(perm.NewSpace(n, nil).New([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}).P())
+	for name, c := range map[string]perm.Crossover{"ox": perm.OX, "pmx": perm.PMX} {
+		s := perm.NewSpace(n, length, perm.WithCrossover(c))
+		m := ga.New(200, s.Random)
+		if _, f, _ := m.Evolve(100, 1000); -f > best*1.5 {
+			t.Error(name, "length:", -f, best)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package ga
+
+// WithAdaptivePopulation adapts the population size within [min, max], in the spirit of the parameter-less GA.
+// When the fitnesses collapse, i.e. their standard deviation falls below 5% of the initial one,
+// the population grows by half with new entities of the generator, which inject diversity.
+// When the elite improves, the population shrinks by a tenth, dropping the least fit entities,
+// which saves the evaluations while the progress is steady.
+func WithAdaptivePopulation(min, max int) Option {
+	return func(m *GA) {
+		m.sizing = &[2]int{min, max}
+	}
+}
+
+// Size returns the size of the population.
+func (m *GA) Size() int {
+	return m.n
+}
+
+// adapt adapts the population size, where prev is the fitness of the elite before the generation.
+func (m *GA) adapt(prev float64) {
+	min, max := m.sizing[0], m.sizing[1]
+	_, std := m.pop.Stats()
+	n := m.n
+	if m.base > 0 && std < 0.05*m.base {
+		n = n + n/2 + 1
+	} else if m.fitness > prev {
+		n = n * 9 / 10
+	}
+	if n > max {
+		n = max
+	}
+	if n < min {
+		n = min
+	}
+	if n == m.n {
+		return
+	}
+	es, fs := make([]Entity, n), make([]float64, n)
+	idx := order(m.pop.fitnesses)
+	for j := 0; j < n && j < m.n; j++ {
+		i := idx[m.n-1-j]
+		es[j], fs[j] = m.pop.entities[i], m.pop.fitnesses[i]
+	}
+	if k := m.n; n > k {
+		m.spawn(n-k, func(i int) {
+			es[k+i] = m.g()
+		})
+		m.pop.evaluateInto(es[k:], fs[k:])
+	}
+	m.resize(n)
+	copy(m.pop.entities, es)
+	copy(m.pop.fitnesses, fs)
+	m.pop.summarize()
+	if e, f := m.pop.Best(); m.fitter(e, f) {
+		m.fitness, m.elite = f, e
+	}
+	m.reweigh()
+}
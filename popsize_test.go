@@ -0,0 +1,29 @@
+package ga_test
+
+import (
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestAdaptivePopulation(t *testing.T) {
+	m := ga.New(50, MIN{}.Mutate, ga.WithAdaptivePopulation(20, 200))
+	lo, hi := m.Size(), m.Size()
+	for i := 0; i < 200; i++ {
+		m.Next()
+		if n := m.Size(); n < lo {
+			lo = n
+		} else if n > hi {
+			hi = n
+		}
+		if n := len(m.EvolveTo(0)); n != m.Size() || n < 20 || n > 200 {
+			t.Fatal("size:", n, m.Size())
+		}
+	}
+	if lo >= 50 || hi <= 50 {
+		t.Fatal("not adapted:", lo, hi)
+	}
+	if f := m.Fitness(); f < -1e-2 {
+		t.Fatal("fitness:", f)
+	}
+}
@@ -0,0 +1,346 @@
+package ga
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Population is a population of entities, with their fitnesses, selection weights and statistics.
+// It is the evaluation and selection machinery of GA model,
+// and can be reused to build other algorithms on the same infrastructure.
+type Population struct {
+	entities   []Entity
+	fitnesses  []float64
+	weights    []float64
+	fsum       float64
+	moments    moments
+	ibest      int
+	iworst     int
+	eval       func(context.Context, Entity) float64
+	ctx        context.Context
+	deadline   time.Duration
+	sequential bool
+	batch      func([]Entity, []float64)
+	stable     bool
+	nc         int
+	executor   Executor
+	scaling    Scaling
+	accs       []accumulator
+	better     func(a, b Entity) bool
+}
+
+// accumulator is the per-worker accumulator of the reductions, reused across generations.
+// It is padded to two cache lines, so the accumulators of the workers never share a cache line.
+type accumulator struct {
+	sm, sv, mb, mw, fsum float64
+	ib, iw, n            int
+	_                    [2*cacheLine - 64]byte
+}
+
+// scratch returns the accumulators for the current concurrency.
+func (p *Population) scratch() []accumulator {
+	if nc := p.concurrency(); len(p.accs) != nc {
+		p.accs = make([]accumulator, nc)
+	}
+	return p.accs
+}
+
+// NewPopulation creates a population of the entities es, which should be evaluated before use.
+func NewPopulation(es []Entity) *Population {
+	p := &Population{eval: func(_ context.Context, e Entity) float64 {
+		return fitness(e)
+	}, nc: NC}
+	p.init(es)
+	return p
+}
+
+func (p *Population) init(es []Entity) {
+	n := len(es)
+	p.entities, p.fitnesses, p.weights = es, make([]float64, n), make([]float64, n)
+	p.ibest, p.iworst = -1, -1
+}
+
+// Len returns the size of the population.
+func (p *Population) Len() int {
+	return len(p.entities)
+}
+
+// At returns the i-th entity and its fitness.
+func (p *Population) At(i int) (Entity, float64) {
+	return p.entities[i], p.fitnesses[i]
+}
+
+// Weight returns the selection weight of the i-th entity, which is in [0, 1].
+func (p *Population) Weight(i int) float64 {
+	return p.weights[i]
+}
+
+// Evaluate evaluates the fitnesses of all entities concurrently,
+// and updates the statistics and the selection weights.
+func (p *Population) Evaluate() {
+	p.evaluate()
+	p.summarize()
+	p.weigh()
+}
+
+// Stats returns the mean and the standard deviation of the fitnesses.
+func (p *Population) Stats() (mean, std float64) {
+	return p.moments.mean, math.Sqrt(p.moments.variance())
+}
+
+// Best returns the fittest entity and its fitness.
+// If no fitness is greater than -Inf, it returns nil and -Inf.
+func (p *Population) Best() (Entity, float64) {
+	if p.ibest < 0 {
+		return nil, math.Inf(-1)
+	}
+	return p.entities[p.ibest], p.fitnesses[p.ibest]
+}
+
+// Worst returns the least fit entity and its fitness.
+// If no fitness is less than +Inf, it returns nil and +Inf.
+func (p *Population) Worst() (Entity, float64) {
+	if p.iworst < 0 {
+		return nil, math.Inf(1)
+	}
+	return p.entities[p.iworst], p.fitnesses[p.iworst]
+}
+
+func (p *Population) evaluate() {
+	p.evaluateInto(p.entities, p.fitnesses)
+}
+
+// run calls f(c, i) for i in [0, n) to evaluate the fitnesses, sequentially if the evaluation is sequential.
+func (p *Population) run(n int, f func(c, i int)) {
+	if p.sequential {
+		sequential(n, f)
+	} else {
+		p.parallel(n, f)
+	}
+}
+
+// evaluateInto evaluates the fitnesses of es into fs, with the settings of the population.
+func (p *Population) evaluateInto(es []Entity, fs []float64) {
+	if p.batch != nil {
+		p.batch(es, fs)
+		return
+	}
+	evaluate(es, fs, p.eval, p.ctx, p.deadline, p.run)
+}
+
+// summarize computes the moments, the best and the worst of the fitnesses.
+func (p *Population) summarize() {
+	accs := p.scratch()
+	for c := range accs {
+		a := &accs[c]
+		a.sm, a.sv, a.n = 0, 0, 0
+		a.mb, a.mw, a.ib, a.iw = math.Inf(-1), math.Inf(1), -1, -1
+	}
+	p.reduce(len(p.entities), func(c, lo, hi int) {
+		k, sm, sv := 0, 0.0, 0.0
+		mb, mw, ib, iw := math.Inf(-1), math.Inf(1), -1, -1
+		for i, f := range p.fitnesses[lo:hi] {
+			if math.IsInf(f, -1) {
+				if iw < 0 || mw > f {
+					mw, iw = f, lo+i
+				}
+				continue
+			}
+			k++
+			sm += f
+			sv += f * f
+			if mb < f {
+				mb, ib = f, lo+i
+			}
+			if mw > f {
+				mw, iw = f, lo+i
+			}
+		}
+		a := &accs[c]
+		a.n += k
+		a.sm += sm
+		a.sv += sv
+		if a.mb < mb || ib >= 0 && a.mb == mb && ib < a.ib {
+			a.mb, a.ib = mb, ib
+		}
+		if a.mw > mw || iw >= 0 && a.mw == mw && (iw < a.iw || a.iw < 0) {
+			a.mw, a.iw = mw, iw
+		}
+	})
+	// The ties are broken by the lowest index, so the best and the worst do not depend on the workers.
+	n, sm, sv := 0, 0.0, 0.0
+	best, worst := &accs[0], &accs[0]
+	for c := range accs {
+		a := &accs[c]
+		n, sm, sv = n+a.n, sm+a.sm, sv+a.sv
+		if best.mb < a.mb || a.ib >= 0 && best.mb == a.mb && (a.ib < best.ib || best.ib < 0) {
+			best = a
+		}
+		if worst.mw > a.mw || a.iw >= 0 && worst.mw == a.mw && (a.iw < worst.iw || worst.iw < 0) {
+			worst = a
+		}
+	}
+	p.ibest, p.iworst = best.ib, worst.iw
+	if p.better != nil && p.ibest >= 0 {
+		p.breakTies()
+	}
+
+	mean := 0.0
+	if n > 0 {
+		mean = sm / float64(n)
+	}
+	p.moments.set(n, mean, sv-mean*sm)
+}
+
+// chunk is the number of elements a worker reduces in a tight loop over contiguous slices.
+const chunk = 1024
+
+// reduce runs the reduction f over the chunks [lo, hi) of [0, n) by parallel,
+// or by sequential if the population is stable, so the rounding and the ties do not depend on the concurrency.
+func (p *Population) reduce(n int, f func(c, lo, hi int)) {
+	k := (n + chunk - 1) / chunk
+	g := func(c, j int) {
+		lo, hi := j*chunk, (j+1)*chunk
+		if hi > n {
+			hi = n
+		}
+		f(c, lo, hi)
+	}
+	if p.stable || k == 1 {
+		sequential(k, g)
+	} else {
+		p.parallel(k, g)
+	}
+}
+
+// weigh computes the selection weights, by scaling of the fitnesses.
+func (p *Population) weigh() {
+	mean, std := p.Stats()
+	p.scale(p.fitnesses, mean, std)
+}
+
+// scale computes the selection weights by scaling of fs, with the mean and the standard deviation.
+func (p *Population) scale(fs []float64, mean, std float64) {
+	if std == 0 {
+		std = 1
+	}
+	if p.scaling == RankScaling {
+		p.rank(fs)
+		return
+	}
+	scaler := p.scaler(fs, mean, std)
+	accs := p.scratch()
+	for c := range accs {
+		accs[c].fsum = 0
+	}
+	p.reduce(len(p.entities), func(c, lo, hi int) {
+		s, ws := 0.0, p.weights[lo:hi]
+		for i, f := range fs[lo:hi] {
+			w := scaler(f)
+			ws[i] = w
+			s += w
+		}
+		accs[c].fsum += s
+	})
+	p.fsum = 0
+	for c := range accs {
+		p.fsum += accs[c].fsum
+	}
+	if p.fsum == 0 {
+		for i := range p.weights {
+			p.weights[i] = 1
+		}
+		p.fsum = float64(len(p.weights))
+	}
+}
+
+// cap clamps the selection weight of each entity to at most frac of the total,
+// and redistributes the excess proportionally among the others.
+// The total is not changed, and frac is at least 1/n.
+func (p *Population) cap(frac float64) {
+	n := len(p.weights)
+	if frac*float64(n) < 1 {
+		frac = 1 / float64(n)
+	}
+	limit, capped := frac*p.fsum, make([]bool, n)
+	for {
+		excess, rest := 0.0, 0.0
+		for i, w := range p.weights {
+			if !capped[i] && w > limit {
+				excess += w - limit
+				p.weights[i], capped[i] = limit, true
+			}
+		}
+		if excess == 0 {
+			return
+		}
+		for i, w := range p.weights {
+			if !capped[i] {
+				rest += w
+			}
+		}
+		if rest == 0 {
+			return
+		}
+		for i := range p.weights {
+			if !capped[i] {
+				p.weights[i] *= 1 + excess/rest
+			}
+		}
+	}
+}
+
+// replace replaces the entities at idx with es, and updates the statistics incrementally,
+// which evaluates only the new entities. The selection weights are not updated.
+func (p *Population) replace(idx []int, es []Entity) []float64 {
+	fs := make([]float64, len(es))
+	p.evaluateInto(es, fs)
+	rescan := false
+	for j, i := range idx {
+		p.moments.replace(p.fitnesses[i], fs[j])
+		p.entities[i], p.fitnesses[i] = es[j], fs[j]
+		if i == p.ibest || i == p.iworst {
+			rescan = true
+		} else if p.ibest >= 0 && fs[j] > p.fitnesses[p.ibest] {
+			p.ibest = i
+		} else if p.iworst >= 0 && fs[j] < p.fitnesses[p.iworst] {
+			p.iworst = i
+		} else if p.ibest >= 0 && fs[j] == p.fitnesses[p.ibest] || p.iworst >= 0 && fs[j] == p.fitnesses[p.iworst] {
+			// The ties are broken by the rescan.
+			rescan = true
+		}
+	}
+	if p.moments.stale() {
+		p.moments.reset(p.fitnesses)
+	}
+	if rescan {
+		p.extremes()
+	}
+	return fs
+}
+
+// extremes finds the best and the worst sequentially.
+func (p *Population) extremes() {
+	p.ibest, p.iworst = -1, -1
+	b, w := math.Inf(-1), math.Inf(1)
+	for i, f := range p.fitnesses {
+		if b < f {
+			b, p.ibest = f, i
+		}
+		if w > f {
+			w, p.iworst = f, i
+		}
+	}
+	if p.better != nil && p.ibest >= 0 {
+		p.breakTies()
+	}
+}
+
+func neg(xs []float64) []float64 {
+	ys := make([]float64, len(xs))
+	for i, x := range xs {
+		ys[i] = -x
+	}
+	return ys
+}
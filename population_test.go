@@ -0,0 +1,88 @@
+package ga_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestPopulation(t *testing.T) {
+	es := []ga.Entity{MIN{1, 0}, MIN{0, 0}, MIN{2, 2}, MIN{0, -1}}
+	p := ga.NewPopulation(es)
+	p.Evaluate()
+	if p.Len() != 4 {
+		t.Fatal("len:", p.Len())
+	}
+	if e, f := p.Best(); e != es[1] || f != 0 {
+		t.Fatal("best:", e, f)
+	}
+	if e, f := p.Worst(); e != es[2] || f != -8 {
+		t.Fatal("worst:", e, f)
+	}
+	mean, std := p.Stats()
+	if math.Abs(mean+2.5) > 1e-12 || math.Abs(std-math.Sqrt(10.25)) > 1e-12 {
+		t.Fatal("stats:", mean, std)
+	}
+	for i := 0; i < p.Len(); i++ {
+		if e, f := p.At(i); e != es[i] || f != e.Fitness() {
+			t.Fatal("at:", i, e, f)
+		}
+		if w := p.Weight(i); w <= 0 || w >= 1 {
+			t.Fatal("weight:", i, w)
+		}
+	}
+	if p.Weight(1) <= p.Weight(0) || p.Weight(0) <= p.Weight(2) {
+		t.Fatal("weight order")
+	}
+}
+
+// Float is a fitness computed in float32.
+type Float float32
+
+func (x Float) Fitness() float64 {
+	panic("float64 fitness")
+}
+
+func (x Float) Fitness32() float32 {
+	return float32(x)
+}
+
+func (x Float) Mutate() ga.Entity {
+	return x
+}
+
+func (x Float) Crossover(e ga.Entity, w float64) ga.Entity {
+	return x
+}
+
+func TestLargePopulation(t *testing.T) {
+	n := 5000
+	es := make([]ga.Entity, n)
+	for i := range es {
+		es[i] = Float(i % 1000)
+	}
+	es[3333] = Float(math.Inf(-1))
+	p := ga.NewPopulation(es)
+	p.Evaluate()
+	if _, f := p.Best(); f != 999 {
+		t.Fatal("best:", f)
+	}
+	if e, _ := p.Worst(); e != es[3333] {
+		t.Fatal("worst:", e)
+	}
+	mean, std := p.Stats()
+	m, v := (float64(n/1000)*999*1000/2-333)/float64(n-1), 0.0
+	for i, e := range es {
+		if i != 3333 {
+			f := float64(e.(Float))
+			v += (f - m) * (f - m) / float64(n-1)
+		}
+	}
+	if math.Abs(mean-m) > 1e-9 || math.Abs(std-math.Sqrt(v)) > 1e-9 {
+		t.Fatal("stats:", mean, std, m, math.Sqrt(v))
+	}
+	if p.Weight(999) <= p.Weight(4000) || p.Weight(3333) != 0 {
+		t.Fatal("weights:", p.Weight(999), p.Weight(4000), p.Weight(3333))
+	}
+}
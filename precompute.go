@@ -0,0 +1,32 @@
+package ga
+
+import "sync/atomic"
+
+// IndexedFitness is an optional interface of Entity, whose fitness is read by its index.
+type IndexedFitness interface {
+	// FitnessAt returns the fitness of the entity at the index i of the batch prepared in the generation gen.
+	FitnessAt(gen, i int) float64
+}
+
+// WithPrecompute calls prepare with each batch of entities before their fitnesses are read,
+// the whole generation or the new entities of an incremental update,
+// so they may be evaluated externally at once and the model merely reads the precomputed values.
+// The fitness of each entity of a batch is then read exactly once, sequentially in the order of the batch,
+// by FitnessAt if the entity implements IndexedFitness, or by Fitness otherwise.
+// As with WithBatchEvaluator, the oracle, the fitness cache and the generation deadline are not applied to them,
+// and the options re-evaluating the elite, e.g. WithDynamicFitness, still call Fitness.
+func WithPrecompute(prepare func(gen int, es []Entity)) Option {
+	return func(m *GA) {
+		m.evaluator("WithPrecompute", func(es []Entity, fs []float64) {
+			atomic.AddInt64(&m.evals, int64(len(es)))
+			prepare(m.gen, es)
+			for i, e := range es {
+				if x, ok := e.(IndexedFitness); ok {
+					fs[i] = m.objective(x.FitnessAt(m.gen, i))
+				} else {
+					fs[i] = m.objective(fitness(e))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package ga_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+type Precomputed struct {
+	X     *float64
+	calls *[]int
+}
+
+func (x Precomputed) Fitness() float64 {
+	panic("Fitness called")
+}
+
+func (x Precomputed) FitnessAt(gen, i int) float64 {
+	*x.calls = append(*x.calls, i)
+	return -*x.X * *x.X
+}
+
+func (x Precomputed) Mutate() ga.Entity {
+	y := *x.X + rand.Float64() - 0.5
+	return Precomputed{&y, x.calls}
+}
+
+func (x Precomputed) Crossover(e ga.Entity, w float64) ga.Entity {
+	y := w**x.X + (1-w)**e.(Precomputed).X
+	return Precomputed{&y, x.calls}
+}
+
+func TestPrecompute(t *testing.T) {
+	var calls []int
+	var batch []ga.Entity
+	m := ga.New(20, func() ga.Entity {
+		x := rand.Float64()*10 - 5
+		return Precomputed{&x, &calls}
+	}, ga.WithPrecompute(func(gen int, es []ga.Entity) {
+		for i := range calls {
+			if calls[i] != i {
+				t.Fatal("order:", calls)
+			}
+		}
+		if len(calls) != len(batch) {
+			t.Fatal("calls:", len(calls), len(batch))
+		}
+		calls, batch = calls[:0], es
+	}))
+	m.Evolve(50, 500)
+	if m.Fitness() < -0.01 {
+		t.Fatal("fitness:", m.Fitness())
+	}
+}
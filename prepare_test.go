@@ -0,0 +1,20 @@
+package ga_test
+
+import (
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestPrepare(t *testing.T) {
+	m := ga.New(50, MIN{}.Mutate)
+	m.Next()
+	s, a := m.SnapshotStats(), m.Adaptation()
+	m.Prepare()
+	if p := m.SnapshotStats(); p.Generation != s.Generation || p.Mean != s.Mean || p.Evaluations != s.Evaluations+50 {
+		t.Fatal("stats:", s, p)
+	}
+	if m.Adaptation() != a {
+		t.Fatal("adaptation:", a, m.Adaptation())
+	}
+}
@@ -0,0 +1,173 @@
+// Package pso implements the particle swarm optimization over the vector space of package vector,
+// with the same driver surface as GA model, so the two can be swapped on the same problem.
+package pso
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/ofunc/ga"
+	"github.com/ofunc/ga/vector"
+)
+
+// Swarm is a particle swarm.
+type Swarm struct {
+	space     *vector.Space
+	x, v      [][]float64
+	best      [][]float64
+	fbest     []float64
+	elite     ga.Entity
+	fitness   float64
+	ielite    []float64
+	inertia   float64
+	c1, c2    float64
+	seed      int64
+	nc        int
+	exec      ga.Executor
+	rnd       *rand.Rand
+	fitnesses []float64
+}
+
+// Option is an option of Swarm.
+type Option func(*Swarm)
+
+// WithSeed sets the seed of the random source, default to the current time.
+func WithSeed(seed int64) Option {
+	return func(s *Swarm) {
+		s.seed = seed
+	}
+}
+
+// WithInertia sets the inertia weight of the velocities, default to 0.7298.
+func WithInertia(w float64) Option {
+	return func(s *Swarm) {
+		s.inertia = w
+	}
+}
+
+// WithCoefficients sets the cognitive and social coefficients, default to 1.49618 both.
+func WithCoefficients(c1, c2 float64) Option {
+	return func(s *Swarm) {
+		s.c1, s.c2 = c1, c2
+	}
+}
+
+// WithConcurrency sets the number of concurrency of the evaluation, default to ga.NC when New is called.
+func WithConcurrency(n int) Option {
+	return func(s *Swarm) {
+		s.nc = n
+	}
+}
+
+// WithExecutor evaluates the particles by e, e.g. the one shared with GA models by ga.WithExecutor,
+// instead of the worker pool shared by all GA models.
+func WithExecutor(e ga.Executor) Option {
+	return func(s *Swarm) {
+		s.exec = e
+	}
+}
+
+// New creates a swarm of n particles in the space s, uniformly distributed.
+func New(n int, s *vector.Space, opts ...Option) *Swarm {
+	m := &Swarm{
+		space:     s,
+		x:         make([][]float64, n),
+		v:         make([][]float64, n),
+		best:      make([][]float64, n),
+		fbest:     make([]float64, n),
+		fitness:   math.Inf(-1),
+		inertia:   0.7298,
+		c1:        1.49618,
+		c2:        1.49618,
+		seed:      time.Now().UnixNano(),
+		fitnesses: make([]float64, n),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	if m.nc <= 0 {
+		m.nc = ga.NC
+	}
+	if m.exec == nil {
+		m.exec = ga.SharedExecutor()
+	}
+	m.rnd = rand.New(rand.NewPCG(uint64(m.seed), 0x9e3779b97f4a7c15))
+	lower, upper := s.Bounds()
+	for i := range m.x {
+		m.x[i], m.v[i] = make([]float64, s.Dim()), make([]float64, s.Dim())
+		for j := range m.x[i] {
+			d := upper[j] - lower[j]
+			m.x[i][j] = lower[j] + m.rnd.Float64()*d
+			m.v[i][j] = (m.rnd.Float64()*2 - 1) * d / 2
+		}
+		m.fbest[i] = math.Inf(-1)
+	}
+	m.evaluate()
+	return m
+}
+
+// Fitness returns the fitness of the current elite.
+func (m *Swarm) Fitness() float64 {
+	return m.fitness
+}
+
+// Elite returns the current elite, which is a *vector.Vector.
+func (m *Swarm) Elite() ga.Entity {
+	return m.elite
+}
+
+// Next moves the particles one step, and returns the current elite and fitness.
+func (m *Swarm) Next() (ga.Entity, float64) {
+	lower, upper := m.space.Bounds()
+	for i, x := range m.x {
+		v := m.v[i]
+		for j := range x {
+			d := upper[j] - lower[j]
+			v[j] = m.inertia*v[j] +
+				m.c1*m.rnd.Float64()*(m.best[i][j]-x[j]) +
+				m.c2*m.rnd.Float64()*(m.ielite[j]-x[j])
+			v[j] = math.Max(-d, math.Min(d, v[j]))
+			if x[j] += v[j]; x[j] < lower[j] {
+				x[j], v[j] = lower[j], 0
+			} else if x[j] > upper[j] {
+				x[j], v[j] = upper[j], 0
+			}
+		}
+	}
+	m.evaluate()
+	return m.elite, m.fitness
+}
+
+// Evolve runs the swarm until the elite k steps have not changed,
+// or the max of iterations has been reached.
+func (m *Swarm) Evolve(k int, max int) (ga.Entity, float64, bool) {
+	i, fitness := 0, m.fitness
+	for j := 0; i < k && j < max; i, j = i+1, j+1 {
+		if _, f := m.Next(); fitness < f {
+			i, fitness = 0, f
+		}
+	}
+	return m.elite, fitness, i >= k
+}
+
+// evaluate evaluates the particles by the concurrent workers, and updates the personal and global bests.
+func (m *Swarm) evaluate() {
+	n := len(m.x)
+	es, nc := make([]ga.Entity, n), min(m.nc, n)
+	m.exec.Run(nc, func(c int) {
+		for i := c * n / nc; i < (c+1)*n/nc; i++ {
+			es[i] = m.space.New(m.x[i])
+			m.fitnesses[i] = es[i].Fitness()
+		}
+	})
+	for i, f := range m.fitnesses {
+		if m.fbest[i] < f {
+			m.fbest[i], m.best[i] = f, append(m.best[i][:0], m.x[i]...)
+		}
+		if m.fitness < f {
+			m.fitness, m.elite = f, es[i]
+			m.ielite = append(m.ielite[:0], m.x[i]...)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package pso_test
+
+import (
+	"testing"
+
+	"github.com/ofunc/ga"
+	"github.com/ofunc/ga/pso"
+	"github.com/ofunc/ga/vector"
+)
+
+func sphere(x []float64) float64 {
+	f := 0.0
+	for _, v := range x {
+		f -= (v - 1) * (v - 1)
+	}
+	return f
+}
+
+type driver interface {
+	Evolve(k, max int) (ga.Entity, float64, bool)
+	Elite() ga.Entity
+	Fitness() float64
+}
+
+func TestSwarm(t *testing.T) {
+	s := vector.NewSpace([]float64{-5, -5, -5}, []float64{5, 5, 5}, sphere)
+	for name, m := range map[string]driver{
+		"pso": pso.New(30, s, pso.WithSeed(1)),
+		"ga":  ga.New(100, s.Random),
+	} {
+		e, f, ok := m.Evolve(50, 1000)
+		if !ok || f < -1e-2 || e != m.Elite() || f != m.Fitness() || e.Fitness() != f {
+			t.Error(name, "result:", e, f, ok)
+		}
+		for _, x := range e.(*vector.Vector).X() {
+			if x < -5 || x > 5 {
+				t.Error(name, "out of bounds:", x)
+			}
+		}
+	}
+}
+
+func TestSwarmExecutor(t *testing.T) {
+	runs := 0
+	e := ga.ExecutorFunc(func(n int, f func(i int)) {
+		runs++
+		for i := 0; i < n; i++ {
+			f(i)
+		}
+	})
+	s := vector.NewSpace([]float64{-5, -5, -5}, []float64{5, 5, 5}, sphere)
+	m := pso.New(30, s, pso.WithSeed(1), pso.WithExecutor(e))
+	if _, f, _ := m.Evolve(50, 1000); f < -1e-2 || runs == 0 {
+		t.Fatal("executor:", f, runs)
+	}
+}
@@ -0,0 +1,19 @@
+package ga
+
+import "sort"
+
+// Rank evaluates the candidate e, and reports where it would fall in the current population,
+// without adding it to the population.
+// The rank is 1 plus the number of entities fitter than e,
+// and the percentile is the percentage of entities which e is at least as fit as.
+func (m *GA) Rank(e Entity) (rank int, percentile float64) {
+	if m.sorted == nil {
+		m.sorted = append([]float64(nil), m.pop.fitnesses...)
+		sort.Float64s(m.sorted)
+	}
+	f := m.eval(e)
+	k := sort.Search(len(m.sorted), func(i int) bool {
+		return m.sorted[i] > f
+	})
+	return len(m.sorted) - k + 1, 100 * float64(k) / float64(len(m.sorted))
+}
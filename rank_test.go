@@ -0,0 +1,22 @@
+package ga_test
+
+import (
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestRank(t *testing.T) {
+	m := ga.New(100, MIN{}.Mutate)
+	m.Evolve(10, 20)
+	if r, p := m.Rank(MIN{}); r != 1 || p != 100 {
+		t.Fatal("best:", r, p)
+	}
+	if r, p := m.Rank(MIN{1e3, 1e3}); r != 101 || p != 0 {
+		t.Fatal("worst:", r, p)
+	}
+	e, f := m.Elite(), m.Fitness()
+	if r, _ := m.Rank(e); f == m.SnapshotStats().Best && r != 1 {
+		t.Fatal("elite:", r)
+	}
+}
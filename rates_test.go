@@ -0,0 +1,52 @@
+package ga_test
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+var crossovers int64
+
+type Crossed struct {
+	Walk
+}
+
+func (x Crossed) Mutate() ga.Entity {
+	return Crossed{x.Walk.Mutate().(Walk)}
+}
+
+func (x Crossed) Crossover(e ga.Entity, w float64) ga.Entity {
+	atomic.AddInt64(&crossovers, 1)
+	return Crossed{x.Walk.Crossover(e.(Crossed).Walk, w).(Walk)}
+}
+
+func TestOperatorRates(t *testing.T) {
+	g := func() ga.Entity {
+		return Crossed{Walk(10)}
+	}
+	m := ga.New(100, g, ga.WithFixedMutationRate(0.3))
+	m.EvolveTo(10)
+	if pm := m.Adaptation().PM; pm != 0.3 {
+		t.Fatal("fixed:", pm)
+	}
+
+	m = ga.New(100, g, ga.WithMutationBounds(0.01, 0.05))
+	for i := 1; i <= 30; i++ {
+		m.EvolveTo(i)
+		if pm := m.Adaptation().PM; pm < 0.01 || pm > 0.05 {
+			t.Fatal("bounds:", pm)
+		}
+	}
+
+	atomic.StoreInt64(&crossovers, 0)
+	ga.New(100, g, ga.WithCrossoverRate(0)).EvolveTo(5)
+	if n := atomic.LoadInt64(&crossovers); n != 0 {
+		t.Fatal("crossovers at rate 0:", n)
+	}
+	ga.New(100, g, ga.WithCrossoverRate(0.5)).EvolveTo(10)
+	if n := atomic.LoadInt64(&crossovers); n < 400 || n > 600 {
+		t.Fatal("crossovers at rate 0.5:", n)
+	}
+}
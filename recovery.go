@@ -0,0 +1,123 @@
+package ga
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"runtime/debug"
+	"sync/atomic"
+	"time"
+)
+
+// Recovery is the policy on a panic of Fitness, Mutate or Crossover, see WithRecovery.
+type Recovery int
+
+const (
+	// NoRecovery does not recover the panics, the default.
+	NoRecovery Recovery = iota
+	// SkipPanics gives the panicking entity the fitness -Inf, or keeps the parent of the panicking operator.
+	SkipPanics
+	// RegeneratePanics replaces the offspring of the panicking operator by a new entity of the generator.
+	// A panicking entity still gets the fitness -Inf, so it is never selected.
+	RegeneratePanics
+	// AbortOnPanic skips the panics like SkipPanics, and halts the model at the end of the generation.
+	AbortOnPanic
+)
+
+// PanicError is a recovered panic of an entity.
+type PanicError struct {
+	// Value is the value passed to panic.
+	Value interface{}
+	// Stack is the stack trace of the panic.
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprint("panic: ", e.Value)
+}
+
+// WithRecovery recovers the panics of Fitness, Mutate and Crossover by the policy r,
+// so a single panicking entity does not crash the model.
+// The recovered panics are reported by Err as OperatorError, whose Err is a PanicError.
+func WithRecovery(r Recovery) Option {
+	return func(m *GA) {
+		m.recovery = r
+	}
+}
+
+// WithEvaluationTimeout bounds the time of each evaluation of an entity implementing FitnessContext by d.
+// Its context is done after d, and then it gets the fitness -Inf, and the timeout is reported by Err.
+func WithEvaluationTimeout(d time.Duration) Option {
+	return func(m *GA) {
+		m.etimeout = d
+	}
+}
+
+// recovered records the recovered panic v of the operation op, and reports whether there is one.
+func (m *GA) recovered(op string, v interface{}) bool {
+	if v == nil {
+		return false
+	}
+	m.fail(op, &PanicError{v, debug.Stack()})
+	if m.recovery == AbortOnPanic {
+		atomic.StoreInt32(&m.aborted, 1)
+	}
+	return true
+}
+
+// guarded returns the fitness by f, or -Inf on a recovered panic.
+func (m *GA) guarded(f func() float64) (v float64) {
+	if m.recovery == NoRecovery {
+		return f()
+	}
+	defer func() {
+		if m.recovered("fitness", recover()) {
+			v = math.Inf(-1)
+		}
+	}()
+	return f()
+}
+
+// operate returns the result of the operation op by f, or the fallback of the policy on a recovered panic.
+func (m *GA) operate(op string, fallback Entity, f func() Entity) (z Entity) {
+	if m.invariants != nil {
+		defer func() {
+			m.inspect(op, z)
+		}()
+	}
+	if m.recovery == NoRecovery {
+		return f()
+	}
+	defer func() {
+		if !m.recovered(op, recover()) {
+			return
+		}
+		z = fallback
+		if m.recovery == RegeneratePanics {
+			z = m.g()
+		}
+	}()
+	return f()
+}
+
+// timed returns the fitness of c within the evaluation timeout.
+func (m *GA) timed(ctx context.Context, c FitnessContext) float64 {
+	if m.etimeout <= 0 {
+		return c.FitnessContext(ctx)
+	}
+	ctx, cancel := context.WithTimeout(ctx, m.etimeout)
+	defer cancel()
+	f := c.FitnessContext(ctx)
+	if ctx.Err() == context.DeadlineExceeded {
+		m.fail("fitness", ctx.Err())
+		return math.Inf(-1)
+	}
+	return f
+}
+
+// abort halts the model if a panic has been recovered by AbortOnPanic.
+func (m *GA) abort() {
+	if atomic.CompareAndSwapInt32(&m.aborted, 1, 0) {
+		m.halt = true
+	}
+}
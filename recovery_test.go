@@ -0,0 +1,90 @@
+package ga_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/ofunc/ga"
+)
+
+type Fragile float64
+
+func (x Fragile) Fitness() float64 {
+	if x < 0 {
+		panic("negative")
+	}
+	return -sqr(float64(x) - 1)
+}
+
+func (x Fragile) Mutate() ga.Entity {
+	if rand.Intn(10) == 0 {
+		panic("mutate")
+	}
+	return x + Fragile(rand.Float64()-0.5)
+}
+
+func (x Fragile) Crossover(e ga.Entity, w float64) ga.Entity {
+	return Fragile(w*float64(x) + (1-w)*float64(e.(Fragile)))
+}
+
+func TestRecovery(t *testing.T) {
+	for _, r := range []ga.Recovery{ga.SkipPanics, ga.RegeneratePanics} {
+		m := ga.New(50, func() ga.Entity {
+			return Fragile(rand.Float64()*10 - 5)
+		}, ga.WithRecovery(r))
+		m.Evolve(100, 1000)
+		if m.Fitness() < -0.01 {
+			t.Fatal("fitness:", r, m.Fitness())
+		}
+		var p *ga.PanicError
+		if es, ok := m.Err().(ga.Errors); !ok || !errors.As(es[0], &p) {
+			t.Fatal("err:", r, es)
+		}
+	}
+	m := ga.New(50, func() ga.Entity {
+		return Fragile(rand.Float64()*10 - 5)
+	}, ga.WithRecovery(ga.AbortOnPanic))
+	m.Evolve(100, 1000)
+	if g := m.Generation(); g != 1 {
+		t.Fatal("generation:", g)
+	}
+}
+
+type Stalling float64
+
+func (x Stalling) Fitness() float64 {
+	return float64(x)
+}
+
+func (x Stalling) FitnessContext(ctx context.Context) float64 {
+	if x < 0 {
+		<-ctx.Done()
+	}
+	return float64(x)
+}
+
+func (x Stalling) Mutate() ga.Entity {
+	return x
+}
+
+func (x Stalling) Crossover(e ga.Entity, w float64) ga.Entity {
+	return x
+}
+
+func TestEvaluationTimeout(t *testing.T) {
+	k := 0
+	m := ga.New(10, func() ga.Entity {
+		k++
+		return Stalling(k - 5)
+	}, ga.WithEvaluationTimeout(time.Millisecond), ga.WithSequentialEval())
+	if f := m.Stats().Worst; !math.IsInf(f, -1) {
+		t.Fatal("worst:", f)
+	}
+	if m.Err() == nil {
+		t.Fatal("no timeout")
+	}
+}
@@ -0,0 +1,178 @@
+package ga
+
+import "reflect"
+
+// WithRecycle sets the hook f, which is called after every generation with each entity of the discarded generation,
+// e.g. to put its genome back into a sync.Pool for the operators to reuse.
+// An entity is discarded if it is neither in the current population, nor retained by the model,
+// e.g. the elite, the hall of fame, the history, the MAP-Elites archive, the Pareto front or the lineage,
+// compared by ==, so only the entities of comparable types, e.g. pointers, are recycled.
+// f must not recycle the entities retained elsewhere, e.g. by Population, ParetoFront or the observers.
+// The entities implementing MutableEntity are reused by the model itself instead, and not passed to f.
+func WithRecycle(f func(Entity)) Option {
+	return func(m *GA) {
+		m.recycler.f = f
+	}
+}
+
+// Releaser is an optional interface of Entity, which holds resources beyond the memory, e.g. GPU buffers or file handles.
+// Release is called once on each entity of comparable type when it is discarded, as decided for WithRecycle,
+// before the entity is passed to the hook of WithRecycle, if any.
+// The entities replaced outside the generations, e.g. by the immigrants, Reshape or Reset, are released by the next generation.
+// The entities implementing MutableEntity are reused by the model instead, and not released,
+// and the entities shared by Clone are never released, since the other model may still have them.
+type Releaser interface {
+	Release()
+}
+
+type recycler struct {
+	f       func(Entity)
+	release bool
+	// live is the entities retained by the model, and kept is the ones retained at the previous generation.
+	live    map[Entity]bool
+	kept    map[Entity]bool
+	spare   []Entity
+	dropped []Entity
+	// shared is the entities shared with a clone, which are neither released nor recycled.
+	shared map[Entity]bool
+}
+
+// recycle collects the discarded entities: the ones in the buffer of the next generation, the dropped ones,
+// and the ones retained at the previous generation but not any more, e.g. a replaced elite, and clears them.
+// The mutable ones are kept as spares for the slots of the next generation, and the others are released and passed to the hook.
+func (m *GA) recycle() {
+	r := &m.recycler
+	if r.live == nil {
+		r.live, r.kept = make(map[Entity]bool), make(map[Entity]bool)
+	}
+	m.retain(r.live)
+	r.spare = r.spare[:0]
+	discard := func(e Entity) {
+		if _, ok := r.live[e]; ok || !comparable(e) {
+			return
+		}
+		r.live[e] = false
+		if r.shared[e] {
+			delete(r.shared, e)
+			return
+		}
+		if _, ok := e.(MutableEntity); ok {
+			r.spare = append(r.spare, e)
+			return
+		}
+		if x, ok := e.(Releaser); ok {
+			x.Release()
+		}
+		if r.f != nil {
+			r.f(e)
+		}
+	}
+	for i, e := range m.tentities {
+		m.tentities[i] = nil
+		discard(e)
+	}
+	for i, e := range r.dropped {
+		r.dropped[i] = nil
+		discard(e)
+	}
+	r.dropped = r.dropped[:0]
+	for e := range r.kept {
+		discard(e)
+		delete(r.kept, e)
+	}
+	for e, ok := range r.live {
+		if !ok {
+			delete(r.live, e)
+		}
+	}
+	r.live, r.kept = r.kept, r.live
+}
+
+// shareWith marks the entities shared by m and its clone c, so that neither of them releases or recycles an entity the other still has.
+func (m *GA) shareWith(c *GA) {
+	for _, e := range append(c.pop.entities[:c.n:c.n], c.elite) {
+		if _, ok := e.(MutableEntity); ok || !comparable(e) {
+			continue
+		}
+		for _, x := range []*GA{m, c} {
+			if x.recycler.shared == nil {
+				x.recycler.shared = make(map[Entity]bool)
+			}
+			x.recycler.shared[e] = true
+		}
+	}
+}
+
+// recycling reports whether the discarded entities are collected by recycle.
+func (m *GA) recycling() bool {
+	return m.recycler.f != nil || m.recycler.release || m.mutable
+}
+
+// drop collects the entities es, which are replaced outside the buffers of the generations, e.g. by the immigrants,
+// to be recycled with the discarded ones by the next generation.
+func (m *GA) drop(es ...Entity) {
+	if m.recycling() {
+		m.recycler.dropped = append(m.recycler.dropped, es...)
+	}
+}
+
+// dropAt collects the entities of the population at idx, which are about to be replaced.
+func (m *GA) dropAt(idx []int) {
+	if m.recycling() {
+		for _, i := range idx {
+			m.recycler.dropped = append(m.recycler.dropped, m.pop.entities[i])
+		}
+	}
+}
+
+// retain marks the entities retained by the GA model in live:
+// the population, the elite and the entities kept by the enabled features, e.g. the hall of fame or the MAP-Elites archive.
+func (m *GA) retain(live map[Entity]bool) {
+	mark := func(es ...Entity) {
+		for _, e := range es {
+			if comparable(e) {
+				live[e] = true
+			}
+		}
+	}
+	mark(m.pop.entities...)
+	mark(m.elite, m.published, m.solution)
+	if m.fame != nil {
+		mark(m.fame.es...)
+	}
+	if m.history != nil {
+		for _, x := range m.history.records {
+			mark(x.Elite)
+		}
+	}
+	if m.archive != nil {
+		mark(m.archive.entities...)
+	}
+	if m.noise != nil {
+		mark(m.noise.elite)
+	}
+	if m.species != nil {
+		mark(m.species.reps...)
+	}
+	if m.mo != nil {
+		mark(m.mo.front...)
+	}
+	if m.parchive != nil {
+		mark(m.parchive.current.Load().es...)
+	}
+	if m.nearby != nil {
+		mark(m.nearby.seeds...)
+	}
+	if m.lineage != nil {
+		m.lineage.mutex.Lock()
+		for e, o := range m.lineage.origins {
+			mark(e)
+			mark(o.Parents...)
+		}
+		m.lineage.mutex.Unlock()
+	}
+}
+
+func comparable(e Entity) bool {
+	return e != nil && reflect.TypeOf(e).Comparable()
+}
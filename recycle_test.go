@@ -0,0 +1,237 @@
+package ga_test
+
+import (
+	"math/rand"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+// Boxed is a pointer to Walk.
+type Boxed struct {
+	W Walk
+}
+
+func (b *Boxed) Fitness() float64 {
+	return b.W.Fitness()
+}
+
+func (b *Boxed) Mutate() ga.Entity {
+	return &Boxed{b.W.Mutate().(Walk)}
+}
+
+func (b *Boxed) Crossover(e ga.Entity, w float64) ga.Entity {
+	return &Boxed{b.W.Crossover(e.(*Boxed).W, w).(Walk)}
+}
+
+func TestRecycle(t *testing.T) {
+	recycled := make(map[*Boxed]bool)
+	m := ga.New(20, func() ga.Entity {
+		return &Boxed{Walk(rand.Float64())}
+	}, ga.WithRecycle(func(e ga.Entity) {
+		b := e.(*Boxed)
+		if recycled[b] {
+			t.Fatal("recycled twice:", b)
+		}
+		recycled[b] = true
+	}), ga.WithHallOfFame(3))
+	for i := 0; i < 10; i++ {
+		m.Next()
+		for _, e := range m.Population() {
+			if recycled[e.(*Boxed)] {
+				t.Fatal("live entity recycled:", e)
+			}
+		}
+		if recycled[m.Elite().(*Boxed)] {
+			t.Fatal("elite recycled")
+		}
+		es, _ := m.HallOfFame()
+		for _, e := range es {
+			if recycled[e.(*Boxed)] {
+				t.Fatal("famous entity recycled")
+			}
+		}
+	}
+	if len(recycled) < 100 {
+		t.Fatal("recycled:", len(recycled))
+	}
+}
+
+var allocs int32
+
+// Buffer is a large genome with in-place operators, minimized at all ones.
+type Buffer struct {
+	X []float64
+}
+
+func newBuffer(dst ga.Entity) *Buffer {
+	if b, ok := dst.(*Buffer); ok {
+		return b
+	}
+	atomic.AddInt32(&allocs, 1)
+	return &Buffer{make([]float64, 64)}
+}
+
+func (b *Buffer) Fitness() float64 {
+	f := 0.0
+	for _, x := range b.X {
+		f -= sqr(x - 1)
+	}
+	return f
+}
+
+func (b *Buffer) Mutate() ga.Entity {
+	panic("not called")
+}
+
+func (b *Buffer) Crossover(e ga.Entity, w float64) ga.Entity {
+	panic("not called")
+}
+
+func (b *Buffer) CloneInto(dst ga.Entity) ga.Entity {
+	c := newBuffer(dst)
+	copy(c.X, b.X)
+	return c
+}
+
+func (b *Buffer) MutateInPlace() {
+	b.X[rand.Intn(len(b.X))] += rand.NormFloat64() / 4
+}
+
+func (b *Buffer) CrossoverInto(dst ga.Entity, e ga.Entity, w float64) ga.Entity {
+	c, o := newBuffer(dst), e.(*Buffer)
+	for i := range c.X {
+		c.X[i] = w*b.X[i] + (1-w)*o.X[i]
+	}
+	return c
+}
+
+// Handle is a Boxed holding a resource, which counts its releases.
+type Handle struct {
+	Boxed
+	released *int32
+}
+
+func (h *Handle) Release() {
+	atomic.AddInt32(h.released, 1)
+}
+
+func (h *Handle) Mutate() ga.Entity {
+	return &Handle{*h.Boxed.Mutate().(*Boxed), h.released}
+}
+
+func (h *Handle) Crossover(e ga.Entity, w float64) ga.Entity {
+	return &Handle{*h.Boxed.Crossover(&e.(*Handle).Boxed, w).(*Boxed), h.released}
+}
+
+func TestReleaser(t *testing.T) {
+	var released int32
+	m := ga.New(20, func() ga.Entity {
+		return &Handle{Boxed{Walk(rand.Float64())}, &released}
+	})
+	m.EvolveTo(10)
+	if n := atomic.LoadInt32(&released); n < 150 || n > 200 {
+		t.Fatal("released:", n)
+	}
+}
+
+func TestMutableEntity(t *testing.T) {
+	m := ga.New(20, func() ga.Entity {
+		b := newBuffer(nil)
+		for i := range b.X {
+			b.X[i] = rand.Float64()
+		}
+		return b
+	}, ga.WithFixedMutationRate(1))
+	before := m.Fitness()
+	atomic.StoreInt32(&allocs, 0)
+	for i := 0; i < 50; i++ {
+		m.Next()
+	}
+	if n := atomic.LoadInt32(&allocs); n > 3*20 {
+		t.Fatal("allocations:", n)
+	}
+	if m.Fitness() < before+1 {
+		t.Fatal("fitness:", before, m.Fitness())
+	}
+	if f := m.Elite().Fitness(); f != m.Fitness() {
+		t.Fatal("elite overwritten:", f, m.Fitness())
+	}
+}
+
+func TestRecycleMapElites(t *testing.T) {
+	recycled := make(map[*Boxed]bool)
+	m := ga.New(20, func() ga.Entity {
+		return &Boxed{Walk(rand.Float64())}
+	}, ga.WithRecycle(func(e ga.Entity) {
+		recycled[e.(*Boxed)] = true
+	}), ga.WithMapElites(func(e ga.Entity) []float64 {
+		return []float64{float64(e.(*Boxed).W)}
+	}, []float64{-5}, []float64{5}, 10))
+	for i := 0; i < 20; i++ {
+		m.Next()
+		for _, c := range m.Archive() {
+			if recycled[c.Entity.(*Boxed)] {
+				t.Fatal("archived entity recycled:", c.Entity)
+			}
+		}
+	}
+	if len(recycled) == 0 {
+		t.Fatal("nothing recycled")
+	}
+}
+
+// Lease is a Boxed recording its release.
+type Lease struct {
+	Boxed
+	released int
+}
+
+func (l *Lease) Release() {
+	l.released++
+}
+
+func (l *Lease) Mutate() ga.Entity {
+	return &Lease{Boxed: *l.Boxed.Mutate().(*Boxed)}
+}
+
+func (l *Lease) Crossover(e ga.Entity, w float64) ga.Entity {
+	return &Lease{Boxed: *l.Boxed.Crossover(&e.(*Lease).Boxed, w).(*Boxed)}
+}
+
+func TestReleaseDropped(t *testing.T) {
+	g := func() ga.Entity {
+		return &Lease{Boxed: Boxed{Walk(rand.Float64())}}
+	}
+	m := ga.New(20, g, ga.WithRandomImmigrants(0.2), ga.WithHallOfFame(3))
+	seen := make(map[*Lease]bool)
+	live := func() map[*Lease]bool {
+		es, _ := m.HallOfFame()
+		ls := map[*Lease]bool{m.Elite().(*Lease): true}
+		for _, e := range append(es, m.Population()...) {
+			ls[e.(*Lease)] = true
+		}
+		for l := range ls {
+			if l.released > 0 {
+				t.Fatal("retained entity released:", l)
+			}
+			seen[l] = true
+		}
+		return ls
+	}
+	for i := 0; i < 10; i++ {
+		m.Next()
+		live()
+		if i == 5 {
+			m.Reshape(g, nil, 1)
+			live()
+		}
+	}
+	ls := live()
+	for l := range seen {
+		if !ls[l] && l.released != 1 {
+			t.Fatal("released:", l, l.released)
+		}
+	}
+}
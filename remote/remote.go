@@ -0,0 +1,206 @@
+// Package remote dispatches the fitness evaluations of GA models to worker processes over HTTP,
+// so that the master only runs the selection and the bookkeeping.
+//
+// A worker serves Handler, and the master evaluates by an Evaluator set by ga.WithBatchEvaluator.
+// The entities are serialized by a user-supplied Codec, e.g. one registered by ga.RegisterCodec.
+package remote
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"runtime"
+	"sync"
+
+	"github.com/ofunc/ga"
+)
+
+// Codec serializes the entities between the master and the workers,
+// which is the same as ga.Codec, so a codec returned by ga.LookupCodec can be used.
+type Codec = ga.Codec
+
+// maxBody is the default limit of the size of the request bodies of Handler.
+const maxBody = 64 << 20
+
+// HandlerOption is an option of Handler.
+type HandlerOption func(*handler)
+
+type handler struct {
+	max int64
+}
+
+// WithMaxBody limits the size of the request bodies to n bytes, default to 64 MiB.
+func WithMaxBody(n int64) HandlerOption {
+	return func(h *handler) {
+		h.max = n
+	}
+}
+
+// Handler returns the HTTP handler of a worker, which decodes a batch of entities by c,
+// and replies their fitnesses, evaluated concurrently by Fitness.
+// A request body larger than the limit set by WithMaxBody is rejected,
+// and an entity whose Fitness panics gets the fitness -Inf, so the worker survives it.
+func Handler(c Codec, opts ...HandlerOption) http.Handler {
+	h := &handler{max: maxBody}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var bs [][]byte
+		if err := gob.NewDecoder(http.MaxBytesReader(w, r.Body, h.max)).Decode(&bs); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		es := make([]ga.Entity, len(bs))
+		for i, b := range bs {
+			e, err := c.Decode(b)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			es[i] = e
+		}
+		fs := make([]float64, len(es))
+		sem := make(chan struct{}, runtime.GOMAXPROCS(0))
+		var wg sync.WaitGroup
+		for i, e := range es {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(i int, e ga.Entity) {
+				defer func() {
+					if recover() != nil {
+						fs[i] = math.Inf(-1)
+					}
+					<-sem
+					wg.Done()
+				}()
+				fs[i] = e.Fitness()
+			}(i, e)
+		}
+		wg.Wait()
+		w.Header().Set("Content-Type", "application/octet-stream")
+		gob.NewEncoder(w).Encode(fs)
+	})
+}
+
+// Evaluator is a ga.BatchEvaluator, which splits every batch evenly among the workers,
+// and posts the parts to them concurrently.
+// A part failed on a worker is retried on the others in turn,
+// and its entities get the fitness -Inf if it fails on all of them.
+type Evaluator struct {
+	codec  Codec
+	urls   []string
+	client *http.Client
+	mutex  sync.Mutex
+	err    error
+}
+
+// Option is an option of Evaluator.
+type Option func(*Evaluator)
+
+// WithClient sets the HTTP client, default to http.DefaultClient.
+func WithClient(c *http.Client) Option {
+	return func(x *Evaluator) {
+		x.client = c
+	}
+}
+
+// NewEvaluator creates an evaluator posting to the workers at urls, which serve Handler with a compatible codec.
+func NewEvaluator(c Codec, urls []string, opts ...Option) *Evaluator {
+	x := &Evaluator{codec: c, urls: urls, client: http.DefaultClient}
+	for _, opt := range opts {
+		opt(x)
+	}
+	return x
+}
+
+// Evaluate evaluates the fitnesses of es into fs by the workers.
+func (x *Evaluator) Evaluate(es []ga.Entity, fs []float64) {
+	bs := make([][]byte, len(es))
+	for i, e := range es {
+		b, err := x.codec.Encode(e)
+		if err != nil {
+			x.fail(err)
+			for i := range fs {
+				fs[i] = math.Inf(-1)
+			}
+			return
+		}
+		bs[i] = b
+	}
+	n := len(x.urls)
+	if n == 0 {
+		x.fail(errors.New("remote: no workers"))
+		for i := range fs {
+			fs[i] = math.Inf(-1)
+		}
+		return
+	}
+	var wg sync.WaitGroup
+	for w := 0; w < n; w++ {
+		i, j := w*len(es)/n, (w+1)*len(es)/n
+		if i == j {
+			continue
+		}
+		wg.Add(1)
+		go func(w, i, j int) {
+			defer wg.Done()
+			var err error
+			for k := 0; k < n; k++ {
+				if err = x.post(x.urls[(w+k)%n], bs[i:j], fs[i:j]); err == nil {
+					return
+				}
+			}
+			x.fail(err)
+			for k := i; k < j; k++ {
+				fs[k] = math.Inf(-1)
+			}
+		}(w, i, j)
+	}
+	wg.Wait()
+}
+
+// Err returns the last error, which is cleared then.
+func (x *Evaluator) Err() error {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+	err := x.err
+	x.err = nil
+	return err
+}
+
+func (x *Evaluator) fail(err error) {
+	x.mutex.Lock()
+	x.err = err
+	x.mutex.Unlock()
+}
+
+// post posts the encoded entities bs to the worker at url, and decodes the fitnesses into fs.
+func (x *Evaluator) post(url string, bs [][]byte, fs []float64) error {
+	var body bytes.Buffer
+	if err := gob.NewEncoder(&body).Encode(bs); err != nil {
+		return err
+	}
+	resp, err := x.client.Post(url, "application/octet-stream", &body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("remote: %s: %s: %s", url, resp.Status, bytes.TrimSpace(msg))
+	}
+	var rs []float64
+	if err := gob.NewDecoder(resp.Body).Decode(&rs); err != nil {
+		return err
+	}
+	if len(rs) != len(fs) {
+		return fmt.Errorf("remote: %s: %d fitnesses for %d entities", url, len(rs), len(bs))
+	}
+	copy(fs, rs)
+	return nil
+}
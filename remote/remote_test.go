@@ -0,0 +1,84 @@
+package remote_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ofunc/ga"
+	"github.com/ofunc/ga/remote"
+	"github.com/ofunc/ga/vector"
+)
+
+type codec struct {
+	s *vector.Space
+}
+
+func (c codec) Encode(e ga.Entity) ([]byte, error) {
+	var b bytes.Buffer
+	err := gob.NewEncoder(&b).Encode(e.(*vector.Vector).X())
+	return b.Bytes(), err
+}
+
+func (c codec) Decode(b []byte) (ga.Entity, error) {
+	var x []float64
+	err := gob.NewDecoder(bytes.NewReader(b)).Decode(&x)
+	return c.s.New(x), err
+}
+
+func TestEvaluator(t *testing.T) {
+	s := vector.NewSpace([]float64{-5, -5}, []float64{5, 5}, func(x []float64) float64 {
+		return -x[0]*x[0] - x[1]*x[1]
+	})
+	good := httptest.NewServer(remote.Handler(codec{s}))
+	defer good.Close()
+	bad := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "down", http.StatusServiceUnavailable)
+	}))
+	defer bad.Close()
+
+	x := remote.NewEvaluator(codec{s}, []string{good.URL, bad.URL})
+	m := ga.New(40, s.Random, ga.WithBatchEvaluator(x))
+	_, f, _ := m.Evolve(20, 100)
+	if err := x.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if f < -1e-2 {
+		t.Fatal("fitness:", f)
+	}
+
+	x = remote.NewEvaluator(codec{s}, []string{bad.URL})
+	es, fs := []ga.Entity{s.Random(), s.Random()}, make([]float64, 2)
+	x.Evaluate(es, fs)
+	if !math.IsInf(fs[0], -1) || !math.IsInf(fs[1], -1) || x.Err() == nil {
+		t.Fatal("failure:", fs)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	s := vector.NewSpace([]float64{-5, -5}, []float64{5, 5}, func(x []float64) float64 {
+		if x[0] == 3 {
+			panic("bad entity")
+		}
+		return -x[0]*x[0] - x[1]*x[1]
+	})
+	worker := httptest.NewServer(remote.Handler(codec{s}))
+	defer worker.Close()
+	x := remote.NewEvaluator(codec{s}, []string{worker.URL})
+	es, fs := []ga.Entity{s.New([]float64{1, 0}), s.New([]float64{3, 0})}, make([]float64, 2)
+	x.Evaluate(es, fs)
+	if fs[0] != -1 || !math.IsInf(fs[1], -1) || x.Err() != nil {
+		t.Fatal("panic:", fs)
+	}
+
+	small := httptest.NewServer(remote.Handler(codec{s}, remote.WithMaxBody(16)))
+	defer small.Close()
+	x = remote.NewEvaluator(codec{s}, []string{small.URL})
+	x.Evaluate(es, fs)
+	if !math.IsInf(fs[0], -1) || x.Err() == nil {
+		t.Fatal("body limit:", fs)
+	}
+}
@@ -0,0 +1,111 @@
+package ga
+
+import "math/rand/v2"
+
+// Replacer forms the next generation from the parents and the offspring, see WithReplacement.
+type Replacer interface {
+	// Replace returns the indices of the n entities of the next generation, chosen from the pool of
+	// the parents with the fitnesses ps followed by the offspring with the fitnesses os,
+	// so the index i is of the parent i if i < len(ps), or of the offspring i-len(ps) otherwise.
+	Replace(ps, os []float64, n int, rnd *rand.Rand) []int
+}
+
+// WithReplacement switches the GA model to produce lambda offspring each generation,
+// and to form the next generation from the parents and the offspring by r.
+// If lambda <= 0, it is the size of the population.
+// By default, the offspring fully replace the parents, the same as GenerationalReplacer.
+func WithReplacement(r Replacer, lambda int) Option {
+	return func(m *GA) {
+		m.replacer, m.lambda = r, lambda
+	}
+}
+
+type generational struct{}
+
+// GenerationalReplacer replaces the parents by the first offspring.
+// If there are less offspring than the parents, the fittest parents fill the rest.
+func GenerationalReplacer() Replacer {
+	return generational{}
+}
+
+func (generational) Replace(ps, os []float64, n int, rnd *rand.Rand) []int {
+	if len(os) < n {
+		return fill(ps, len(os), n)
+	}
+	idx := make([]int, n)
+	for j := range idx {
+		idx[j] = len(ps) + j
+	}
+	return idx
+}
+
+type plus struct {
+	Survivor
+}
+
+// PlusReplacer is the (μ+λ) scheme, which chooses the next generation from the parents and the offspring by s,
+// e.g. TruncationSurvivor for a strongly elitist strategy, or TournamentSurvivor for the tournament replacement.
+func PlusReplacer(s Survivor) Replacer {
+	return plus{s}
+}
+
+func (r plus) Replace(ps, os []float64, n int, rnd *rand.Rand) []int {
+	return r.Survive(append(ps[:len(ps):len(ps)], os...), n, rnd)
+}
+
+type comma struct {
+	Survivor
+}
+
+// CommaReplacer is the (μ,λ) scheme, which chooses the next generation from the offspring only by s,
+// so λ should be greater than the size of the population.
+// If there are less offspring than the parents, all the offspring survive, and the fittest parents fill the rest.
+func CommaReplacer(s Survivor) Replacer {
+	return comma{s}
+}
+
+func (r comma) Replace(ps, os []float64, n int, rnd *rand.Rand) []int {
+	if len(os) <= n {
+		return fill(ps, len(os), n)
+	}
+	idx := r.Survive(os, n, rnd)
+	for j := range idx {
+		idx[j] += len(ps)
+	}
+	return idx
+}
+
+// fill returns the indices of all the k offspring and the fittest n-k parents of the fitnesses ps.
+func fill(ps []float64, k, n int) []int {
+	idx := make([]int, 0, n)
+	for j := 0; j < k; j++ {
+		idx = append(idx, len(ps)+j)
+	}
+	best := TruncationSurvivor().Survive(ps, n-k, nil)
+	return append(idx, best...)
+}
+
+// renew breeds the extra offspring of WithReplacement, and forms the next generation by the replacer.
+func (m *GA) renew() {
+	n, offspring := m.n, m.tentities
+	if m.lambda > n {
+		extra := make([]Entity, m.lambda-n)
+		m.pop.parallel(len(extra), func(c, j int) {
+			i := n + j
+			extra[j] = m.offspring(i%n, m.random(c, i))
+		})
+		offspring = append(offspring[:n:n], extra...)
+	} else if m.lambda > 0 {
+		offspring = offspring[:m.lambda]
+	}
+	fs := make([]float64, len(offspring))
+	m.pop.evaluateInto(offspring, fs)
+	m.drop(offspring...)
+	pool := append(m.pop.entities[:n:n], offspring...)
+	pfs := append(m.pop.fitnesses[:n:n], fs...)
+	for j, i := range m.replacer.Replace(m.pop.fitnesses, fs, n, m.rnd) {
+		m.tentities[j], m.tfitnesses[j] = pool[i], pfs[i]
+	}
+	m.swap()
+	m.normalize()
+}
@@ -0,0 +1,51 @@
+package ga_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+type Counting struct {
+	ga.Replacer
+	ps, os int
+}
+
+func (c *Counting) Replace(ps, os []float64, n int, rnd *rand.Rand) []int {
+	c.ps, c.os = len(ps), len(os)
+	return c.Replacer.Replace(ps, os, n, rnd)
+}
+
+func TestReplacement(t *testing.T) {
+	m := ga.New(20, MIN{}.Mutate, ga.WithReplacement(ga.PlusReplacer(ga.TruncationSurvivor()), 60))
+	prev := m.Stats().Best
+	for i := 0; i < 20; i++ {
+		m.Next()
+		if s := m.Stats(); s.Best < prev || m.Size() != 20 {
+			t.Fatal("plus:", i, s.Best, prev, m.Size())
+		}
+		prev = m.Stats().Best
+	}
+
+	for _, c := range []struct {
+		r      ga.Replacer
+		lambda int
+	}{
+		{ga.CommaReplacer(ga.TournamentSurvivor(2)), 40},
+		{ga.CommaReplacer(ga.TruncationSurvivor()), 10},
+		{ga.GenerationalReplacer(), 0},
+		{ga.GenerationalReplacer(), 30},
+	} {
+		r := &Counting{Replacer: c.r}
+		m := ga.New(20, MIN{}.Mutate, ga.WithReplacement(r, c.lambda))
+		m.EvolveTo(5)
+		lambda := c.lambda
+		if lambda <= 0 {
+			lambda = 20
+		}
+		if r.ps != 20 || r.os != lambda || m.Size() != 20 || len(m.Population()) != 20 {
+			t.Fatal("replacement:", c, r.ps, r.os, m.Size())
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package ga
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Variation is the record of the stochastic decisions which produced an offspring, see Replay.
+type Variation struct {
+	// Generation is the generation of the parents.
+	Generation int
+	// Slot is the index of the offspring in the next generation.
+	Slot int
+	// Draws are the random numbers drawn for the slot, in order.
+	Draws []float64
+	// X and Y are the selected parents, and W is the selection weight passed to the crossover.
+	X, Y Entity
+	W    float64
+	// Crossed and Mutated report whether the crossover and the mutation are applied.
+	Crossed, Mutated bool
+	// Offspring is the offspring produced.
+	Offspring Entity
+}
+
+// Replay reruns the variation of the slot i of the next generation from the current population,
+// and returns the record of its decisions, without changing the population, e.g. to debug why a pathological offspring was created.
+// It requires WithReproducibleParallel, by which the random numbers of a slot depend only on the seed, the generation and the slot,
+// so recording the seed is enough to replay any generation, by a checkpoint saved by Save, or by EvolveTo a fresh model of the same seed.
+// The offspring is the same as the one produced by Next, provided that the operators of the entities are deterministic,
+// and it is not replaced as a duplicate by WithNoDuplicates.
+// The replay has no side effect on the model: the listeners of OnEvent are not called, the lineage is not recorded,
+// and the spares of WithRecycle are not reused, so the offspring is a new copy.
+// Only the generational breeding is supported, not the elites, WithSteadyState, WithDifferentialEvolution,
+// WithDeterministicCrowding, WithMapElites, WithStreaming, WithALPS and WithStochasticUniversalSampling.
+func (m *GA) Replay(i int) (Variation, error) {
+	switch {
+	case !m.streams:
+		return Variation{}, errors.New("ga: replay requires WithReproducibleParallel")
+	case m.steady > 0 || m.de || m.crowding != nil || m.archive != nil || m.streaming != nil || m.alps != nil || m.sus:
+		return Variation{}, errors.New("ga: replay of an unsupported breeding")
+	case i < 0 || i >= m.n:
+		return Variation{}, fmt.Errorf("ga: replay of slot %d out of %d", i, m.n)
+	case i < m.elitism:
+		return Variation{}, fmt.Errorf("ga: replay of slot %d of an elite", i)
+	}
+	v := &Variation{Generation: m.gen, Slot: i}
+	r := m.random(0, i)
+	u := func() float64 {
+		x := r()
+		v.Draws = append(v.Draws, x)
+		return x
+	}
+	if m.ops != nil {
+		m.ops.prepare(&m.pop)
+	}
+	listeners, spare := m.listeners, m.recycler.spare
+	m.replay, m.listeners, m.recycler.spare = v, nil, nil
+	defer func() {
+		m.replay, m.listeners, m.recycler.spare = nil, listeners, spare
+	}()
+	v.Offspring = m.offspring(i, u)
+	return *v, nil
+}
@@ -0,0 +1,66 @@
+package ga_test
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+type Step int
+
+func (s Step) Fitness() float64 {
+	return -sqr(float64(s) - 50)
+}
+
+func (s Step) Mutate() ga.Entity {
+	return s + 1
+}
+
+func (s Step) Crossover(e ga.Entity, w float64) ga.Entity {
+	return Step(w*float64(s) + (1-w)*float64(e.(Step)))
+}
+
+func TestReplay(t *testing.T) {
+	model := func(opts ...ga.Option) *ga.GA {
+		k := 0
+		return ga.New(20, func() ga.Entity {
+			k++
+			return Step(7 * k % 100)
+		}, append(opts, ga.WithSeed(7), ga.WithFixedMutationRate(0.5))...)
+	}
+	m := model(ga.WithReproducibleParallel())
+	m.EvolveTo(3)
+	var events int64
+	m.OnEvent(func(ga.Event) {
+		atomic.AddInt64(&events, 1)
+	})
+	v, err := m.Replay(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if events := atomic.LoadInt64(&events); events != 0 {
+		t.Fatal("events of the replay:", events)
+	}
+	if v.Generation != 3 || v.Slot != 5 || len(v.Draws) == 0 || v.X == nil || v.Y == nil {
+		t.Fatal("variation:", v)
+	}
+	x := model(ga.WithReproducibleParallel())
+	x.EvolveTo(3)
+	if w, err := x.Replay(5); err != nil || w.Offspring != v.Offspring || len(w.Draws) != len(v.Draws) {
+		t.Fatal("replay:", w, v, err)
+	}
+	if e := m.EvolveTo(4)[5]; e != v.Offspring {
+		t.Fatal("offspring:", e, v.Offspring)
+	}
+
+	if _, err := model().Replay(5); err == nil {
+		t.Fatal("replay without reproducible parallel")
+	}
+	if _, err := model(ga.WithReproducibleParallel(), ga.WithElitism(1)).Replay(0); err == nil {
+		t.Fatal("replay of an elite")
+	}
+	if _, err := m.Replay(20); err == nil {
+		t.Fatal("replay out of range")
+	}
+}
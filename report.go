@@ -0,0 +1,68 @@
+package ga
+
+import (
+	"context"
+	"time"
+)
+
+// Termination is the reason why a run of EvolveReport stopped.
+type Termination int
+
+const (
+	// Stagnated is the stop when the elite has not changed for k generations.
+	Stagnated Termination = iota
+	// Exhausted is the stop when max generations have been produced.
+	Exhausted
+	// Halted is the stop when the model has been halted, e.g. by WithCycleDetection or AbortOnPanic.
+	Halted
+	// Canceled is the stop when ctx is done.
+	Canceled
+)
+
+// Report is the result of a run of EvolveReport, for the bookkeeping of experiments.
+type Report struct {
+	// Elite and Fitness are the elite and its fitness at the end of the run, the same as EvolveContext.
+	Elite   Entity
+	Fitness float64
+	// Generations is the number of generations produced by the run.
+	Generations int
+	// Evaluations is the number of fitness evaluations used by the run.
+	Evaluations int64
+	// Duration is the wall time of the run.
+	Duration time.Duration
+	// Termination is the reason why the run stopped.
+	Termination Termination
+	// Trajectory is the fitness of the elite after each generation of the run.
+	Trajectory []float64
+	// Err is the error of ctx if it stopped the run.
+	Err error
+}
+
+// EvolveReport runs the GA model like EvolveContext, and returns a report of the run.
+func (m *GA) EvolveReport(ctx context.Context, k int, max int) Report {
+	start, evals := time.Now(), m.Evaluations()
+	r := m.Generations(ctx, k, max)
+	var trajectory []float64
+	for r.Next() {
+		trajectory = append(trajectory, m.objective(m.fitness))
+	}
+	e, f, ok := r.Result()
+	rep := Report{
+		Elite:       e,
+		Fitness:     f,
+		Generations: r.Generation(),
+		Evaluations: m.Evaluations() - evals,
+		Duration:    time.Since(start),
+		Termination: Exhausted,
+		Trajectory:  trajectory,
+		Err:         r.Err(),
+	}
+	if rep.Err != nil {
+		rep.Termination = Canceled
+	} else if m.halt {
+		rep.Termination = Halted
+	} else if ok {
+		rep.Termination = Stagnated
+	}
+	return rep
+}
@@ -0,0 +1,36 @@
+package ga_test
+
+import (
+	"context"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestEvolveReport(t *testing.T) {
+	g := func() ga.Entity {
+		return Walk(rand.Float64()*4 - 2)
+	}
+	m := ga.New(20, g)
+	r := m.EvolveReport(context.Background(), 1000, 5)
+	if r.Termination != ga.Exhausted || r.Generations != 5 || len(r.Trajectory) != 5 {
+		t.Fatal("exhausted:", r.Termination, r.Generations, r.Trajectory)
+	}
+	if r.Evaluations <= 0 || r.Duration <= 0 || r.Fitness != r.Trajectory[4] || r.Elite != m.Elite() {
+		t.Fatal("report:", r)
+	}
+	for i := 1; i < len(r.Trajectory); i++ {
+		if r.Trajectory[i] < r.Trajectory[i-1] {
+			t.Fatal("trajectory:", r.Trajectory)
+		}
+	}
+	if r := m.EvolveReport(context.Background(), 10, 10000); r.Termination != ga.Stagnated || r.Generations >= 10000 {
+		t.Fatal("stagnated:", r.Termination, r.Generations)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if r := m.EvolveReport(ctx, 10, 100); r.Termination != ga.Canceled || r.Err == nil || r.Generations != 0 {
+		t.Fatal("canceled:", r.Termination, r.Err)
+	}
+}
@@ -0,0 +1,68 @@
+package ga
+
+import "math"
+
+// Reshape notifies the GA model that the search space has changed, e.g. the dimension of the genome has grown
+// by a new warehouse of a routing problem, and moves the population into the new space.
+// The entities are migrated by migrate, which is called concurrently, and may return nil to drop an entity,
+// except the fraction frac of the least fit ones, which are replaced by the new generator g, like the dropped ones.
+// If migrate is nil, all the entities are replaced. If g is nil, the generator of the model is kept.
+// Then the population is evaluated again, the mutation probability restarts like by Reset,
+// and the elite, the hall of fame and the memo are invalidated, since they are of the old space.
+// The other states of the options, e.g. the fitness cache, are kept,
+// so the entities of the two spaces must be distinguished by them, e.g. by Keyer.
+// The generations and the evaluations are still counted since New.
+func (m *GA) Reshape(g func() Entity, migrate func(old Entity) Entity, frac float64) {
+	if g != nil {
+		m.g, m.generator = g, g
+	}
+	m.reshape(g != nil, migrate, frac)
+}
+
+// ReshapeCtx is Reshape with the generator g of the context of the generation, like NewCtx,
+// e.g. to scale the entities of the new space with the progress of the search.
+func (m *GA) ReshapeCtx(g func(GenCtx) Entity, migrate func(old Entity) Entity, frac float64) {
+	if g != nil {
+		withGenerator(g)(m)
+	}
+	m.reshape(g != nil, migrate, frac)
+}
+
+// reshape moves the population into the new space, where the generator is replaced if regenerated.
+func (m *GA) reshape(regenerated bool, migrate func(old Entity) Entity, frac float64) {
+	if regenerated {
+		m.sequential = false
+		if m.invariants != nil {
+			m.checkGenerator()
+		}
+	}
+	k := int(math.Round(math.Max(0, math.Min(1, frac)) * float64(m.n)))
+	if migrate == nil {
+		k = m.n
+	}
+	reset := make([]bool, m.n)
+	for _, i := range order(m.pop.fitnesses)[:k] {
+		reset[i] = true
+	}
+	m.drop(m.pop.entities...)
+	m.spawn(m.n, func(i int) {
+		var e Entity
+		if !reset[i] {
+			e = migrate(m.pop.entities[i])
+		}
+		if e == nil {
+			e = m.g()
+		}
+		m.pop.entities[i] = e
+	})
+
+	m.elite, m.fitness = nil, math.Inf(-1)
+	if m.fame != nil {
+		m.fame.reset()
+	}
+	if m.memo != nil {
+		m.memo.reset()
+	}
+	m.trajectory = m.trajectory[:0]
+	m.reinitialize()
+}
@@ -0,0 +1,89 @@
+package ga_test
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+// Depot is a point of a growing number of dimensions, which should be all 1.
+type Depot struct {
+	X []float64
+}
+
+func (d *Depot) Fitness() float64 {
+	f := 0.0
+	for _, x := range d.X {
+		f -= sqr(x - 1)
+	}
+	return f
+}
+
+func (d *Depot) Mutate() ga.Entity {
+	x := append([]float64(nil), d.X...)
+	x[rand.Intn(len(x))] += rand.NormFloat64()
+	return &Depot{x}
+}
+
+func (d *Depot) Crossover(e ga.Entity, w float64) ga.Entity {
+	y := e.(*Depot).X
+	x := make([]float64, len(d.X))
+	for i := range x {
+		x[i] = w*d.X[i] + (1-w)*y[i]
+	}
+	return &Depot{x}
+}
+
+func depots(n int) func() ga.Entity {
+	return func() ga.Entity {
+		x := make([]float64, n)
+		for i := range x {
+			x[i] = 4*rand.Float64() - 2
+		}
+		return &Depot{x}
+	}
+}
+
+func TestReshape(t *testing.T) {
+	m := ga.New(40, depots(2), ga.WithHallOfFame(3))
+	m.EvolveTo(10)
+	m.Reshape(depots(3), func(old ga.Entity) ga.Entity {
+		return &Depot{append(append([]float64(nil), old.(*Depot).X...), 0)}
+	}, 0.25)
+	if es, _ := m.HallOfFame(); len(es) > 3 || len(es) > 0 && len(es[0].(*Depot).X) != 3 {
+		t.Fatal("fame:", es)
+	}
+	for _, e := range m.Population() {
+		if len(e.(*Depot).X) != 3 {
+			t.Fatal("not migrated:", e)
+		}
+	}
+	if e := m.Elite().(*Depot); len(e.X) != 3 || m.Fitness() != e.Fitness() {
+		t.Fatal("elite:", e, m.Fitness())
+	}
+	m.EvolveTo(30)
+	if f := m.Fitness(); f < -0.5 {
+		t.Fatal("fitness:", f)
+	}
+}
+
+func TestReshapeCtx(t *testing.T) {
+	m := ga.New(40, depots(2))
+	m.EvolveTo(5)
+	var gens sync.Map
+	m.ReshapeCtx(func(ctx ga.GenCtx) ga.Entity {
+		gens.Store(ctx.Generation, true)
+		return &Depot{[]float64{ctx.Rand(), ctx.Rand(), ctx.Rand()}}
+	}, nil, 0)
+	for _, e := range m.Population() {
+		if len(e.(*Depot).X) != 3 {
+			t.Fatal("not regenerated:", e)
+		}
+	}
+	if _, ok := gens.Load(5); !ok {
+		t.Fatal("generation of the context")
+	}
+	m.EvolveTo(10)
+}
@@ -0,0 +1,36 @@
+package ga
+
+// Restart is the result of a restart of EvolveRestarts.
+type Restart struct {
+	Seed      int64
+	Elite     Entity
+	Fitness   float64
+	Converged bool
+}
+
+// EvolveRestarts runs r independent restarts by Evolve(k, max) in parallel, and returns the overall elite and fitness,
+// with the results of all the restarts. The first restart is this GA model itself,
+// and the others are new models created by New with the same size, generator and options, and the seeds Seed()+i.
+// So a cache set by WithSharedCache is shared by all the restarts,
+// and the options holding mutable state, e.g. BoltzmannSelector, must not be used.
+func (m *GA) EvolveRestarts(r int, k int, max int) (Entity, float64, []Restart) {
+	if r < 1 {
+		r = 1
+	}
+	rs := make([]Restart, r)
+	m.pop.parallel(r, func(c, i int) {
+		x := m
+		if i > 0 {
+			x = New(m.n, m.generator, append(m.opts[:len(m.opts):len(m.opts)], WithSeed(m.seed+int64(i)))...)
+		}
+		e, f, ok := x.Evolve(k, max)
+		rs[i] = Restart{x.seed, e, f, ok}
+	})
+	b := 0
+	for i := range rs {
+		if m.objective(rs[b].Fitness) < m.objective(rs[i].Fitness) {
+			b = i
+		}
+	}
+	return rs[b].Elite, rs[b].Fitness, rs
+}
@@ -0,0 +1,27 @@
+package ga_test
+
+import (
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestEvolveRestarts(t *testing.T) {
+	c := ga.NewCache()
+	m := ga.New(20, Keyed{}.Mutate, ga.WithSharedCache(c), ga.WithSeed(5))
+	e, f, rs := m.EvolveRestarts(4, 20, 100)
+	if len(rs) != 4 {
+		t.Fatal("restarts:", len(rs))
+	}
+	for i, r := range rs {
+		if r.Seed != int64(5+i) || r.Fitness > f || r.Elite.Fitness() != r.Fitness {
+			t.Fatal("restart:", i, r)
+		}
+	}
+	if rs[0].Elite != m.Elite() || e.Fitness() != f {
+		t.Fatal("result:", e, f)
+	}
+	if hits, _ := c.Hits(); hits == 0 {
+		t.Fatal("cache not shared")
+	}
+}
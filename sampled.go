@@ -0,0 +1,76 @@
+package ga
+
+import (
+	"math"
+	"math/rand/v2"
+	"sync"
+)
+
+// NewSampled creates a GA model whose generator is the sampler from a prior distribution,
+// which receives the random source of the model, so the samples are reproduced by WithSeed.
+// The calls of the sampler are serialized, since the random source is not safe for concurrent use,
+// and the populations are sampled in the order of their slots, e.g. the initial one, so they do not depend on the scheduling.
+func NewSampled(n int, sampler func(r *rand.Rand) Entity, opts ...Option) *GA {
+	return newGA(n, nil, nil, append([]Option{withSampler(sampler)}, opts...))
+}
+
+// withSampler sets the generator of the sampler, which is bound to the model it is applied to,
+// so the copies of the model, e.g. by Clone, sample from their own random sources.
+func withSampler(sampler func(r *rand.Rand) Entity) Option {
+	return func(m *GA) {
+		var mutex sync.Mutex
+		m.g = func() Entity {
+			mutex.Lock()
+			defer mutex.Unlock()
+			return sampler(m.rnd)
+		}
+		m.generator, m.sequential = m.g, true
+	}
+}
+
+// WithSeedNeighborhoods builds the first round(frac*n) entities of the initial population near the seeds,
+// each of which is a seed mutated radius times, instead of the seed itself,
+// and the generator generates the rest. The seeds are drawn in proportion to the weights,
+// stratified so each seed gets its share exactly up to rounding, or uniformly if weights is nil.
+// It takes precedence over WithSecondaryGenerator, and frac is clamped to [0, 1].
+func WithSeedNeighborhoods(seeds []Entity, weights []float64, radius int, frac float64) Option {
+	return func(m *GA) {
+		if weights == nil {
+			weights = make([]float64, len(seeds))
+			for i := range weights {
+				weights[i] = 1
+			}
+		}
+		m.nearby = &neighborhoods{seeds, weights, radius, frac}
+	}
+}
+
+type neighborhoods struct {
+	seeds   []Entity
+	weights []float64
+	radius  int
+	frac    float64
+}
+
+// size returns the number of the entities near the seeds in a population of n.
+func (s *neighborhoods) size(n int) int {
+	if len(s.seeds) == 0 {
+		return 0
+	}
+	return int(math.Round(math.Max(0, math.Min(1, s.frac)) * float64(n)))
+}
+
+// near returns the entity i of k near the seeds, whose seed is at the quantile (i+0.5)/k of the weights.
+func (s *neighborhoods) near(i, k int) Entity {
+	q, j := (float64(i)+0.5)/float64(k)*sum(s.weights), 0
+	for ; j < len(s.seeds)-1; j++ {
+		if q -= s.weights[j]; q < 0 {
+			break
+		}
+	}
+	e := s.seeds[j]
+	for r := 0; r < s.radius; r++ {
+		e = e.Mutate()
+	}
+	return e
+}
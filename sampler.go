@@ -0,0 +1,62 @@
+package ga
+
+import "math/rand/v2"
+
+// Sampler draws entities in proportion to their selection weights in O(1), by the alias method.
+// It is a snapshot of the population at its creation, which is not affected by later generations.
+type Sampler struct {
+	entities []Entity
+	probs    []float64
+	aliases  []int
+}
+
+// NewSampler creates a sampler from the current selection weights.
+func (m *GA) NewSampler() *Sampler {
+	return m.pop.NewSampler()
+}
+
+// NewSampler creates a sampler from the current selection weights.
+func (p *Population) NewSampler() *Sampler {
+	n := len(p.entities)
+	s := &Sampler{
+		entities: append([]Entity(nil), p.entities...),
+		probs:    make([]float64, n),
+		aliases:  make([]int, n),
+	}
+	var small, large []int
+	for i, w := range p.weights {
+		s.probs[i] = w * float64(n) / p.fsum
+		if s.probs[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+	for len(small) > 0 && len(large) > 0 {
+		l, g := small[len(small)-1], large[len(large)-1]
+		small = small[:len(small)-1]
+		s.aliases[l] = g
+		if s.probs[g] += s.probs[l] - 1; s.probs[g] < 1 {
+			large = large[:len(large)-1]
+			small = append(small, g)
+		}
+	}
+	for _, i := range append(small, large...) {
+		s.probs[i], s.aliases[i] = 1, i
+	}
+	return s
+}
+
+// Index draws the index of an entity.
+func (s *Sampler) Index(r *rand.Rand) int {
+	i := r.IntN(len(s.probs))
+	if r.Float64() < s.probs[i] {
+		return i
+	}
+	return s.aliases[i]
+}
+
+// Sample draws an entity.
+func (s *Sampler) Sample(r *rand.Rand) Entity {
+	return s.entities[s.Index(r)]
+}
@@ -0,0 +1,35 @@
+package ga_test
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestSampler(t *testing.T) {
+	es := []ga.Entity{MIN{0, 0}, MIN{1, 0}, MIN{1, 1}, MIN{2, 2}, MIN{3, 0}}
+	p := ga.NewPopulation(es)
+	p.Evaluate()
+	s := p.NewSampler()
+
+	const k = 200000
+	r := rand.New(rand.NewPCG(1, 1))
+	counts := make([]int, len(es))
+	for i := 0; i < k; i++ {
+		counts[s.Index(r)]++
+	}
+	wsum := 0.0
+	for i := range es {
+		wsum += p.Weight(i)
+	}
+	for i, c := range counts {
+		if q := p.Weight(i) / wsum; math.Abs(float64(c)/k-q) > 0.01 {
+			t.Fatal("frequency:", i, float64(c)/k, q)
+		}
+	}
+	if e := s.Sample(r); e == nil {
+		t.Fatal("sample")
+	}
+}
@@ -0,0 +1,70 @@
+package ga
+
+import (
+	"math"
+)
+
+// Scaling is the scaling of the fitnesses into selection weights.
+type Scaling int
+
+const (
+	// SigmoidScaling weighs by the logistic function of the standardized fitness, the default.
+	// It saturates when the fitnesses differ by many standard deviations.
+	SigmoidScaling Scaling = iota
+	// SigmaTruncation weighs by the excess of the fitness over two standard deviations below the mean,
+	// relative to the excess of the best.
+	SigmaTruncation
+	// LinearScaling weighs linearly between the worst and the best fitness.
+	LinearScaling
+	// RankScaling weighs by the rank of the fitness, ties sharing their mean rank.
+	// The selection pressure does not depend on the distribution of the fitnesses.
+	RankScaling
+)
+
+// WithScaling sets the scaling of the fitnesses into selection weights.
+func WithScaling(s Scaling) Option {
+	return func(m *GA) {
+		m.pop.scaling = s
+	}
+}
+
+// scaler returns the weight of a fitness in fs, with the mean and the standard deviation.
+func (p *Population) scaler(fs []float64, mean, std float64) func(float64) float64 {
+	switch p.scaling {
+	case SigmaTruncation, LinearScaling:
+		lo, hi := math.Inf(1), math.Inf(-1)
+		for _, f := range fs {
+			lo, hi = math.Min(lo, f), math.Max(hi, f)
+		}
+		if p.scaling == SigmaTruncation {
+			lo = math.Max(lo, mean-2*std)
+		}
+		if hi <= lo {
+			return func(float64) float64 { return 1 }
+		}
+		return func(f float64) float64 {
+			return math.Max(0, f-lo) / (hi - lo)
+		}
+	default:
+		return func(f float64) float64 {
+			return 1 / (1 + math.Exp((mean-f)/std))
+		}
+	}
+}
+
+// rank sets the weights to the ranks of fs, from 1/n for the worst to 1 for the best.
+func (p *Population) rank(fs []float64) {
+	n, is := len(fs), order(fs)
+	for i := 0; i < n; {
+		j := i + 1
+		for j < n && fs[is[j]] == fs[is[i]] {
+			j++
+		}
+		w := float64(i+j+1) / float64(2*n)
+		for _, k := range is[i:j] {
+			p.weights[k] = w
+		}
+		i = j
+	}
+	p.fsum = float64(n+1) / 2
+}
@@ -0,0 +1,41 @@
+package ga_test
+
+import (
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestScaling(t *testing.T) {
+	for _, s := range []ga.Scaling{ga.SigmoidScaling, ga.SigmaTruncation, ga.LinearScaling, ga.RankScaling} {
+		var mutex sync.Mutex
+		var records [][3]float64
+		k := 0
+		m := ga.New(20, func() ga.Entity {
+			k++
+			if k == 20 {
+				return Weighed{1e9, &mutex, &records}
+			}
+			return Weighed{k, &mutex, &records}
+		}, ga.WithScaling(s))
+		m.Next()
+		spread := 0.0
+		for _, r := range records {
+			x, y, w := r[0], r[1], r[2]
+			if x != y && (x > y) != (w > 0.5) {
+				t.Fatal("order:", s, r)
+			}
+			if x < 20 && y < 20 {
+				spread = math.Max(spread, math.Abs(w-0.5))
+			}
+			if s == ga.RankScaling && x < 20 && y < 20 && math.Abs(w-x/(x+y)) > 1e-9 {
+				t.Fatal("rank:", r)
+			}
+		}
+		if s == ga.SigmoidScaling && spread > 0.01 || s == ga.RankScaling && spread < 0.1 {
+			t.Fatal("spread:", s, spread)
+		}
+	}
+}
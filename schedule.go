@@ -0,0 +1,53 @@
+package ga
+
+import (
+	"math"
+	"sync/atomic"
+)
+
+// Schedule is the parameters of the breeding of a generation, see WithSchedule.
+type Schedule struct {
+	// PM is the mutation probability, which is not clamped to the bounds set by WithMutationBounds.
+	PM float64
+	// PC is the crossover probability, see WithCrossoverRate.
+	PC float64
+	// Pressure is the exponent of the selection weights, where 1 keeps them as they are,
+	// the larger favor the fitter entities more, and 0 selects uniformly.
+	Pressure float64
+}
+
+// WithSchedule controls the parameters by the schedule f instead of the adaptation,
+// e.g. to start explorative and end exploitative.
+// f is called with the generation and the evaluations of each new population,
+// and returns the parameters for breeding its offspring. All the fields are applied as they are.
+// It takes precedence over WithMutationPolicy, WithFixedMutationRate and WithCrossoverRate.
+func WithSchedule(f func(gen int, evals int64) Schedule) Option {
+	return func(m *GA) {
+		m.schedule = f
+	}
+}
+
+// plan applies the schedule for the current population.
+func (m *GA) plan() {
+	gen := m.gen
+	if m.base > 0 {
+		gen++
+	}
+	s := m.schedule(gen, atomic.LoadInt64(&m.evals))
+	m.pm, m.pc, m.pressure = s.PM, s.PC, math.Max(0, s.Pressure)
+}
+
+// sharpen raises the selection weights to the power k, so the larger k is, the higher the selection pressure is.
+func (p *Population) sharpen(k float64) {
+	p.fsum = 0
+	for i, w := range p.weights {
+		p.weights[i] = math.Pow(w, k)
+		p.fsum += p.weights[i]
+	}
+	if p.fsum == 0 {
+		for i := range p.weights {
+			p.weights[i] = 1
+		}
+		p.fsum = float64(len(p.weights))
+	}
+}
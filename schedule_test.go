@@ -0,0 +1,34 @@
+package ga_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestSchedule(t *testing.T) {
+	var gens []int
+	m := ga.New(30, func() ga.Entity {
+		return Walk(rand.Float64()*4 - 2)
+	}, ga.WithSchedule(func(gen int, evals int64) ga.Schedule {
+		gens = append(gens, gen)
+		r := float64(gen) / 50
+		return ga.Schedule{PM: 0.5 * (1 - r), PC: 1, Pressure: 1 + 4*r}
+	}))
+	if pm := m.MutationRate(); pm != 0.5 {
+		t.Fatal("initial:", pm)
+	}
+	m.EvolveTo(50)
+	if pm := m.MutationRate(); pm != 0 {
+		t.Fatal("final:", pm)
+	}
+	for i, g := range gens {
+		if g != i {
+			t.Fatal("generations:", gens)
+		}
+	}
+	if f := m.Fitness(); f < -1e-2 {
+		t.Fatal("fitness:", f)
+	}
+}
@@ -0,0 +1,27 @@
+package ga
+
+type script struct {
+	parentA func(gen, slot, n int) int
+	parentB func(gen, slot, n int) int
+	mutate  func(gen, slot int) bool
+}
+
+// WithDeterministic replaces the random decisions of the variation by the callbacks, for testing operators.
+// The parents of the offspring at slot in generation gen are the entities at parentA(gen, slot, n)
+// and parentB(gen, slot, n), where gen is the generation of the parents and n is the population size,
+// and the offspring is mutated if mutate(gen, slot) returns true.
+// The weight passed to Crossover is still derived from the selection weights of the parents.
+// So the GA model is a pure function of the callbacks and the operators, bypassing the random source.
+// It disables the adaptive mutation probability.
+func WithDeterministic(parentA, parentB func(gen, slot, n int) int, mutate func(gen, slot int) bool) Option {
+	return func(m *GA) {
+		m.script = &script{parentA, parentB, mutate}
+	}
+}
+
+func (s *script) select2(m *GA, slot int) (Entity, Entity, float64) {
+	p := &m.pop
+	a, b := s.parentA(m.gen, slot, m.n), s.parentB(m.gen, slot, m.n)
+	wa, wb := p.weights[a], p.weights[b]
+	return p.entities[a], p.entities[b], wa / (wa + wb)
+}
@@ -0,0 +1,44 @@
+package ga_test
+
+import (
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+type Tag string
+
+func (x Tag) Fitness() float64 {
+	return float64(len(x))
+}
+
+func (x Tag) Mutate() ga.Entity {
+	return x + "m"
+}
+
+func (x Tag) Crossover(e ga.Entity, w float64) ga.Entity {
+	return x + e.(Tag)
+}
+
+func TestDeterministic(t *testing.T) {
+	nc := ga.NC
+	ga.NC = 1
+	defer func() { ga.NC = nc }()
+
+	i := 0
+	tags := []Tag{"a", "b", "c"}
+	m := ga.New(3, func() ga.Entity {
+		i++
+		return tags[i-1]
+	}, ga.WithDeterministic(func(gen, slot, n int) int {
+		return slot
+	}, func(gen, slot, n int) int {
+		return (slot + 1) % n
+	}, func(gen, slot int) bool {
+		return slot == 2
+	}))
+	pop := m.EvolveTo(1)
+	if pop[0] != Tag("ab") || pop[1] != Tag("bc") || pop[2] != Tag("cam") {
+		t.Fatal("population:", pop)
+	}
+}
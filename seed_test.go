@@ -0,0 +1,119 @@
+package ga_test
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/ofunc/ga"
+)
+
+type Walk float64
+
+func (w Walk) Fitness() float64 {
+	return -sqr(float64(w) - 1)
+}
+
+func (w Walk) Mutate() ga.Entity {
+	return w + Walk(rand.Float64()-0.5)
+}
+
+func (w Walk) Crossover(e ga.Entity, x float64) ga.Entity {
+	return Walk(x*float64(w) + (1-x)*float64(e.(Walk)))
+}
+
+func TestSeedSolution(t *testing.T) {
+	m := ga.New(50, func() ga.Entity {
+		t.Error("generator should not be called")
+		return nil
+	}, ga.WithSeedSolution(Walk(100), 4))
+	pop := m.EvolveTo(0)
+	if pop[0] != Walk(100) {
+		t.Fatal("seed:", pop[0])
+	}
+	for _, e := range pop {
+		if math.Abs(float64(e.(Walk))-100) > 2 {
+			t.Fatal("spread:", e)
+		}
+	}
+}
+
+func TestSecondaryGenerator(t *testing.T) {
+	pop := ga.New(10, func() ga.Entity {
+		return Walk(1)
+	}, ga.WithSecondaryGenerator(0.25, func() ga.Entity {
+		return Walk(2)
+	})).EvolveTo(0)
+	for i, e := range pop {
+		w := Walk(1)
+		if i < 3 {
+			w = 2
+		}
+		if e != w {
+			t.Fatal("slot:", i, e)
+		}
+	}
+}
+
+func TestNewFrom(t *testing.T) {
+	g := func() ga.Entity {
+		return Walk(-10)
+	}
+	m := ga.NewFrom(10, []ga.Entity{Walk(1), Walk(2)}, g)
+	pop := m.EvolveTo(0)
+	if len(pop) != 10 || pop[0] != Walk(1) || pop[1] != Walk(2) || pop[2] != Walk(-10) {
+		t.Fatal("population:", pop)
+	}
+	if m.Elite() != Walk(1) {
+		t.Fatal("elite:", m.Elite())
+	}
+	es := make([]ga.Entity, 20)
+	for i := range es {
+		es[i] = Walk(i)
+	}
+	if pop := ga.NewFrom(5, es, g).EvolveTo(0); len(pop) != 5 || pop[4] != Walk(4) {
+		t.Fatal("population:", pop)
+	}
+}
+
+func TestSampled(t *testing.T) {
+	sampler := func(r *rand.Rand) ga.Entity {
+		x := Walk(20*r.Float64() - 10)
+		// the workers are interleaved by the sleeps of the varying lengths.
+		time.Sleep(time.Duration(time.Now().UnixNano()%100) * time.Microsecond)
+		return x
+	}
+	x := ga.NewSampled(30, sampler, ga.WithSeed(7), ga.WithConcurrency(4)).EvolveTo(0)
+	y := ga.NewSampled(30, sampler, ga.WithSeed(7), ga.WithConcurrency(4)).EvolveTo(0)
+	for i := range x {
+		if x[i] != y[i] {
+			t.Fatal("not reproduced:", x, y)
+		}
+	}
+	if _, f, _ := ga.NewSampled(30, sampler).Evolve(30, 100); f < -1e-2 {
+		t.Fatal("fitness:", f)
+	}
+}
+
+func TestSeedNeighborhoods(t *testing.T) {
+	m := ga.New(40, func() ga.Entity {
+		return Walk(20*rand.Float64() - 10)
+	}, ga.WithSeedNeighborhoods([]ga.Entity{Walk(100), Walk(-100)}, []float64{3, 1}, 2, 0.5))
+	near := map[int]int{}
+	for i, e := range m.EvolveTo(0) {
+		x := float64(e.(Walk))
+		switch {
+		case i >= 20 && math.Abs(x) <= 10:
+		case i < 20 && math.Abs(x-100) <= 1 && x != 100:
+			near[100]++
+		case i < 20 && math.Abs(x+100) <= 1 && x != -100:
+			near[-100]++
+		default:
+			t.Fatal("entity:", i, x)
+		}
+	}
+	if near[100] != 15 || near[-100] != 5 {
+		t.Fatal("shares:", near)
+	}
+}
@@ -0,0 +1,139 @@
+package ga
+
+import (
+	"math"
+	"sort"
+)
+
+// Selector is a parent selection, which replaces the default sigmoid scaled roulette.
+type Selector interface {
+	// Selection is called once every generation with the fitnesses fs of the population,
+	// and returns a function choosing the index of a parent, with the random numbers in [0, 1) of rand.
+	// The returned function is called concurrently.
+	Selection(fs []float64) func(rand func() float64) int
+}
+
+// WithSelector replaces the default roulette selection by s.
+// The crossover weight is still computed from the sigmoid scaled weights of the parents.
+func WithSelector(s Selector) Option {
+	return func(m *GA) {
+		m.selector = s
+	}
+}
+
+type tournamentSelector int
+
+// TournamentSelector chooses the fittest of size entities drawn uniformly with replacement.
+// The selection pressure grows with size, and does not depend on the scale of the fitnesses.
+func TournamentSelector(size int) Selector {
+	if size < 1 {
+		size = 1
+	}
+	return tournamentSelector(size)
+}
+
+func (t tournamentSelector) Selection(fs []float64) func(func() float64) int {
+	n := len(fs)
+	return func(rand func() float64) int {
+		b := index(rand(), n)
+		for j := 1; j < int(t); j++ {
+			if c := index(rand(), n); fs[c] > fs[b] {
+				b = c
+			}
+		}
+		return b
+	}
+}
+
+type rankSelector float64
+
+// RankSelector chooses the entities with the probabilities linear in their ranks,
+// where the fittest is chosen pressure times as often as the average, and pressure is in [1, 2].
+func RankSelector(pressure float64) Selector {
+	return rankSelector(math.Max(1, math.Min(2, pressure)))
+}
+
+func (s rankSelector) Selection(fs []float64) func(func() float64) int {
+	n := len(fs)
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		return fs[idx[i]] < fs[idx[j]]
+	})
+	ws := make([]float64, n)
+	for r, i := range idx {
+		ws[i] = 2 - float64(s)
+		if n > 1 {
+			ws[i] += 2 * float64(r) * (float64(s) - 1) / float64(n-1)
+		}
+	}
+	return roulette(ws)
+}
+
+type boltzmannSelector struct {
+	t       float64
+	cooling float64
+}
+
+// BoltzmannSelector chooses the entities with the probabilities proportional to exp(f/T),
+// where the temperature T starts at t and is multiplied by cooling every generation,
+// so the selection pressure increases as the evolution goes on, like simulated annealing.
+func BoltzmannSelector(t, cooling float64) Selector {
+	return &boltzmannSelector{t, cooling}
+}
+
+func (s *boltzmannSelector) Selection(fs []float64) func(func() float64) int {
+	best := math.Inf(-1)
+	for _, f := range fs {
+		best = math.Max(best, f)
+	}
+	ws := make([]float64, len(fs))
+	for i, f := range fs {
+		if !math.IsInf(best, -1) {
+			ws[i] = math.Exp((f - best) / s.t)
+		}
+	}
+	s.t *= s.cooling
+	return roulette(ws)
+}
+
+// roulette returns a function choosing the indices with the probabilities proportional to ws,
+// or uniformly if all the weights are 0.
+func roulette(ws []float64) func(func() float64) int {
+	cs, sum := make([]float64, len(ws)), 0.0
+	for i, w := range ws {
+		sum += w
+		cs[i] = sum
+	}
+	n := len(ws)
+	return func(rand func() float64) int {
+		if sum == 0 {
+			return index(rand(), n)
+		}
+		i := sort.SearchFloat64s(cs, rand()*sum)
+		for i < n-1 && ws[i] == 0 {
+			i++
+		}
+		return i
+	}
+}
+
+func index(u float64, n int) int {
+	if i := int(u * float64(n)); i < n {
+		return i
+	}
+	return n - 1
+}
+
+// pick2 chooses the indices of two parents by the selector.
+func (m *GA) pick2(u func() float64) (int, int, float64) {
+	i, j := m.pick(u), m.pick(u)
+	p := &m.pop
+	w := 0.5
+	if s := p.weights[i] + p.weights[j]; s > 0 {
+		w = p.weights[i] / s
+	}
+	return i, j, w
+}
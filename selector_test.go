@@ -0,0 +1,37 @@
+package ga_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestSelectors(t *testing.T) {
+	for _, s := range []ga.Selector{
+		ga.TournamentSelector(3),
+		ga.RankSelector(1.8),
+		ga.BoltzmannSelector(10, 0.9),
+	} {
+		m := ga.New(100, MIN{}.Mutate, ga.WithSelector(s))
+		if _, f, _ := m.Evolve(30, 200); f < -1e-2 {
+			t.Errorf("%T: %v", s, f)
+		}
+	}
+}
+
+func TestRankSelector(t *testing.T) {
+	const k = 100000
+	pick := ga.RankSelector(2).Selection([]float64{3, 1, math.Inf(-1), 2})
+	r := rand.New(rand.NewSource(1))
+	counts := make([]int, 4)
+	for i := 0; i < k; i++ {
+		counts[pick(r.Float64)]++
+	}
+	for i, p := range []float64{0.5, 1.0 / 6, 0, 1.0 / 3} {
+		if q := float64(counts[i]) / k; math.Abs(q-p) > 0.01 {
+			t.Error(i, q, p)
+		}
+	}
+}
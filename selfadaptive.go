@@ -0,0 +1,24 @@
+package ga
+
+// SelfAdaptive is an entity carrying its own strategy parameters in the style of evolution strategies,
+// e.g. a mutation rate and step sizes, which evolve and propagate with it instead of the global mutation probability.
+type SelfAdaptive interface {
+	Entity
+	// Adapt returns a copy of the entity with its strategy parameters perturbed, e.g. log-normally,
+	// without modifying the receiver, which is called for every offspring before the mutation.
+	Adapt() Entity
+	// MutationRate returns the mutation probability of the entity, which is used instead of the global one.
+	MutationRate() float64
+}
+
+// adapt perturbs the strategy parameters of the offspring z if it is SelfAdaptive,
+// and returns it with its mutation probability, or pm otherwise.
+func adapt(z Entity, pm float64) (Entity, float64) {
+	if s, ok := z.(SelfAdaptive); ok {
+		z = s.Adapt()
+		if s, ok := z.(SelfAdaptive); ok {
+			return z, s.MutationRate()
+		}
+	}
+	return z, pm
+}
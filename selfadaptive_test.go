@@ -0,0 +1,72 @@
+package ga_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+var mutations int
+
+// Strategic is Walk carrying its own mutation rate.
+type Strategic struct {
+	W    Walk
+	Rate float64
+}
+
+func (s Strategic) Fitness() float64 {
+	return s.W.Fitness()
+}
+
+func (s Strategic) Mutate() ga.Entity {
+	mutations++
+	return Strategic{s.W.Mutate().(Walk), s.Rate}
+}
+
+func (s Strategic) Crossover(e ga.Entity, w float64) ga.Entity {
+	o := e.(Strategic)
+	return Strategic{s.W.Crossover(o.W, w).(Walk), w*s.Rate + (1-w)*o.Rate}
+}
+
+func (s Strategic) Adapt() ga.Entity {
+	if s.Rate > 0 {
+		s.Rate = math.Min(1, s.Rate*math.Exp(rand.NormFloat64()/4))
+	}
+	return s
+}
+
+func (s Strategic) MutationRate() float64 {
+	return s.Rate
+}
+
+func TestSelfAdaptive(t *testing.T) {
+	defer func() { mutations = 0 }()
+	mutations = 0
+	m := ga.New(20, func() ga.Entity {
+		return Strategic{Walk(4 * rand.Float64()), 0}
+	})
+	m.EvolveTo(5)
+	if mutations != 0 {
+		t.Fatal("mutations without rate:", mutations)
+	}
+
+	m = ga.New(50, func() ga.Entity {
+		return Strategic{Walk(4*rand.Float64() + 2), 0.5}
+	})
+	m.EvolveTo(50)
+	if mutations < 500 {
+		t.Fatal("mutations:", mutations)
+	}
+	if f := m.Fitness(); f < -1e-3 {
+		t.Fatal("fitness:", f)
+	}
+	rates := make(map[float64]bool)
+	for _, e := range m.Population() {
+		rates[e.(Strategic).Rate] = true
+	}
+	if len(rates) < 2 {
+		t.Fatal("rates not adapted:", rates)
+	}
+}
@@ -0,0 +1,45 @@
+package ga_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+type Logged struct {
+	MIN
+	ID  int32
+	log *[]int32
+	mu  *sync.Mutex
+}
+
+func (x Logged) Fitness() float64 {
+	x.mu.Lock()
+	*x.log = append(*x.log, x.ID)
+	x.mu.Unlock()
+	return x.MIN.Fitness()
+}
+
+func TestSequentialEval(t *testing.T) {
+	nc := ga.NC
+	ga.NC = 4
+	defer func() { ga.NC = nc }()
+
+	var log []int32
+	var mu sync.Mutex
+	var id int32
+	m := ga.New(40, func() ga.Entity {
+		return Logged{MIN{}.Mutate().(MIN), atomic.AddInt32(&id, 1), &log, &mu}
+	}, ga.WithSequentialEval())
+	pop := m.EvolveTo(0)
+	if len(log) != len(pop) {
+		t.Fatal("evaluations:", len(log))
+	}
+	for i, e := range pop {
+		if e.(Logged).ID != log[i] {
+			t.Fatal("order:", i, e.(Logged).ID, log[i])
+		}
+	}
+}
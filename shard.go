@@ -0,0 +1,125 @@
+package ga
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// Shard is a part of a batch of entities, delegated to a Transport.
+type Shard struct {
+	// Generation is the generation of the batch.
+	Generation int
+	// Index is the index of the shard in the batch.
+	Index int
+	// Entities are the entities of the shard, which are a copy owned by the shard.
+	Entities []Entity
+	// Vary reports whether the remote may vary the entities, see WithShards.
+	Vary bool
+}
+
+// ShardResult is the result of a shard from a Transport.
+type ShardResult struct {
+	// Entities are the entities replacing those of the shard, e.g. refined by a local search on the remote,
+	// or nil to keep them. They are ignored unless the shard may be varied.
+	Entities []Entity
+	// Fitnesses are the fitnesses of the entities of the shard, or of their replacements.
+	Fitnesses []float64
+}
+
+// Transport delegates the shards to the remote machines, e.g. by a message queue or batch jobs.
+type Transport interface {
+	// Dispatch evaluates the shard s remotely, and returns its result.
+	// It is called concurrently for the shards of a batch, and should give up once ctx is done.
+	Dispatch(ctx context.Context, s Shard) (ShardResult, error)
+}
+
+// WithShards evaluates the populations by t instead of calling Fitness of each entity.
+// Each batch of entities, the whole generation or the new entities of an incremental update,
+// is split into n shards of nearly equal sizes, which are dispatched concurrently.
+// If vary, the remote may also vary the entities of the shards, and return the varied entities with their fitnesses.
+// A shard is lost if Dispatch fails, returns a malformed result, or has not returned within timeout if timeout > 0,
+// and then its entities are kept and get the fitness fallback, and the loss is reported by Err.
+// The late results of the stragglers are discarded.
+// As with WithBatchEvaluator, the oracle, the fitness cache and the generation deadline are not applied to them.
+func WithShards(t Transport, n int, timeout time.Duration, vary bool, fallback float64) Option {
+	return func(m *GA) {
+		m.evaluator("WithShards", func(es []Entity, fs []float64) {
+			if len(es) == 0 {
+				return
+			}
+			atomic.AddInt64(&m.evals, int64(len(es)))
+			ctx := m.pop.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+			k := n
+			if k > len(es) {
+				k = len(es)
+			}
+			if k < 1 {
+				k = 1
+			}
+			type reply struct {
+				i   int
+				r   ShardResult
+				err error
+			}
+			// The replies are buffered, so the stragglers never block.
+			replies := make(chan reply, k)
+			for i := 0; i < k; i++ {
+				s := Shard{m.gen, i, append([]Entity(nil), es[i*len(es)/k:(i+1)*len(es)/k]...), vary}
+				go func() {
+					r, err := t.Dispatch(ctx, s)
+					replies <- reply{s.Index, r, err}
+				}()
+			}
+			settled := make([]bool, k)
+		wait:
+			for j := 0; j < k; j++ {
+				select {
+				case r := <-replies:
+					settled[r.i] = true
+					lo, hi := r.i*len(es)/k, (r.i+1)*len(es)/k
+					if r.err == nil && len(r.r.Fitnesses) != hi-lo {
+						r.err = fmt.Errorf("ga: %d fitnesses for %d entities", len(r.r.Fitnesses), hi-lo)
+					} else if r.err == nil && vary && r.r.Entities != nil && len(r.r.Entities) != hi-lo {
+						r.err = fmt.Errorf("ga: %d varied entities for %d entities", len(r.r.Entities), hi-lo)
+					}
+					if r.err != nil {
+						m.fail("shard", r.err)
+						m.lose(fs[lo:hi], fallback)
+						continue
+					}
+					for i, f := range r.r.Fitnesses {
+						fs[lo+i] = m.objective(f)
+					}
+					if vary && r.r.Entities != nil {
+						copy(es[lo:hi], r.r.Entities)
+					}
+				case <-ctx.Done():
+					break wait
+				}
+			}
+			for i, ok := range settled {
+				if !ok {
+					m.fail("shard", fmt.Errorf("ga: shard %d: %v", i, ctx.Err()))
+					m.lose(fs[i*len(es)/k:(i+1)*len(es)/k], fallback)
+				}
+			}
+		})
+	}
+}
+
+// lose sets the fitnesses fs of a lost shard to fallback.
+func (m *GA) lose(fs []float64, fallback float64) {
+	for i := range fs {
+		fs[i] = m.objective(fallback)
+	}
+}
@@ -0,0 +1,62 @@
+package ga_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/ofunc/ga"
+)
+
+type transport struct {
+	lost, slow int
+}
+
+func (t transport) Dispatch(ctx context.Context, s ga.Shard) (ga.ShardResult, error) {
+	if s.Index == t.lost {
+		return ga.ShardResult{}, errors.New("lost")
+	}
+	if s.Index == t.slow {
+		<-ctx.Done()
+		time.Sleep(time.Millisecond)
+	}
+	r := ga.ShardResult{Fitnesses: make([]float64, len(s.Entities))}
+	if s.Vary {
+		r.Entities = make([]ga.Entity, len(s.Entities))
+	}
+	for i, e := range s.Entities {
+		if s.Vary {
+			e = e.(Walk) / 2
+			r.Entities[i] = e
+		}
+		r.Fitnesses[i] = e.Fitness()
+	}
+	return r, nil
+}
+
+func TestShards(t *testing.T) {
+	g := func() ga.Entity {
+		return Walk(rand.Float64()*4 - 2)
+	}
+	m := ga.New(40, g, ga.WithShards(transport{-1, -1}, 4, 0, false, 0))
+	if _, f, _ := m.Evolve(20, 200); f < -1e-2 || m.Err() != nil {
+		t.Fatal("fitness:", f, m.Err())
+	}
+
+	m = ga.New(40, g, ga.WithShards(transport{1, 2}, 4, 10*time.Millisecond, false, math.Inf(-1)))
+	if es, ok := m.Err().(ga.Errors); !ok || len(es) != 2 {
+		t.Fatal("errors:", es)
+	}
+	if s := m.Stats(); !math.IsInf(s.Worst, -1) || math.IsInf(s.Best, -1) {
+		t.Fatal("stats:", s)
+	}
+
+	es := []ga.Entity{Walk(4), Walk(8)}
+	m = ga.NewFrom(2, es, g, ga.WithShards(transport{-1, -1}, 2, 0, true, 0))
+	if pop := m.Population(); pop[0] != Walk(2) || pop[1] != Walk(4) {
+		t.Fatal("varied:", pop)
+	}
+}
@@ -0,0 +1,33 @@
+package ga_test
+
+import (
+	"math"
+	"math/rand/v2"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestMaxSelectionShare(t *testing.T) {
+	var i int32
+	m := ga.New(5, func() ga.Entity {
+		if atomic.AddInt32(&i, 1) == 1 {
+			return Walk(1)
+		}
+		return Walk(-99)
+	}, ga.WithMaxSelectionShare(0.25))
+
+	const k = 100000
+	r, s := rand.New(rand.NewPCG(1, 1)), m.NewSampler()
+	counts := make(map[ga.Entity]int)
+	for j := 0; j < k; j++ {
+		counts[s.Sample(r)]++
+	}
+	if q := float64(counts[Walk(1)]) / k; math.Abs(q-0.25) > 0.01 {
+		t.Fatal("share:", q)
+	}
+	if q := float64(counts[Walk(-99)]) / k; math.Abs(q-0.75) > 0.01 {
+		t.Fatal("rest:", q)
+	}
+}
@@ -0,0 +1,155 @@
+package ga
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// Scalar is a named scalar of a generation.
+type Scalar struct {
+	Tag   string
+	Value float64
+}
+
+// Sink is a receiver of the per-generation scalars of GA model.
+// The scalars are: best, mean, std, pm, diversity and entropy,
+// followed by objective if the elite implements Objective.
+type Sink interface {
+	Log(gen int, scalars []Scalar)
+}
+
+// EliteSink is an optional interface of Sink, which also receives the description of the elite by Describe,
+// e.g. to log the best solution as [3 1 4 1 5] instead of an opaque pointer.
+// LogElite is called instead of Log if the sink implements EliteSink.
+type EliteSink interface {
+	Sink
+	LogElite(gen int, scalars []Scalar, elite string)
+}
+
+// WithSink adds the sink s which receives the scalars of every generation.
+// The sinks of several WithSink and WithTrace all receive the scalars, in the order of the options.
+func WithSink(s Sink) Option {
+	return func(m *GA) {
+		switch x := m.sink.(type) {
+		case nil:
+			m.sink = s
+		case sinks:
+			m.sink = append(x[:len(x):len(x)], s)
+		default:
+			m.sink = sinks{x, s}
+		}
+	}
+}
+
+// sinks is a sink which fans out the scalars to several sinks.
+type sinks []Sink
+
+func (ss sinks) Log(gen int, scalars []Scalar) {
+	for _, s := range ss {
+		s.Log(gen, scalars)
+	}
+}
+
+func (ss sinks) LogElite(gen int, scalars []Scalar, elite string) {
+	for _, s := range ss {
+		if x, ok := s.(EliteSink); ok {
+			x.LogElite(gen, scalars, elite)
+		} else {
+			s.Log(gen, scalars)
+		}
+	}
+}
+
+func (m *GA) report() {
+	if m.sink == nil {
+		return
+	}
+	s := m.stats
+	scalars := []Scalar{
+		{"best", s.Fitness},
+		{"mean", s.Mean},
+		{"std", s.Std},
+		{"pm", s.PM},
+		{"diversity", s.Diversity},
+		{"entropy", m.entropy()},
+	}
+	if _, ok := m.elite.(Objective); ok {
+		scalars = append(scalars, Scalar{"objective", s.Objective})
+	}
+	if x, ok := m.sink.(EliteSink); ok {
+		x.LogElite(s.Generation, scalars, Describe(m.elite))
+		return
+	}
+	m.sink.Log(s.Generation, scalars)
+}
+
+// entropy is the entropy of the selection distribution.
+func (m *GA) entropy() float64 {
+	hs := make([]float64, m.pop.concurrency())
+	m.do(func(c, i int) {
+		if p := m.pop.weights[i] / m.pop.fsum; p > 0 {
+			hs[c] -= p * math.Log(p)
+		}
+	})
+	return sum(hs)
+}
+
+// LineProtocol is a sink which writes one line per generation in the line protocol format:
+//
+//	<name> best=<v>,mean=<v>,...,elite="<description>" <gen>
+type LineProtocol struct {
+	Name string
+	w    io.Writer
+	err  error
+}
+
+// NewLineProtocol creates a line protocol sink.
+func NewLineProtocol(w io.Writer, name string) *LineProtocol {
+	return &LineProtocol{Name: name, w: w}
+}
+
+// Log writes the scalars of a generation.
+func (p *LineProtocol) Log(gen int, scalars []Scalar) {
+	p.write(gen, scalars, "", false)
+}
+
+// LogElite writes the scalars of a generation, and the description of the elite as the string field elite.
+func (p *LineProtocol) LogElite(gen int, scalars []Scalar, elite string) {
+	p.write(gen, scalars, elite, true)
+}
+
+func (p *LineProtocol) write(gen int, scalars []Scalar, elite string, described bool) {
+	if p.err != nil {
+		return
+	}
+	_, p.err = io.WriteString(p.w, p.Name)
+	for i, s := range scalars {
+		sep := ","
+		if i == 0 {
+			sep = " "
+		}
+		if p.err == nil {
+			_, p.err = fmt.Fprintf(p.w, "%s%s=%g", sep, s.Tag, s.Value)
+		}
+	}
+	if described && p.err == nil {
+		sep := ","
+		if len(scalars) == 0 {
+			sep = " "
+		}
+		_, p.err = fmt.Fprintf(p.w, `%selite="%s"`, sep, lineEscaper.Replace(elite))
+	}
+	if p.err == nil {
+		_, p.err = fmt.Fprintf(p.w, " %d\n", gen)
+	}
+}
+
+// lineEscaper escapes a string field of the line protocol.
+var lineEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// Err returns the first error in writing.
+func (p *LineProtocol) Err() error {
+	return p.err
+}
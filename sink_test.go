@@ -0,0 +1,133 @@
+package ga_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/json"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestLineProtocol(t *testing.T) {
+	var b bytes.Buffer
+	p := ga.NewLineProtocol(&b, "min")
+	m := ga.New(20, MIN{}.Mutate, ga.WithSink(p))
+	m.Next()
+	m.Next()
+	if p.Err() != nil {
+		t.Fatal(p.Err())
+	}
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatal("lines:", len(lines))
+	}
+	for _, tag := range []string{"min best=", ",mean=", ",std=", ",pm=", ",diversity=", ",entropy=", `,elite="`} {
+		if !strings.Contains(lines[2], tag) {
+			t.Fatal("missing:", tag, lines[2])
+		}
+	}
+	if !strings.HasSuffix(lines[2], " 2") {
+		t.Fatal("generation:", lines[2])
+	}
+}
+
+func TestTensorBoard(t *testing.T) {
+	dir, err := os.MkdirTemp("", "ga")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	tb, err := ga.NewTensorBoard(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := ga.New(20, MIN{}.Mutate, ga.WithSink(tb))
+	m.Next()
+	if err := tb.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "events.out.tfevents.*"))
+	if len(files) != 1 {
+		t.Fatal("files:", files)
+	}
+	data, _ := os.ReadFile(files[0])
+	table := crc32.MakeTable(crc32.Castagnoli)
+	mask := func(b []byte) uint32 {
+		c := crc32.Checksum(b, table)
+		return (c>>15 | c<<17) + 0xa282ead8
+	}
+	n := 0
+	for len(data) > 0 {
+		l := binary.LittleEndian.Uint64(data)
+		if mask(data[:8]) != binary.LittleEndian.Uint32(data[8:]) {
+			t.Fatal("length crc")
+		}
+		rec := data[12 : 12+l]
+		if mask(rec) != binary.LittleEndian.Uint32(data[12+l:]) {
+			t.Fatal("data crc")
+		}
+		data = data[16+l:]
+		n++
+	}
+	if n != 3 {
+		t.Fatal("records:", n)
+	}
+}
+
+func TestTrace(t *testing.T) {
+	var b bytes.Buffer
+	m := ga.New(20, MIN{}.Mutate, ga.WithTrace(&b, ga.JSONLines))
+	m.Next()
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatal("lines:", len(lines))
+	}
+	var r map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[1]), &r); err != nil {
+		t.Fatal(err, lines[1])
+	}
+	if r["gen"] != 1.0 || r["best"] == nil || r["pm"] == nil || r["elite"] != ga.Describe(m.Elite()) {
+		t.Fatal("record:", r)
+	}
+
+	var c bytes.Buffer
+	b.Reset()
+	m = ga.New(20, MIN{}.Mutate, ga.WithSink(ga.NewLineProtocol(&b, "min")), ga.WithTrace(&c, ga.CSV))
+	m.Next()
+	if n, k := strings.Count(b.String(), "\n"), strings.Count(c.String(), "\n"); n != 2 || k != 3 {
+		t.Fatal("fan out:", n, k)
+	}
+
+	b.Reset()
+	p := ga.NewTrace(&b, ga.CSV)
+	m = ga.New(20, MIN{}.Mutate, ga.WithSink(p))
+	m.Next()
+	m.Next()
+	if p.Err() != nil {
+		t.Fatal(p.Err())
+	}
+	rs, err := csv.NewReader(&b).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rs) != 4 || rs[0][0] != "gen" || rs[0][1] != "best" || rs[3][0] != "2" {
+		t.Fatal("records:", rs)
+	}
+	if k := len(rs[0]) - 1; rs[0][k] != "elite" || rs[3][k] != ga.Describe(m.Elite()) {
+		t.Fatal("elite:", rs)
+	}
+
+	b.Reset()
+	p = ga.NewTrace(&b, ga.CSV)
+	p.LogElite(1, []ga.Scalar{{"best", 1}}, `a "b", c`)
+	if rs, err := csv.NewReader(&b).ReadAll(); err != nil || rs[1][2] != `a "b", c` {
+		t.Fatal("quoted:", rs, err)
+	}
+}
@@ -0,0 +1,126 @@
+package ga
+
+import (
+	"math"
+	"sort"
+)
+
+// WithSpeciation partitions the population into species in the style of NEAT,
+// where an entity belongs to the first species whose representative is within threshold by the distance dist,
+// or founds a new species. The representatives are the first members of the species of the previous generation.
+// The selection weights are shared within each species, dividing them by its size,
+// the offspring are allocated to the species in proportion to their total shared weights,
+// and both parents of an offspring are selected from its species.
+func WithSpeciation(dist func(x, y Entity) float64, threshold float64) Option {
+	return func(m *GA) {
+		m.species = &speciation{dist: dist, threshold: threshold}
+	}
+}
+
+// Species returns the sizes of the species of the current population, or nil if WithSpeciation is not set.
+func (m *GA) Species() []int {
+	if m.species == nil {
+		return nil
+	}
+	ns := make([]int, len(m.species.members))
+	for i, ms := range m.species.members {
+		ns[i] = len(ms)
+	}
+	return ns
+}
+
+type speciation struct {
+	dist      func(x, y Entity) float64
+	threshold float64
+	reps      []Entity
+	members   [][]int
+	slots     []int
+}
+
+// apply partitions p into species, shares the weights of p, and allocates the slots of the offspring.
+func (s *speciation) apply(p *Population) {
+	s.members = make([][]int, len(s.reps))
+	for i, e := range p.entities {
+		k := -1
+		for j, r := range s.reps {
+			if s.dist(e, r) < s.threshold {
+				k = j
+				break
+			}
+		}
+		if k < 0 {
+			s.reps = append(s.reps, e)
+			s.members = append(s.members, nil)
+			k = len(s.reps) - 1
+		}
+		s.members[k] = append(s.members[k], i)
+	}
+	reps, members := s.reps[:0], s.members[:0]
+	for _, ms := range s.members {
+		if len(ms) > 0 {
+			reps, members = append(reps, p.entities[ms[0]]), append(members, ms)
+		}
+	}
+	s.reps, s.members = reps, members
+
+	totals := make([]float64, len(s.members))
+	p.fsum = 0
+	for k, ms := range s.members {
+		for _, i := range ms {
+			p.weights[i] /= float64(len(ms))
+			totals[k] += p.weights[i]
+		}
+		p.fsum += totals[k]
+	}
+
+	// the largest remainder method
+	n := len(p.entities)
+	counts, rs := make([]int, len(totals)), make([]int, len(totals))
+	left := n
+	for k, t := range totals {
+		q := float64(n) / float64(len(totals))
+		if p.fsum > 0 {
+			q = t / p.fsum * float64(n)
+		}
+		counts[k] = int(math.Floor(q))
+		left -= counts[k]
+		rs[k] = k
+	}
+	sort.SliceStable(rs, func(a, b int) bool {
+		qa, qb := totals[rs[a]]/p.fsum*float64(n), totals[rs[b]]/p.fsum*float64(n)
+		return qa-math.Floor(qa) > qb-math.Floor(qb)
+	})
+	for j := 0; j < left; j++ {
+		counts[rs[j%len(rs)]]++
+	}
+	s.slots = s.slots[:0]
+	for k, c := range counts {
+		for j := 0; j < c; j++ {
+			s.slots = append(s.slots, k)
+		}
+	}
+}
+
+// select2 selects two parents for the slot i from its species, by the shared weights.
+func (s *speciation) select2(p *Population, i int, u func() float64) (Entity, Entity, float64) {
+	ms := s.members[s.slots[i%len(s.slots)]]
+	sum := 0.0
+	for _, j := range ms {
+		sum += p.weights[j]
+	}
+	pick := func() int {
+		r := u() * sum
+		for _, j := range ms {
+			if r -= p.weights[j]; r < 0 {
+				return j
+			}
+		}
+		return ms[len(ms)-1]
+	}
+	a, b := pick(), pick()
+	w := 0.5
+	if t := p.weights[a] + p.weights[b]; t > 0 {
+		w = p.weights[a] / t
+	}
+	return p.entities[a], p.entities[b], w
+}
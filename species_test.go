@@ -0,0 +1,40 @@
+package ga_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestSpeciation(t *testing.T) {
+	dist := func(x, y ga.Entity) float64 {
+		return math.Abs(float64(x.(Twin) - y.(Twin)))
+	}
+	m := ga.New(100, func() ga.Entity {
+		return Twin(8*rand.Float64() - 4)
+	}, ga.WithSpeciation(dist, 1))
+	left, right := 0, 0
+	for _, e := range m.EvolveTo(50) {
+		switch x := float64(e.(Twin)); {
+		case math.Abs(x+2) < 0.5:
+			left++
+		case math.Abs(x-2) < 0.5:
+			right++
+		}
+	}
+	if left < 20 || right < 20 {
+		t.Fatal("species:", left, right)
+	}
+	n := 0
+	for _, k := range m.Species() {
+		n += k
+	}
+	if n != 100 {
+		t.Fatal("members:", n, m.Species())
+	}
+	if ga.New(10, func() ga.Entity { return Twin(0) }).Species() != nil {
+		t.Fatal("species without speciation")
+	}
+}
@@ -0,0 +1,91 @@
+package ga
+
+import "sync/atomic"
+
+// Stats is a snapshot of the statistics of a generation.
+type Stats struct {
+	// Generation is the number of generations produced since New.
+	Generation int
+	// Fitness is the fitness of the elite.
+	Fitness float64
+	// Objective is the raw objective of the elite, see Objective.
+	Objective float64
+	// Best, Mean, Std and Worst are the statistics of the fitnesses of the generation.
+	Best  float64
+	Mean  float64
+	Std   float64
+	Worst float64
+	// PM is the mutation probability.
+	PM float64
+	// Diversity is the diversity measured by the metric set by WithDiversityMetric.
+	Diversity float64
+	// Evaluations is the number of fitness evaluations since New.
+	Evaluations int64
+	// Minimize reports whether the fitnesses are minimized, see WithMinimize.
+	Minimize bool
+}
+
+// gain returns the improvement from the fitness a to b, which is negative if b is worse.
+func (s Stats) gain(a, b float64) float64 {
+	if s.Minimize {
+		return a - b
+	}
+	return b - a
+}
+
+// SnapshotStats returns the statistics of the last completed generation.
+// It is safe to call concurrently with evolution, and the statistics are always of the same generation.
+func (m *GA) SnapshotStats() Stats {
+	m.smutex.Lock()
+	defer m.smutex.Unlock()
+	return m.stats
+}
+
+// Stats returns the statistics of the current generation, the same as SnapshotStats.
+func (m *GA) Stats() Stats {
+	return m.SnapshotStats()
+}
+
+// publish captures the statistics of the current generation.
+func (m *GA) publish() {
+	m.expose()
+	if m.fame != nil {
+		m.fame.update(&m.pop)
+	}
+	if m.history != nil {
+		m.history.record(m.gen, m.elite, m.fitness)
+	}
+	m.trajectory = append(m.trajectory, m.fitness)
+	if len(m.trajectory) > 2*window {
+		m.trajectory = append(m.trajectory[:0], m.trajectory[len(m.trajectory)-window:]...)
+	}
+}
+
+// expose makes the statistics and the elite of the current generation visible to the concurrent readers.
+func (m *GA) expose() {
+	s := m.snapshot()
+	m.smutex.Lock()
+	m.stats, m.published = s, m.elite
+	m.smutex.Unlock()
+	m.capture()
+}
+
+// snapshot returns the statistics of the current population.
+func (m *GA) snapshot() Stats {
+	_, best := m.pop.Best()
+	_, worst := m.pop.Worst()
+	mean, std := m.pop.Stats()
+	return Stats{
+		Generation:  m.gen,
+		Fitness:     m.objective(m.fitness),
+		Objective:   m.rawObjective(),
+		Best:        m.objective(best),
+		Mean:        m.objective(mean),
+		Std:         std,
+		Worst:       m.objective(worst),
+		PM:          m.pm,
+		Diversity:   m.diversity,
+		Evaluations: atomic.LoadInt64(&m.evals),
+		Minimize:    m.minimize,
+	}
+}
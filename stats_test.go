@@ -0,0 +1,85 @@
+package ga_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestSnapshotStats(t *testing.T) {
+	m := ga.New(50, MIN{}.Mutate)
+	if s := m.SnapshotStats(); s.Generation != 0 || s.Evaluations != 50 {
+		t.Fatal("initial:", s)
+	}
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		last := 0
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			s := m.SnapshotStats()
+			if s.Generation < last || s.Best < s.Worst || s.Fitness < s.Best || s.Evaluations != int64(50*(s.Generation+1)) {
+				t.Error("inconsistent:", s)
+				return
+			}
+			last = s.Generation
+		}
+	}()
+	m.Evolve(100, 100)
+	close(done)
+	wg.Wait()
+
+	s := m.SnapshotStats()
+	if s.Generation != 100 || s.Fitness != m.Fitness() || s.Mean < s.Worst || s.Std < 0 {
+		t.Fatal("final:", s)
+	}
+	if m.Stats() != s {
+		t.Fatal("stats:", m.Stats(), s)
+	}
+}
+
+func TestConcurrentElite(t *testing.T) {
+	m := ga.New(50, MIN{}.Mutate)
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		last := m.Fitness()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			f, e := m.Fitness(), m.Elite()
+			if f < last || e == nil || e.Fitness() < f {
+				t.Error("inconsistent:", last, f, e)
+				return
+			}
+			last = f
+		}
+	}()
+	m.Evolve(100, 100)
+	close(done)
+	wg.Wait()
+}
+
+func TestCounters(t *testing.T) {
+	m := ga.New(20, MIN{}.Mutate, ga.WithElitism(2))
+	if m.Generation() != 0 || m.Evaluations() != 20 {
+		t.Fatal("initial:", m.Generation(), m.Evaluations())
+	}
+	m.EvolveUntil(ga.MaxEvaluations(300))
+	if g, e := m.Generation(), m.Evaluations(); g != 15 || e != 320 || m.Stats().Evaluations != e {
+		t.Fatal("budget:", g, e)
+	}
+}
@@ -0,0 +1,36 @@
+package ga
+
+import "math"
+
+// WithSteadyState switches the GA model to the steady-state mode,
+// where each generation replaces only the fraction frac of the least fit entities by offspring,
+// instead of the whole population. At least one entity is replaced, and the fittest is always kept.
+// Only the offspring are evaluated, so it suits noisy or expensive fitnesses.
+func WithSteadyState(frac float64) Option {
+	return func(m *GA) {
+		m.steady = frac
+	}
+}
+
+// step replaces the least fit entities by offspring in the steady-state mode.
+func (m *GA) step() {
+	k := int(math.Round(m.steady * float64(m.n)))
+	if k > m.n-1 {
+		k = m.n - 1
+	}
+	if k < 1 {
+		k = 1
+	}
+	es := make([]Entity, k)
+	m.fork()
+	if m.sus && m.pick == nil {
+		m.spin(k)
+	}
+	m.pop.parallel(k, func(c, i int) {
+		es[i] = m.offspring(i, m.random(c, i))
+	})
+	if m.dedup != nil {
+		m.unique(es, 0, true)
+	}
+	m.replace(order(m.pop.fitnesses)[:k], es)
+}
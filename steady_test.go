@@ -0,0 +1,34 @@
+package ga_test
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestSteadyState(t *testing.T) {
+	atomic.StoreInt64(&evaluations, 0)
+	m := ga.New(50, Keyed{}.Mutate, ga.WithSteadyState(0.1))
+	n := atomic.LoadInt64(&evaluations)
+	prev := m.EvolveTo(0)
+	for i := 1; i <= 10; i++ {
+		next := m.EvolveTo(i)
+		changed := 0
+		for j := range next {
+			if next[j] != prev[j] {
+				changed++
+			}
+		}
+		if changed > 5 {
+			t.Fatal("replaced:", i, changed)
+		}
+		prev = next
+	}
+	if d := atomic.LoadInt64(&evaluations) - n; d != 50 {
+		t.Fatal("evaluations:", d)
+	}
+	if _, f, _ := m.Evolve(100, 2000); f < 35 {
+		t.Fatal("fitness:", f)
+	}
+}
@@ -0,0 +1,222 @@
+package ga
+
+import "time"
+
+// StopCondition is a condition to stop the evolution, which is checked after every generation.
+type StopCondition interface {
+	// Start is called with the statistics when the evolution starts.
+	Start(s Stats)
+	// Stop reports whether to stop, with the statistics of the last generation.
+	Stop(s Stats) bool
+}
+
+// EvolveUntil runs the GA model until the condition c is met, and returns the elite and fitness.
+func (m *GA) EvolveUntil(c StopCondition) (Entity, float64) {
+	m.halt = false
+	c.Start(m.SnapshotStats())
+	for !m.halt {
+		m.Next()
+		if c.Stop(m.SnapshotStats()) {
+			break
+		}
+	}
+	return m.elite, m.objective(m.fitness)
+}
+
+// Any is met if any of the conditions is met.
+// All the conditions are checked every generation, so stateful ones are kept up to date.
+func Any(cs ...StopCondition) StopCondition {
+	return combined{cs, false}
+}
+
+// All is met if all of the conditions are met.
+// All the conditions are checked every generation, so stateful ones are kept up to date.
+func All(cs ...StopCondition) StopCondition {
+	return combined{cs, true}
+}
+
+type combined struct {
+	cs  []StopCondition
+	all bool
+}
+
+func (x combined) Start(s Stats) {
+	for _, c := range x.cs {
+		c.Start(s)
+	}
+}
+
+func (x combined) Stop(s Stats) bool {
+	r := x.all
+	for _, c := range x.cs {
+		if x.all {
+			r = c.Stop(s) && r
+		} else {
+			r = c.Stop(s) || r
+		}
+	}
+	return r
+}
+
+type target float64
+
+// Target is met when the fitness of the elite reaches f, from below, or from above with WithMinimize.
+func Target(f float64) StopCondition {
+	return target(f)
+}
+
+func (target) Start(s Stats) {}
+
+func (t target) Stop(s Stats) bool {
+	return s.gain(float64(t), s.Fitness) >= 0
+}
+
+type stagnation struct {
+	k       int
+	i       int
+	fitness float64
+}
+
+// Stagnation is met when the fitness of the elite has not improved for k generations.
+func Stagnation(k int) StopCondition {
+	return &stagnation{k: k}
+}
+
+func (x *stagnation) Start(s Stats) {
+	x.i, x.fitness = 0, s.Fitness
+}
+
+func (x *stagnation) Stop(s Stats) bool {
+	if x.i++; s.gain(x.fitness, s.Fitness) > 0 {
+		x.i, x.fitness = 0, s.Fitness
+	}
+	return x.i >= x.k
+}
+
+type timeout struct {
+	d     time.Duration
+	start time.Time
+}
+
+// Timeout is met when d has elapsed since the evolution started.
+func Timeout(d time.Duration) StopCondition {
+	return &timeout{d: d}
+}
+
+func (x *timeout) Start(s Stats) {
+	x.start = time.Now()
+}
+
+func (x *timeout) Stop(s Stats) bool {
+	return time.Since(x.start) >= x.d
+}
+
+type generations struct {
+	n     int
+	start int
+}
+
+// MaxGenerations is met when n generations have been produced since the evolution started.
+func MaxGenerations(n int) StopCondition {
+	return &generations{n: n}
+}
+
+func (x *generations) Start(s Stats) {
+	x.start = s.Generation
+}
+
+func (x *generations) Stop(s Stats) bool {
+	return s.Generation-x.start >= x.n
+}
+
+type evaluations struct {
+	n     int64
+	start int64
+}
+
+// MaxEvaluations is met when n fitness evaluations have been done since the evolution started.
+func MaxEvaluations(n int64) StopCondition {
+	return &evaluations{n: n}
+}
+
+func (x *evaluations) Start(s Stats) {
+	x.start = s.Evaluations
+}
+
+func (x *evaluations) Stop(s Stats) bool {
+	return s.Evaluations-x.start >= x.n
+}
+
+type diversity float64
+
+// DiversityBelow is met when the diversity falls below d, see WithDiversityMetric.
+func DiversityBelow(d float64) StopCondition {
+	return diversity(d)
+}
+
+func (diversity) Start(s Stats) {}
+
+func (d diversity) Stop(s Stats) bool {
+	return s.Diversity < float64(d)
+}
+
+type improvement struct {
+	eps float64
+	k   int
+	fs  []float64
+}
+
+// ImprovementBelow is met when the fitness of the elite has improved by less than eps over the last k generations.
+func ImprovementBelow(eps float64, k int) StopCondition {
+	return &improvement{eps: eps, k: k}
+}
+
+func (x *improvement) Start(s Stats) {
+	x.fs = append(x.fs[:0], s.Fitness)
+}
+
+func (x *improvement) Stop(s Stats) bool {
+	if x.fs = append(x.fs, s.Fitness); len(x.fs) > x.k+1 {
+		x.fs = x.fs[1:]
+	}
+	return len(x.fs) > x.k && s.gain(x.fs[0], x.fs[x.k]) < x.eps
+}
+
+type slope struct {
+	improvement
+}
+
+// SlopeBelow is met when the least squares slope of the fitness of the elite over the last k generations
+// is less than eps per generation, in the direction of the improvement.
+// Unlike ImprovementBelow, it is robust to a single jump of the fitness, e.g. by a noisy evaluation.
+func SlopeBelow(eps float64, k int) StopCondition {
+	return &slope{improvement{eps: eps, k: k}}
+}
+
+func (x *slope) Stop(s Stats) bool {
+	if x.fs = append(x.fs, s.Fitness); len(x.fs) > x.k+1 {
+		x.fs = x.fs[1:]
+	}
+	if len(x.fs) <= x.k || x.k < 1 {
+		return false
+	}
+	// The slope of the fitnesses against the generations 0, 1, ..., k, centered at k/2.
+	c, sxy, sxx := float64(x.k)/2, 0.0, 0.0
+	for i, f := range x.fs {
+		d := float64(i) - c
+		sxy += d * f
+		sxx += d * d
+	}
+	return s.gain(0, sxy/sxx) < x.eps
+}
+
+// StopFunc is met when f returns true, with the statistics of the last generation.
+type StopFunc func(s Stats) bool
+
+// Start does nothing.
+func (StopFunc) Start(s Stats) {}
+
+// Stop calls f.
+func (f StopFunc) Stop(s Stats) bool {
+	return f(s)
+}
@@ -0,0 +1,78 @@
+package ga_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ofunc/ga"
+)
+
+func TestStopConditions(t *testing.T) {
+	m := ga.New(50, MIN{}.Mutate, ga.WithDiversityMetric(spread))
+	g := func() int {
+		return m.SnapshotStats().Generation
+	}
+
+	n := g()
+	m.EvolveUntil(ga.MaxGenerations(5))
+	if g()-n != 5 {
+		t.Fatal("generations:", g()-n)
+	}
+
+	e := m.SnapshotStats().Evaluations
+	m.EvolveUntil(ga.MaxEvaluations(120))
+	if d := m.SnapshotStats().Evaluations - e; d != 150 {
+		t.Fatal("evaluations:", d)
+	}
+
+	if _, f := m.EvolveUntil(ga.Any(ga.Target(-0.1), ga.MaxGenerations(1000))); f < -0.1 {
+		t.Fatal("target:", f)
+	}
+
+	n = g()
+	m.EvolveUntil(ga.All(ga.MaxGenerations(3), ga.MaxGenerations(7)))
+	if g()-n != 7 {
+		t.Fatal("all:", g()-n)
+	}
+
+	n = g()
+	m.EvolveUntil(ga.Any(ga.MaxGenerations(3), ga.MaxGenerations(7)))
+	if g()-n != 3 {
+		t.Fatal("any:", g()-n)
+	}
+
+	n = g()
+	m.EvolveUntil(ga.Stagnation(10))
+	if g()-n < 10 {
+		t.Fatal("stagnation:", g()-n)
+	}
+
+	start := time.Now()
+	m.EvolveUntil(ga.Timeout(10 * time.Millisecond))
+	if d := time.Since(start); d < 10*time.Millisecond {
+		t.Fatal("timeout:", d)
+	}
+
+	m.EvolveUntil(ga.Any(ga.DiversityBelow(1), ga.MaxGenerations(1000)))
+	if d := m.Diversity(); d >= 1 {
+		t.Fatal("diversity:", d)
+	}
+
+	n = g()
+	m.EvolveUntil(ga.StopFunc(func(s ga.Stats) bool {
+		return s.Generation >= n+3
+	}))
+	if g()-n != 3 {
+		t.Fatal("func:", g()-n)
+	}
+
+	n = g()
+	fs := []float64{m.Fitness()}
+	m.OnGeneration(func(gen int, e ga.Entity, f float64, s ga.Stats) {
+		fs = append(fs, f)
+	})
+	m.EvolveUntil(ga.Any(ga.ImprovementBelow(1e-3, 5), ga.MaxGenerations(1000)))
+	if k := len(fs); k < 6 || k > 1000 || fs[k-1]-fs[k-6] >= 1e-3 {
+		t.Fatal("improvement:", fs)
+	}
+}
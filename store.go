@@ -0,0 +1,203 @@
+package ga
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+)
+
+// Store is a key-value store persisting the elites, see WithEliteStore.
+// Get returns false if key is not found.
+type Store interface {
+	Get(key string) ([]byte, bool, error)
+	Put(key string, value []byte) error
+}
+
+// WithEliteStore persists the elite, and the hall of fame if WithHallOfFame is set, into s,
+// encoded by the codec registered as name, whenever the elite improves,
+// so that the next run can warm start from them by LoadElites and NewFrom.
+// The entries of the previous run are overwritten by the first improvement of the run, even if they were fitter,
+// since the fitnesses may not be comparable across the runs, e.g. of the changed data.
+// The errors of the store are reported by Err.
+// It panics if name is not registered.
+func WithEliteStore(s Store, name string) Option {
+	c, err := lookupCodec(name)
+	if err != nil {
+		panic(err)
+	}
+	return func(m *GA) {
+		m.store = &eliteStore{s: s, codec: c, name: name}
+	}
+}
+
+type eliteStore struct {
+	s     Store
+	codec Codec
+	name  string
+}
+
+// stored is an entry of an elite store.
+type stored struct {
+	Codec   string
+	Fitness float64
+	Entity  []byte
+}
+
+// persist writes the elite and the hall of fame into the store.
+func (m *GA) persist() {
+	es, fs := []Entity{m.elite}, []float64{m.objective(m.fitness)}
+	if m.fame != nil {
+		hs, hfs := m.HallOfFame()
+		es, fs = append(es, hs...), append(fs, hfs...)
+	}
+	for i, e := range es {
+		b, err := m.store.codec.Encode(e)
+		if err != nil {
+			m.fail("store", err)
+			return
+		}
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(stored{m.store.name, fs[i], b}); err != nil {
+			m.fail("store", err)
+			return
+		}
+		if err := m.store.s.Put(storeKey(i), buf.Bytes()); err != nil {
+			m.fail("store", err)
+			return
+		}
+	}
+	// The count is written last, so the stale entries of a larger hall of fame are never read.
+	if err := m.store.s.Put(countKey, []byte(strconv.Itoa(len(es)))); err != nil {
+		m.fail("store", err)
+	}
+}
+
+// countKey is the key of the number of the entries written by the last persist.
+const countKey = "count"
+
+// storeKey returns the key of the i-th entry, where 0 is the elite, and the others are the hall of fame.
+func storeKey(i int) string {
+	if i == 0 {
+		return "elite"
+	}
+	return "fame-" + strconv.Itoa(i-1)
+}
+
+// LoadElites loads the elite and the hall of fame persisted by WithEliteStore from s,
+// without the duplicates distinguished like by WithHallOfFame, e.g. as the seeds of NewFrom,
+// and returns them with their fitnesses, the elite first.
+// Only the entries of the last persist are loaded, as recorded by its count.
+// It returns no entities without an error if s is empty.
+func LoadElites(s Store) ([]Entity, []float64, error) {
+	var es []Entity
+	var fs []float64
+	seen := make(map[interface{}]bool)
+	b, ok, err := s.Get(countKey)
+	if err != nil || !ok {
+		return nil, nil, err
+	}
+	n, err := strconv.Atoi(string(b))
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := 0; i < n; i++ {
+		b, ok, err := s.Get(storeKey(i))
+		if err != nil {
+			return nil, nil, err
+		}
+		if !ok {
+			return nil, nil, fmt.Errorf("ga: missing %s of %d entries", storeKey(i), n)
+		}
+		var x stored
+		if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&x); err != nil {
+			return nil, nil, err
+		}
+		c, err := lookupCodec(x.Codec)
+		if err != nil {
+			return nil, nil, err
+		}
+		e, err := c.Decode(x.Entity)
+		if err != nil {
+			return nil, nil, err
+		}
+		if id := identity(e); id != nil {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+		}
+		es, fs = append(es, e), append(fs, x.Fitness)
+	}
+	return es, fs, nil
+}
+
+// MemoryStore is a Store in memory, which is safe for concurrent use.
+type MemoryStore struct {
+	mutex sync.Mutex
+	m     map[string][]byte
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{m: make(map[string][]byte)}
+}
+
+// Get returns a copy of the value of key.
+func (s *MemoryStore) Get(key string) ([]byte, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	v, ok := s.m[key]
+	return append([]byte(nil), v...), ok, nil
+}
+
+// Put sets the value of key to a copy of value.
+func (s *MemoryStore) Put(key string, value []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.m[key] = append([]byte(nil), value...)
+	return nil
+}
+
+// FileStore is a Store of the files in a directory, one file per key.
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore creates a FileStore in the directory dir, which is created if it does not exist.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &FileStore{dir}, nil
+}
+
+// Get reads the file of key.
+func (s *FileStore) Get(key string) ([]byte, bool, error) {
+	b, err := os.ReadFile(filepath.Join(s.dir, key))
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	return b, err == nil, err
+}
+
+// Put writes the file of key, by a temporary file renamed over it, so the file is never partially written.
+func (s *FileStore) Put(key string, value []byte) error {
+	f, err := os.CreateTemp(s.dir, key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(value); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), filepath.Join(s.dir, key))
+}
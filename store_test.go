@@ -0,0 +1,48 @@
+package ga_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestEliteStore(t *testing.T) {
+	g := func() ga.Entity {
+		return Walk(20*rand.Float64() - 10)
+	}
+	fs, err := ga.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []ga.Store{ga.NewMemoryStore(), fs} {
+		if es, _, err := ga.LoadElites(s); err != nil || len(es) != 0 {
+			t.Fatal("empty:", es, err)
+		}
+		m := ga.New(20, g, ga.WithEliteStore(s, "walk"), ga.WithHallOfFame(5))
+		m.EvolveTo(10)
+		if err := m.Err(); err != nil {
+			t.Fatal(err)
+		}
+		es, fits, err := ga.LoadElites(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(es) != 5 || es[0] != m.Elite() || fits[0] != m.Fitness() {
+			t.Fatal("elites:", es, fits, m.Elite())
+		}
+
+		x := ga.NewFrom(20, es, g, ga.WithEliteStore(s, "walk"))
+		if x.Fitness() < m.Fitness() {
+			t.Fatal("warm start:", x.Fitness(), m.Fitness())
+		}
+
+		y := ga.New(20, func() ga.Entity {
+			return Walk(100 + rand.Float64())
+		}, ga.WithEliteStore(s, "walk"))
+		y.EvolveTo(10)
+		if es, _, err := ga.LoadElites(s); err != nil || len(es) != 1 || es[0] != y.Elite() {
+			t.Fatal("stale elites:", es, err, y.Elite())
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package ga
+
+// WithReproducibleParallel derives an independent random stream for each slot of each generation from the seed,
+// instead of sharing the random source between the workers, whose interleaving depends on the scheduling.
+// The statistics of the populations are reduced sequentially too.
+// So the same seed always yields the same evolution regardless of the concurrency and GOMAXPROCS,
+// provided that the operators of the entities are deterministic too.
+func WithReproducibleParallel() Option {
+	return func(m *GA) {
+		m.streams, m.pop.stable = true, true
+	}
+}
+
+// random returns the random numbers in [0, 1) for the slot i produced by the worker c.
+// By default, each worker has its own stream, so the workers do not contend for the random source.
+func (m *GA) random(c, i int) func() float64 {
+	if !m.streams {
+		return m.workers[c].Float64
+	}
+	s := stream(mix(uint64(m.seed) ^ mix(uint64(m.gen)<<32|uint64(uint32(i)))))
+	return s.Float64
+}
+
+// fork seeds the streams of the workers from the random source of the model, before a parallel variation.
+// So the streams are restored with the random source from a checkpoint.
+func (m *GA) fork() {
+	if m.streams {
+		return
+	}
+	if nc := m.pop.concurrency(); len(m.workers) != nc {
+		m.workers = make([]worker, nc)
+	}
+	for c := range m.workers {
+		m.workers[c].stream = stream(m.rnd.Uint64())
+	}
+}
+
+// worker is the stream of a worker, padded to two cache lines,
+// so the streams of the workers never share a cache line.
+type worker struct {
+	stream
+	_ [2*cacheLine - 8]byte
+}
+
+// stream is a splitmix64 generator, which is cheap to create for every slot.
+type stream uint64
+
+func (s *stream) Float64() float64 {
+	*s += 0x9e3779b97f4a7c15
+	return float64(mix(uint64(*s))>>11) / (1 << 53)
+}
+
+func mix(z uint64) uint64 {
+	z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+	z = (z ^ z>>27) * 0x94d049bb133111eb
+	return z ^ z>>31
+}
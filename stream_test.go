@@ -0,0 +1,40 @@
+package ga_test
+
+import (
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestReproducibleParallel(t *testing.T) {
+	nc := ga.NC
+	defer func() { ga.NC = nc }()
+
+	run := func(n int) []ga.Entity {
+		ga.NC = n
+		m := ga.New(40, nil, ga.WithSeedSolution(Saved(-4), 3), ga.WithSeed(11), ga.WithReproducibleParallel())
+		return m.EvolveTo(15)
+	}
+	xs, ys := run(1), run(4)
+	for i := range xs {
+		if xs[i] != ys[i] {
+			t.Fatal("NC dependent:", i, xs[i], ys[i])
+		}
+	}
+}
+
+func TestWorkerStreams(t *testing.T) {
+	nc := ga.NC
+	ga.NC = 4
+	defer func() { ga.NC = nc }()
+
+	run := func() []ga.Entity {
+		return ga.New(40, nil, ga.WithSeedSolution(Saved(-4), 3), ga.WithSeed(11)).EvolveTo(15)
+	}
+	xs, ys := run(), run()
+	for i := range xs {
+		if xs[i] != ys[i] {
+			t.Fatal("not reproducible:", i, xs[i], ys[i])
+		}
+	}
+}
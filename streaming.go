@@ -0,0 +1,172 @@
+package ga
+
+import "container/heap"
+
+// WithStreaming switches the GA model to the streaming mode for very large populations,
+// where each generation is a virtual population of n entities, but only the breeding pool of the size given to New is kept.
+// The n entities are produced from the pool and evaluated in batches of at most batch, or of the pool size,
+// and fed into the next pool, which keeps the fittest entities seen, except for the fraction reservoir of its slots,
+// which keep a uniform sample of the others by reservoir sampling, to preserve the diversity.
+// The initial population is streamed from the generator in the same way.
+// The entities not kept by the pool are discarded as by WithRecycle, and released if they are Releaser.
+// So the memory is bounded by the pool and a batch instead of n, at the cost of the selection being exact only within the pool,
+// e.g. the statistics and the selection weights are of the pool.
+func WithStreaming(n, batch int, reservoir float64) Option {
+	return func(m *GA) {
+		m.streaming = &streaming{n: n, batch: batch, reservoir: reservoir}
+	}
+}
+
+// streaming is the state of WithStreaming.
+type streaming struct {
+	n         int
+	batch     int
+	reservoir float64
+}
+
+// intake keeps the fittest entities fed, and a uniform sample of the others,
+// and passes the entities it does not keep to drop.
+type intake struct {
+	top  fittest
+	k    int
+	res  []Entity
+	rfs  []float64
+	seen int
+	drop func(...Entity)
+}
+
+// fittest is a min-heap of the fittest entities.
+type fittest struct {
+	es []Entity
+	fs []float64
+}
+
+func (h fittest) Len() int           { return len(h.es) }
+func (h fittest) Less(i, j int) bool { return h.fs[i] < h.fs[j] }
+func (h fittest) Swap(i, j int) {
+	h.es[i], h.es[j] = h.es[j], h.es[i]
+	h.fs[i], h.fs[j] = h.fs[j], h.fs[i]
+}
+
+func (h *fittest) Push(x interface{}) {
+	y := x.(scored)
+	h.es, h.fs = append(h.es, y.e), append(h.fs, y.f)
+}
+
+func (h *fittest) Pop() interface{} {
+	n := len(h.es) - 1
+	y := scored{h.es[n], h.fs[n]}
+	h.es, h.fs = h.es[:n], h.fs[:n]
+	return y
+}
+
+// scored is an entity with its fitness.
+type scored struct {
+	e Entity
+	f float64
+}
+
+// feed feeds an entity, with the random numbers of u for the reservoir.
+func (t *intake) feed(e Entity, f float64, u func() float64, r int) {
+	if len(t.top.es) < t.k {
+		heap.Push(&t.top, scored{e, f})
+		return
+	}
+	if t.k > 0 && f > t.top.fs[0] {
+		e, f, t.top.es[0], t.top.fs[0] = t.top.es[0], t.top.fs[0], e, f
+		heap.Fix(&t.top, 0)
+	}
+	if r == 0 {
+		t.drop(e)
+		return
+	}
+	if t.seen++; len(t.res) < r {
+		t.res, t.rfs = append(t.res, e), append(t.rfs, f)
+	} else if j := index(u(), t.seen); j < r {
+		t.drop(t.res[j])
+		t.res[j], t.rfs[j] = e, f
+	} else {
+		t.drop(e)
+	}
+}
+
+// streamInto streams n entities produced by f in batches into es and fs of the pool size,
+// after the evaluated entities fed by seed.
+// The batch starting at j takes the spares from j, so that no offspring kept by the intake is overwritten by a later batch.
+func (m *GA) streamInto(es []Entity, fs []float64, n int, seed func(t *intake, r int), f func(c, i, j int) Entity) {
+	k := len(es)
+	r := int(m.streaming.reservoir*float64(k) + 0.5)
+	if r > k-1 {
+		r = k - 1
+	}
+	if r < 0 {
+		r = 0
+	}
+	t := &intake{k: k - r, drop: m.drop}
+	if seed != nil {
+		seed(t, r)
+	}
+	b := m.streaming.batch
+	if b <= 0 || b > k {
+		b = k
+	}
+	bes, bfs := make([]Entity, b), make([]float64, b)
+	spare := m.recycler.spare
+	defer func() { m.recycler.spare = spare }()
+	for j := 0; j < n || t.top.Len()+len(t.res) < k; j += b {
+		if j < len(spare) {
+			m.recycler.spare = spare[j:]
+		} else {
+			m.recycler.spare = nil
+		}
+		m.fork()
+		if m.sus && m.pick == nil {
+			m.spin(b)
+		}
+		m.do(func(c, i int) {
+			if i < b {
+				bes[i] = f(c, i, j+i)
+			}
+		})
+		m.pop.evaluateInto(bes, bfs)
+		for i := range bes {
+			t.feed(bes[i], bfs[i], m.rnd.Float64, r)
+		}
+	}
+	copy(es, t.top.es)
+	copy(fs, t.top.fs)
+	copy(es[len(t.top.es):], t.res)
+	copy(fs[len(t.top.es):], t.rfs)
+}
+
+// streamInitial streams the rest of the initial population from the generator, after the evaluated pool.
+func (m *GA) streamInitial() float64 {
+	p := &m.pop
+	m.streamInto(p.entities, p.fitnesses, m.streaming.n-m.n, func(t *intake, r int) {
+		for i, e := range p.entities {
+			t.feed(e, p.fitnesses[i], m.rnd.Float64, r)
+		}
+	}, func(c, i, j int) Entity {
+		return m.g()
+	})
+	return m.normalize()
+}
+
+// streamNext streams the next generation of offspring, after the elites of the pool.
+func (m *GA) streamNext() {
+	p := &m.pop
+	m.streamInto(m.tentities, m.tfitnesses, m.streaming.n-m.elitism, func(t *intake, r int) {
+		if m.elitism <= 0 {
+			return
+		}
+		idx := order(p.fitnesses)
+		for j := 0; j < m.elitism && j < m.n; j++ {
+			i := idx[m.n-1-j]
+			t.feed(p.entities[i], p.fitnesses[i], m.rnd.Float64, r)
+		}
+	}, func(c, i, j int) Entity {
+		return m.offspring(i, m.random(c, j))
+	})
+	m.swap()
+	m.normalize()
+}
@@ -0,0 +1,70 @@
+package ga_test
+
+import (
+	"math/rand"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestStreaming(t *testing.T) {
+	g := func() ga.Entity {
+		return Walk(20*rand.Float64() - 10)
+	}
+	m := ga.New(10, g, ga.WithStreaming(1000, 50, 0.2))
+	if n := m.Evaluations(); n != 1000 {
+		t.Fatal("initial evaluations:", n)
+	}
+	if f := m.Fitness(); f < -0.01 {
+		t.Fatal("initial fitness:", f)
+	}
+	es := m.Population()
+	if len(es) != 10 {
+		t.Fatal("pool:", len(es))
+	}
+	worst := 0.0
+	for _, e := range es {
+		if f := e.Fitness(); f < worst {
+			worst = f
+		}
+	}
+	if worst > -1 {
+		t.Fatal("no reservoir:", worst)
+	}
+
+	m.Next()
+	if n := m.Evaluations(); n != 2000 {
+		t.Fatal("evaluations:", n)
+	}
+	if e, f, _ := m.Evolve(10, 100); f < -1e-4 || e.Fitness() != f {
+		t.Fatal("evolve:", e, f)
+	}
+}
+
+func TestStreamingMutable(t *testing.T) {
+	m := ga.New(20, func() ga.Entity {
+		b := newBuffer(nil)
+		for i := range b.X {
+			b.X[i] = rand.Float64()
+		}
+		return b
+	}, ga.WithStreaming(100, 5, 0.5), ga.WithFixedMutationRate(1))
+	for i := 0; i < 5; i++ {
+		m.Next()
+		if f := m.Elite().Fitness(); f != m.Fitness() {
+			t.Fatal("elite overwritten:", i, f, m.Fitness())
+		}
+	}
+}
+
+func TestStreamingReleaser(t *testing.T) {
+	var released int32
+	m := ga.New(10, func() ga.Entity {
+		return &Handle{Boxed{Walk(rand.Float64())}, &released}
+	}, ga.WithStreaming(200, 20, 0.5))
+	m.Next()
+	if n := atomic.LoadInt32(&released); n < 190 {
+		t.Fatal("released:", n)
+	}
+}
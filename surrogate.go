@@ -0,0 +1,84 @@
+package ga
+
+import (
+	"math"
+	"sort"
+)
+
+// Surrogate is a cheap model of the fitness, which pre-screens the offspring, see WithSurrogate.
+type Surrogate interface {
+	// Predict predicts the fitnesses of es into fs, which have the same length.
+	Predict(es []Entity, fs []float64)
+	// Train recalibrates the surrogate by the true fitnesses fs of es.
+	Train(es []Entity, fs []float64)
+}
+
+// WithSurrogate pre-screens each batch of entities, the whole generation or the new entities of an incremental update,
+// by the predictions of s, and evaluates only the round(frac*len) most promising of them by the true fitness,
+// so the expensive evaluations are spent on the entities likely to survive.
+// The others get their predicted fitnesses, capped at the worst true fitness of the batch,
+// so a screened entity never outranks an evaluated one, nor becomes the elite.
+// s is trained by the initial population, and then every interval generations
+// by the true fitnesses evaluated since its last training.
+// The true fitnesses are evaluated by the evaluator set by WithBatchEvaluator and the like, if any,
+// and frac is clamped to (0, 1].
+func WithSurrogate(s Surrogate, frac float64, interval int) Option {
+	return func(m *GA) {
+		if interval < 1 {
+			interval = 1
+		}
+		var tes []Entity
+		var tfs []float64
+		trained := -1
+		m.screen = func(es []Entity, fs []float64, evaluate func(es []Entity, fs []float64)) {
+			if trained < 0 {
+				evaluate(es, fs)
+				s.Train(es, m.raw(fs))
+				trained = m.gen
+				return
+			}
+			ps := make([]float64, len(es))
+			s.Predict(es, ps)
+			for i, f := range ps {
+				ps[i] = m.objective(f)
+			}
+			idx := order(ps)
+			k := int(math.Round(math.Max(0, math.Min(1, frac)) * float64(len(es))))
+			if k < 1 {
+				k = 1
+			}
+			idx = idx[len(idx)-k:]
+			sort.Ints(idx)
+			xs, ys := make([]Entity, k), make([]float64, k)
+			for j, i := range idx {
+				xs[j] = es[i]
+			}
+			evaluate(xs, ys)
+			worst := math.Inf(1)
+			for _, f := range ys {
+				worst = math.Min(worst, f)
+			}
+			for i, f := range ps {
+				fs[i] = math.Min(f, worst)
+			}
+			for j, i := range idx {
+				fs[i] = ys[j]
+			}
+			tes, tfs = append(tes, xs...), append(tfs, ys...)
+			// The offspring are evaluated before the generation is counted.
+			if m.gen+1-trained >= interval {
+				s.Train(tes, m.raw(tfs))
+				tes, tfs, trained = nil, nil, m.gen+1
+			}
+		}
+	}
+}
+
+// raw returns the objective values of the fitnesses fs.
+func (m *GA) raw(fs []float64) []float64 {
+	vs := make([]float64, len(fs))
+	for i, f := range fs {
+		vs[i] = m.objective(f)
+	}
+	return vs
+}
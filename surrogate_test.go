@@ -0,0 +1,75 @@
+package ga_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+// quadratic is a surrogate fitting the fitnesses of Walk by a parabola with the vertex at the best sample.
+type quadratic struct {
+	vertex  float64
+	trained int
+}
+
+func (q *quadratic) Predict(es []ga.Entity, fs []float64) {
+	for i, e := range es {
+		d := float64(e.(Walk)) - q.vertex
+		fs[i] = -d * d
+	}
+}
+
+func (q *quadratic) Train(es []ga.Entity, fs []float64) {
+	b := 0
+	for i := range fs {
+		if fs[i] > fs[b] {
+			b = i
+		}
+	}
+	q.vertex = float64(es[b].(Walk))
+	q.trained++
+}
+
+func TestSurrogate(t *testing.T) {
+	q := &quadratic{}
+	m := ga.New(40, func() ga.Entity {
+		return Walk(rand.Float64()*4 - 2)
+	}, ga.WithSurrogate(q, 0.25, 5))
+	if n := m.Evaluations(); n != 40 {
+		t.Fatal("initial evaluations:", n)
+	}
+	m.EvolveTo(50)
+	if n := m.Evaluations(); n != 40+50*10 {
+		t.Fatal("evaluations:", n)
+	}
+	if q.trained != 11 {
+		t.Fatal("trained:", q.trained)
+	}
+	if f := m.Fitness(); f < -1e-2 || f != -sqr(float64(m.Elite().(Walk))-1) {
+		t.Fatal("fitness:", f)
+	}
+}
+
+// Tally is a batch evaluator counting its evaluations.
+type Tally struct {
+	n int
+}
+
+func (b *Tally) Evaluate(es []ga.Entity, fs []float64) {
+	b.n += len(es)
+	for i, e := range es {
+		fs[i] = e.Fitness()
+	}
+}
+
+func TestSurrogateBatch(t *testing.T) {
+	b := &Tally{}
+	m := ga.New(40, func() ga.Entity {
+		return Walk(rand.Float64()*4 - 2)
+	}, ga.WithSurrogate(&quadratic{}, 0.25, 5), ga.WithBatchEvaluator(b))
+	m.EvolveTo(10)
+	if b.n != 40+10*10 || m.Evaluations() != int64(b.n) {
+		t.Fatal("evaluations:", b.n, m.Evaluations())
+	}
+}
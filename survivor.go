@@ -0,0 +1,86 @@
+package ga
+
+import (
+	"math/rand/v2"
+	"sort"
+)
+
+// Survivor is a survivor selection of the (μ+λ) scheme.
+// Each generation, the μ parents and λ offspring (μ = λ = n) are pooled,
+// and the n survivors form the next generation.
+type Survivor interface {
+	// Survive returns the indices of k survivors, chosen from the pool with fitnesses fs.
+	Survive(fs []float64, k int, rnd *rand.Rand) []int
+}
+
+// WithSurvivorSelection switches the GA model to the (μ+λ) scheme with the survivor selection s,
+// the same as WithReplacement(PlusReplacer(s), 0).
+// By default, the offspring fully replace the parents.
+func WithSurvivorSelection(s Survivor) Option {
+	return func(m *GA) {
+		m.survivor = s
+	}
+}
+
+type truncation struct{}
+
+// TruncationSurvivor keeps the k fittest of the pool.
+// It is strongly elitist and converges fast, but loses diversity quickly.
+func TruncationSurvivor() Survivor {
+	return truncation{}
+}
+
+func (truncation) Survive(fs []float64, k int, rnd *rand.Rand) []int {
+	idx := make([]int, len(fs))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		return fs[idx[i]] > fs[idx[j]]
+	})
+	return idx[:k]
+}
+
+type tournament int
+
+// TournamentSurvivor keeps the winners of k tournaments of the given size, drawn without replacement from the pool.
+// Unlike truncation, less fit individuals survive with some probability, which preserves diversity.
+// The fittest individual of the pool may be lost, but the elite of GA model is always remembered.
+func TournamentSurvivor(size int) Survivor {
+	if size < 1 {
+		size = 1
+	}
+	return tournament(size)
+}
+
+func (t tournament) Survive(fs []float64, k int, rnd *rand.Rand) []int {
+	pool := make([]int, len(fs))
+	for i := range pool {
+		pool[i] = i
+	}
+	idx := make([]int, 0, k)
+	for len(idx) < k {
+		b := rnd.IntN(len(pool))
+		for j := 1; j < int(t); j++ {
+			if c := rnd.IntN(len(pool)); fs[pool[c]] > fs[pool[b]] {
+				b = c
+			}
+		}
+		idx = append(idx, pool[b])
+		pool[b] = pool[len(pool)-1]
+		pool = pool[:len(pool)-1]
+	}
+	return idx
+}
+
+func (m *GA) survive() {
+	n := m.n
+	m.pop.evaluateInto(m.tentities, m.tfitnesses)
+	pool := append(m.pop.entities[:n:n], m.tentities...)
+	fs := append(m.pop.fitnesses[:n:n], m.tfitnesses...)
+	for j, i := range m.survivor.Survive(fs, n, m.rnd) {
+		m.tentities[j], m.tfitnesses[j] = pool[i], fs[i]
+	}
+	m.swap()
+	m.normalize()
+}
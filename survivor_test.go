@@ -0,0 +1,28 @@
+package ga_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestSurvivorSelection(t *testing.T) {
+	for _, s := range []ga.Survivor{ga.TruncationSurvivor(), ga.TournamentSurvivor(3)} {
+		m := ga.New(100, MIN{}.Mutate, ga.WithSurvivorSelection(s))
+		e, f, _ := m.Evolve(30, 200)
+		if math.Abs(f-e.Fitness()) > 1e-10 {
+			t.FailNow()
+		}
+		if f < -1e-2 {
+			t.Fatal("fitness(0):", f)
+		}
+	}
+}
+
+func TestTruncationSurvivor(t *testing.T) {
+	idx := ga.TruncationSurvivor().Survive([]float64{1, 5, 3, 4, 2}, 3, nil)
+	if len(idx) != 3 || idx[0] != 1 || idx[1] != 3 || idx[2] != 2 {
+		t.Fatal("survivors:", idx)
+	}
+}
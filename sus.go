@@ -0,0 +1,46 @@
+package ga
+
+// WithStochasticUniversalSampling replaces the default roulette selection by stochastic universal sampling.
+// Once every generation, a single spin of the roulette with evenly spaced pointers chooses all the parents,
+// which are shuffled into pairs, so the number of times an entity is chosen is within one of its expected value.
+// It has a much lower selection variance than independent spins, and the selection needs no random numbers of the workers.
+// It is ignored by WithSelector.
+func WithStochasticUniversalSampling() Option {
+	return func(m *GA) {
+		m.sus = true
+	}
+}
+
+// spin chooses the parents of k offspring by stochastic universal sampling, with the random source of the model.
+func (m *GA) spin(k int) {
+	p := &m.pop
+	k *= 2
+	if cap(m.mates) < k {
+		m.mates = make([]int, k)
+	}
+	m.mates = m.mates[:k]
+	step := p.fsum / float64(k)
+	r, i, c := m.rnd.Float64()*step, 0, p.weights[0]
+	for j := range m.mates {
+		for r >= c && i < m.n-1 {
+			i++
+			c += p.weights[i]
+		}
+		m.mates[j] = i
+		r += step
+	}
+	m.rnd.Shuffle(k, func(i, j int) {
+		m.mates[i], m.mates[j] = m.mates[j], m.mates[i]
+	})
+}
+
+// mate returns the indices of the parents of the slot i chosen by spin.
+func (m *GA) mate(i int) (int, int, float64) {
+	p := &m.pop
+	x, y := m.mates[2*i], m.mates[2*i+1]
+	w := 0.5
+	if s := p.weights[x] + p.weights[y]; s > 0 {
+		w = p.weights[x] / s
+	}
+	return x, y, w
+}
@@ -0,0 +1,55 @@
+package ga_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+var (
+	chosen      = map[int]int{}
+	chosenMutex sync.Mutex
+)
+
+// Tagged is an entity of flat fitness, which counts the times it is chosen as a parent.
+type Tagged int
+
+func (x Tagged) Fitness() float64 {
+	return 0
+}
+
+func (x Tagged) Mutate() ga.Entity {
+	return x
+}
+
+func (x Tagged) Crossover(e ga.Entity, w float64) ga.Entity {
+	chosenMutex.Lock()
+	chosen[int(x)]++
+	chosen[int(e.(Tagged))]++
+	chosenMutex.Unlock()
+	return x
+}
+
+func TestStochasticUniversalSampling(t *testing.T) {
+	i := 0
+	m := ga.New(50, func() ga.Entity {
+		i++
+		return Tagged(i)
+	}, ga.WithStochasticUniversalSampling())
+	chosen = map[int]int{}
+	m.Next()
+	if len(chosen) != 50 {
+		t.Fatal("chosen:", len(chosen))
+	}
+	for x, k := range chosen {
+		if k != 2 {
+			t.Fatal("times:", x, k)
+		}
+	}
+
+	a := ga.New(50, MIN{}.Mutate, ga.WithStochasticUniversalSampling())
+	if _, f, _ := a.Evolve(30, 300); f < -0.01 {
+		t.Fatal("fitness:", f)
+	}
+}
@@ -0,0 +1,15 @@
+package ga
+
+// EvolveToTarget runs the GA model until the fitness of the elite reaches target, from below, or from above with WithMinimize,
+// or max generations have been produced, without waiting for the stagnation, e.g. on the benchmarks with known optima.
+// It returns the elite and fitness, the evaluations used by the run, and whether the target is reached.
+// It returns at once if the target is already reached.
+func (m *GA) EvolveToTarget(target float64, max int) (Entity, float64, int64, bool) {
+	evals, t := m.Evaluations(), Target(target)
+	reached := t.Stop(m.SnapshotStats())
+	for i := 0; i < max && !reached; i++ {
+		m.Next()
+		reached = t.Stop(m.SnapshotStats())
+	}
+	return m.elite, m.objective(m.fitness), m.Evaluations() - evals, reached
+}
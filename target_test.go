@@ -0,0 +1,25 @@
+package ga_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestEvolveToTarget(t *testing.T) {
+	m := ga.New(20, func() ga.Entity {
+		return Walk(rand.Float64()*4 - 2)
+	})
+	e, f, n, ok := m.EvolveToTarget(-1e-3, 1000)
+	if !ok || f < -1e-3 || e != m.Elite() || n != m.Evaluations()-20 || m.Generation() >= 1000 {
+		t.Fatal("target:", f, n, ok, m.Generation())
+	}
+	gen := m.Generation()
+	if _, _, n, ok := m.EvolveToTarget(-1e-3, 1000); !ok || n != 0 || m.Generation() != gen {
+		t.Fatal("reached:", n, ok, m.Generation())
+	}
+	if _, _, _, ok := m.EvolveToTarget(1, 5); ok || m.Generation() != gen+5 {
+		t.Fatal("unreachable:", ok, m.Generation())
+	}
+}
@@ -0,0 +1,123 @@
+package ga
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// TensorBoard is a sink which writes the scalars as TensorFlow event files,
+// so that GA runs can be visualized by TensorBoard.
+type TensorBoard struct {
+	f   *os.File
+	w   *bufio.Writer
+	err error
+}
+
+// NewTensorBoard creates a TensorBoard sink, which writes an event file into logdir.
+func NewTensorBoard(logdir string) (*TensorBoard, error) {
+	if err := os.MkdirAll(logdir, 0755); err != nil {
+		return nil, err
+	}
+	host, _ := os.Hostname()
+	now := time.Now()
+	name := fmt.Sprintf("events.out.tfevents.%d.%s", now.Unix(), host)
+	f, err := os.Create(filepath.Join(logdir, name))
+	if err != nil {
+		return nil, err
+	}
+	tb := &TensorBoard{f: f, w: bufio.NewWriter(f)}
+	e := pbFixed64(nil, 1, math.Float64bits(seconds(now)))
+	e = pbBytes(e, 3, []byte("brain.Event:2"))
+	tb.record(e)
+	return tb, tb.err
+}
+
+// Log writes the scalars of a generation as an event.
+func (tb *TensorBoard) Log(gen int, scalars []Scalar) {
+	var s []byte
+	for _, x := range scalars {
+		v := pbBytes(nil, 1, []byte(x.Tag))
+		v = pbFixed32(v, 2, math.Float32bits(float32(x.Value)))
+		s = pbBytes(s, 1, v)
+	}
+	e := pbFixed64(nil, 1, math.Float64bits(seconds(time.Now())))
+	e = pbVarint(e, 2, uint64(gen))
+	e = pbBytes(e, 5, s)
+	tb.record(e)
+}
+
+// Flush flushes the buffered events into the file.
+func (tb *TensorBoard) Flush() error {
+	if tb.err == nil {
+		tb.err = tb.w.Flush()
+	}
+	return tb.err
+}
+
+// Close flushes and closes the event file.
+func (tb *TensorBoard) Close() error {
+	err := tb.Flush()
+	if e := tb.f.Close(); err == nil {
+		err = e
+	}
+	return err
+}
+
+func (tb *TensorBoard) record(data []byte) {
+	if tb.err != nil {
+		return
+	}
+	var h [12]byte
+	binary.LittleEndian.PutUint64(h[:8], uint64(len(data)))
+	binary.LittleEndian.PutUint32(h[8:], maskedCRC(h[:8]))
+	var t [4]byte
+	binary.LittleEndian.PutUint32(t[:], maskedCRC(data))
+	for _, b := range [][]byte{h[:], data, t[:]} {
+		if _, tb.err = tb.w.Write(b); tb.err != nil {
+			return
+		}
+	}
+}
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+func maskedCRC(b []byte) uint32 {
+	c := crc32.Checksum(b, castagnoli)
+	return (c>>15 | c<<17) + 0xa282ead8
+}
+
+func seconds(t time.Time) float64 {
+	return float64(t.UnixNano()) / 1e9
+}
+
+func uvarint(b []byte, x uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	return append(b, buf[:binary.PutUvarint(buf[:], x)]...)
+}
+
+func pbVarint(b []byte, field int, x uint64) []byte {
+	return uvarint(uvarint(b, uint64(field<<3)), x)
+}
+
+func pbFixed32(b []byte, field int, x uint32) []byte {
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], x)
+	return append(uvarint(b, uint64(field<<3|5)), buf[:]...)
+}
+
+func pbFixed64(b []byte, field int, x uint64) []byte {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], x)
+	return append(uvarint(b, uint64(field<<3|1)), buf[:]...)
+}
+
+func pbBytes(b []byte, field int, x []byte) []byte {
+	b = uvarint(uvarint(b, uint64(field<<3|2)), uint64(len(x)))
+	return append(b, x...)
+}
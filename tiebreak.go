@@ -0,0 +1,29 @@
+package ga
+
+// WithTieBreaker breaks the ties of the fittest entities by better, which reports whether a is better than b,
+// e.g. by a secondary objective or a canonical order of the genomes, so the elite is stable across runs and concurrencies.
+// Without it, the ties of a population are broken by the lowest index, and the elite is kept on a tie.
+// The tie breaker is applied to the fittest entities of every generation, and to the elite on a tie with them.
+func WithTieBreaker(better func(a, b Entity) bool) Option {
+	return func(m *GA) {
+		m.pop.better = better
+	}
+}
+
+// breakTies chooses the best of the fittest entities by the tie breaker.
+func (p *Population) breakTies() {
+	f := p.fitnesses[p.ibest]
+	for i, g := range p.fitnesses {
+		if g == f && i != p.ibest && p.better(p.entities[i], p.entities[p.ibest]) {
+			p.ibest = i
+		}
+	}
+}
+
+// fitter reports whether e of the fitness f should replace the elite.
+func (m *GA) fitter(e Entity, f float64) bool {
+	if m.fitness < f {
+		return true
+	}
+	return m.pop.better != nil && m.fitness == f && e != nil && m.elite != nil && m.pop.better(e, m.elite)
+}
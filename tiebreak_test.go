@@ -0,0 +1,46 @@
+package ga_test
+
+import (
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+// Plateau is an entity whose fitness is 1 if it is even, and 0 otherwise.
+type Plateau int
+
+func (p Plateau) Fitness() float64 {
+	return float64(1 - p%2)
+}
+
+func (p Plateau) Mutate() ga.Entity {
+	return p + 1
+}
+
+func (p Plateau) Crossover(e ga.Entity, w float64) ga.Entity {
+	return e
+}
+
+func plateaus(n int) []ga.Entity {
+	es := make([]ga.Entity, n)
+	for i := range es {
+		es[i] = Plateau(2*n - 1 - 2*i)
+	}
+	es[n/2], es[n-1] = Plateau(10), Plateau(4)
+	return es
+}
+
+func TestTieBreaking(t *testing.T) {
+	for _, nc := range []int{1, 3, 8} {
+		m := ga.NewFrom(5000, plateaus(5000), nil, ga.WithConcurrency(nc))
+		if e := m.Elite(); e != Plateau(10) {
+			t.Fatal("lowest index:", nc, e)
+		}
+		m = ga.NewFrom(5000, plateaus(5000), nil, ga.WithConcurrency(nc), ga.WithTieBreaker(func(a, b ga.Entity) bool {
+			return a.(Plateau) < b.(Plateau)
+		}))
+		if e := m.Elite(); e != Plateau(4) {
+			t.Fatal("tie breaker:", nc, e)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package ga
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// TraceFormat is the record format of Trace.
+type TraceFormat int
+
+// The trace formats.
+const (
+	// JSONLines writes one JSON object per generation, with NaN and infinities as null.
+	JSONLines TraceFormat = iota
+	// CSV writes a header line followed by one comma-separated line per generation.
+	CSV
+)
+
+// Trace is a sink which writes one record per generation with the generation, the scalars
+// and the description of the elite by Describe as the last field elite, suitable for plotting by pandas or gnuplot.
+type Trace struct {
+	format TraceFormat
+	w      *bufio.Writer
+	header bool
+	err    error
+}
+
+// NewTrace creates a trace sink writing to w in the format.
+func NewTrace(w io.Writer, format TraceFormat) *Trace {
+	return &Trace{format: format, w: bufio.NewWriter(w)}
+}
+
+// WithTrace adds a trace sink writing to w in the format, besides the sinks set by WithSink.
+func WithTrace(w io.Writer, format TraceFormat) Option {
+	return WithSink(NewTrace(w, format))
+}
+
+// Log writes the record of a generation without the elite.
+func (t *Trace) Log(gen int, scalars []Scalar) {
+	t.write(gen, scalars, "", false)
+}
+
+// LogElite writes the record of a generation with the description of the elite.
+func (t *Trace) LogElite(gen int, scalars []Scalar, elite string) {
+	t.write(gen, scalars, elite, true)
+}
+
+func (t *Trace) write(gen int, scalars []Scalar, elite string, described bool) {
+	if t.err != nil {
+		return
+	}
+	var b []byte
+	switch t.format {
+	case CSV:
+		if !t.header {
+			b = append(b, "gen"...)
+			for _, s := range scalars {
+				b = append(append(b, ','), s.Tag...)
+			}
+			if described {
+				b = append(b, ",elite"...)
+			}
+			b = append(b, '\n')
+			t.header = true
+		}
+		b = strconv.AppendInt(b, int64(gen), 10)
+		for _, s := range scalars {
+			b = strconv.AppendFloat(append(b, ','), s.Value, 'g', -1, 64)
+		}
+		if described {
+			b = appendCSV(append(b, ','), elite)
+		}
+		b = append(b, '\n')
+	default:
+		b = append(b, `{"gen":`...)
+		b = strconv.AppendInt(b, int64(gen), 10)
+		for _, s := range scalars {
+			b = append(strconv.AppendQuote(append(b, ','), s.Tag), ':')
+			if math.IsNaN(s.Value) || math.IsInf(s.Value, 0) {
+				b = append(b, "null"...)
+			} else {
+				b = strconv.AppendFloat(b, s.Value, 'g', -1, 64)
+			}
+		}
+		if described {
+			x, _ := json.Marshal(elite)
+			b = append(append(b, `,"elite":`...), x...)
+		}
+		b = append(b, "}\n"...)
+	}
+	if _, t.err = t.w.Write(b); t.err == nil {
+		t.err = t.w.Flush()
+	}
+}
+
+// appendCSV appends the field s to b, quoted if needed.
+func appendCSV(b []byte, s string) []byte {
+	if !strings.ContainsAny(s, ",\"\r\n") {
+		return append(b, s...)
+	}
+	return append(append(append(b, '"'), strings.ReplaceAll(s, `"`, `""`)...), '"')
+}
+
+// Err returns the first error in writing.
+func (t *Trace) Err() error {
+	return t.err
+}
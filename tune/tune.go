@@ -0,0 +1,216 @@
+// Package tune implements the grid and random search of the hyperparameters of GA model,
+// which runs the model repeatedly for each configuration, and ranks the configurations by the statistics over the repetitions.
+package tune
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"sort"
+
+	"github.com/ofunc/ga"
+)
+
+// Selection is a named parent selection.
+type Selection struct {
+	// Name is the name of the selection.
+	Name string
+	// New creates the selector of a model, or is nil for the default roulette selection.
+	New func() ga.Selector
+}
+
+// Roulette is the default sigmoid scaled roulette selection.
+var Roulette = Selection{"roulette", nil}
+
+// Tournament is the selection of ga.TournamentSelector(size).
+func Tournament(size int) Selection {
+	return Selection{fmt.Sprintf("tournament(%d)", size), func() ga.Selector {
+		return ga.TournamentSelector(size)
+	}}
+}
+
+// Rank is the selection of ga.RankSelector(pressure).
+func Rank(pressure float64) Selection {
+	return Selection{fmt.Sprintf("rank(%g)", pressure), func() ga.Selector {
+		return ga.RankSelector(pressure)
+	}}
+}
+
+// Boltzmann is the selection of ga.BoltzmannSelector(t, cooling).
+func Boltzmann(t, cooling float64) Selection {
+	return Selection{fmt.Sprintf("boltzmann(%g, %g)", t, cooling), func() ga.Selector {
+		return ga.BoltzmannSelector(t, cooling)
+	}}
+}
+
+// Config is a configuration of the hyperparameters.
+type Config struct {
+	// Population is the population size.
+	Population int
+	// PMin and PMax are the bounds of the adaptive mutation probability.
+	PMin, PMax float64
+	// Selection is the parent selection.
+	Selection Selection
+	// Crossover is the crossover rate.
+	Crossover float64
+	// Elitism is the number of elites.
+	Elitism int
+}
+
+// Options returns the options of the configuration.
+func (c Config) Options() []ga.Option {
+	opts := []ga.Option{
+		ga.WithMutationBounds(c.PMin, c.PMax),
+		ga.WithCrossoverRate(c.Crossover),
+		ga.WithElitism(c.Elitism),
+	}
+	if c.Selection.New != nil {
+		opts = append(opts, ga.WithSelector(c.Selection.New()))
+	}
+	return opts
+}
+
+func (c Config) String() string {
+	return fmt.Sprintf("n=%d pm=[%g, %g] selection=%s pc=%g elitism=%d",
+		c.Population, c.PMin, c.PMax, c.Selection.Name, c.Crossover, c.Elitism)
+}
+
+// Space is the search space of the hyperparameters, whose empty dimensions are the defaults of GA model,
+// i.e. the population size 100, the mutation bounds [0.0001, 0.1], the roulette selection, the crossover rate 1 and no elitism.
+type Space struct {
+	Populations    []int
+	MutationBounds [][2]float64
+	Selections     []Selection
+	Crossovers     []float64
+	Elitism        []int
+}
+
+// Grid returns all the configurations of the space.
+func (s Space) Grid() []Config {
+	ns, bs, ss, cs, es := s.dims()
+	var xs []Config
+	for _, n := range ns {
+		for _, b := range bs {
+			for _, sel := range ss {
+				for _, c := range cs {
+					for _, e := range es {
+						xs = append(xs, Config{n, b[0], b[1], sel, c, e})
+					}
+				}
+			}
+		}
+	}
+	return xs
+}
+
+// Sample returns k distinct configurations of the space chosen uniformly by r, or all if there are fewer.
+func (s Space) Sample(k int, r *rand.Rand) []Config {
+	xs := s.Grid()
+	r.Shuffle(len(xs), func(i, j int) {
+		xs[i], xs[j] = xs[j], xs[i]
+	})
+	if k < len(xs) {
+		xs = xs[:k]
+	}
+	return xs
+}
+
+func (s Space) dims() ([]int, [][2]float64, []Selection, []float64, []int) {
+	ns, bs, ss, cs, es := s.Populations, s.MutationBounds, s.Selections, s.Crossovers, s.Elitism
+	if len(ns) == 0 {
+		ns = []int{100}
+	}
+	if len(bs) == 0 {
+		bs = [][2]float64{{0.0001, 0.1}}
+	}
+	if len(ss) == 0 {
+		ss = []Selection{Roulette}
+	}
+	if len(cs) == 0 {
+		cs = []float64{1}
+	}
+	if len(es) == 0 {
+		es = []int{0}
+	}
+	return ns, bs, ss, cs, es
+}
+
+// Problem is the problem the hyperparameters are tuned for.
+type Problem struct {
+	// Generator is the generator of the entities.
+	Generator func() ga.Entity
+	// Evolve runs a model, and returns its fitness, default to Evolve(50, 1000).
+	Evolve func(*ga.GA) float64
+	// Options are the options of every model, applied before those of the configuration.
+	Options []ga.Option
+}
+
+// Result is the statistics of a configuration over the repetitions.
+type Result struct {
+	Config Config
+	// Fitnesses are the fitnesses of the repetitions.
+	Fitnesses []float64
+	// Mean and Std are the mean and standard deviation of the fitnesses.
+	Mean, Std float64
+	// Best and Worst are the best and worst of the fitnesses.
+	Best, Worst float64
+	// Evaluations is the mean number of evaluations.
+	Evaluations float64
+	// Minimize reports whether the fitnesses are minimized, see ga.WithMinimize.
+	Minimize bool
+}
+
+// Run runs each configuration reps times on the problem, with the seeds 1 to reps shared by all the configurations,
+// and returns the results sorted by the mean fitness from the best, then by the number of evaluations.
+func Run(p Problem, xs []Config, reps int) []Result {
+	if reps < 1 {
+		reps = 1
+	}
+	evolve := p.Evolve
+	if evolve == nil {
+		evolve = func(m *ga.GA) float64 {
+			_, f, _ := m.Evolve(50, 1000)
+			return f
+		}
+	}
+	rs := make([]Result, len(xs))
+	for i, x := range xs {
+		r := Result{Config: x, Fitnesses: make([]float64, reps)}
+		for j := range r.Fitnesses {
+			opts := append(append(append([]ga.Option(nil), p.Options...), x.Options()...), ga.WithSeed(int64(j+1)))
+			m := ga.New(x.Population, p.Generator, opts...)
+			r.Fitnesses[j] = evolve(m)
+			s := m.Stats()
+			r.Evaluations += float64(s.Evaluations) / float64(reps)
+			r.Minimize = s.Minimize
+		}
+		r.summarize()
+		rs[i] = r
+	}
+	sort.SliceStable(rs, func(i, j int) bool {
+		a, b := rs[i], rs[j]
+		if a.Mean != b.Mean {
+			return a.Mean > b.Mean != a.Minimize
+		}
+		return a.Evaluations < b.Evaluations
+	})
+	return rs
+}
+
+func (r *Result) summarize() {
+	n := float64(len(r.Fitnesses))
+	r.Best, r.Worst = r.Fitnesses[0], r.Fitnesses[0]
+	for _, f := range r.Fitnesses {
+		r.Mean += f / n
+		if f > r.Best != r.Minimize {
+			r.Best = f
+		}
+		if f < r.Worst != r.Minimize {
+			r.Worst = f
+		}
+	}
+	for _, f := range r.Fitnesses {
+		r.Std += (f - r.Mean) * (f - r.Mean) / n
+	}
+	r.Std = math.Sqrt(r.Std)
+}
@@ -0,0 +1,73 @@
+package tune_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/ofunc/ga"
+	"github.com/ofunc/ga/bench"
+	"github.com/ofunc/ga/tune"
+)
+
+func TestGrid(t *testing.T) {
+	s := tune.Space{
+		Populations: []int{5, 50},
+		Selections:  []tune.Selection{tune.Roulette, tune.Tournament(3)},
+		Elitism:     []int{0, 1},
+	}
+	xs := s.Grid()
+	if len(xs) != 8 || xs[0].Population != 5 || xs[0].PMax != 0.1 || xs[0].Crossover != 1 || xs[7].Selection.Name != "tournament(3)" {
+		t.Fatal("grid:", xs)
+	}
+	if ys := s.Sample(3, rand.New(rand.NewPCG(1, 2))); len(ys) != 3 || ys[0].String() == ys[1].String() {
+		t.Fatal("sample:", ys)
+	}
+
+	p := bench.Rastrigin.Problem(5)
+	rs := tune.Run(tune.Problem{
+		Generator: p.Random,
+		Evolve: func(m *ga.GA) float64 {
+			_, f := m.EvolveUntil(ga.MaxGenerations(50))
+			return f
+		},
+	}, xs, 3)
+	if len(rs) != 8 || rs[0].Config.Population != 50 || rs[7].Config.Population != 5 {
+		t.Fatal("ranking:", rs[0].Config, rs[7].Config)
+	}
+	for _, r := range rs {
+		if len(r.Fitnesses) != 3 || r.Best < r.Mean || r.Worst > r.Mean || r.Std < 0 || r.Evaluations <= 0 {
+			t.Fatal("result:", r)
+		}
+	}
+	for i := 1; i < len(rs); i++ {
+		if rs[i].Mean > rs[i-1].Mean {
+			t.Fatal("order:", rs[i-1].Mean, rs[i].Mean)
+		}
+	}
+}
+
+func TestMinimize(t *testing.T) {
+	rs := tune.Run(tune.Problem{
+		Generator: func() ga.Entity {
+			return Cost(rand.Float64() * 10)
+		},
+		Options: []ga.Option{ga.WithMinimize()},
+	}, tune.Space{Populations: []int{2, 50}}.Grid(), 2)
+	if !rs[0].Minimize || rs[0].Mean > rs[1].Mean || rs[0].Best > rs[0].Worst {
+		t.Fatal("minimize:", rs)
+	}
+}
+
+type Cost float64
+
+func (c Cost) Fitness() float64 {
+	return float64(c * c)
+}
+
+func (c Cost) Mutate() ga.Entity {
+	return c + Cost(rand.Float64()-0.5)
+}
+
+func (c Cost) Crossover(e ga.Entity, w float64) ga.Entity {
+	return Cost(w)*c + Cost(1-w)*e.(Cost)
+}
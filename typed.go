@@ -0,0 +1,114 @@
+package ga
+
+import "fmt"
+
+// Genome is an entity whose operators return its own concrete type E, see NewTyped.
+type Genome[E any] interface {
+	Fitness() float64
+	Mutate() E
+	Crossover(e E, w float64) E
+}
+
+// Typed is a GA model of the entities of the concrete type E,
+// whose accessors return E, so the callers need no type assertions.
+// The embedded GA is the underlying model, whose entities are the boxes of E, see Unbox.
+type Typed[E Genome[E]] struct {
+	*GA
+}
+
+// boxed adapts a Genome to Entity.
+// If E is a pointer, the box is a pointer too, so storing it in an Entity does not allocate,
+// otherwise every box is allocated.
+type boxed[E Genome[E]] struct {
+	e E
+}
+
+func (b boxed[E]) Fitness() float64 {
+	return b.e.Fitness()
+}
+
+func (b boxed[E]) Mutate() Entity {
+	return boxed[E]{b.e.Mutate()}
+}
+
+func (b boxed[E]) Crossover(e Entity, w float64) Entity {
+	return boxed[E]{b.e.Crossover(e.(boxed[E]).e, w)}
+}
+
+// NewTyped creates a typed GA model of n entities generated by g, configured by the options, like New.
+// The optional interfaces of the entities, e.g. Keyer or Releaser, are not seen through the boxes,
+// so if E implements one of them, the model reports it by Err, and E should implement Entity itself, and be used by New, to get them.
+func NewTyped[E Genome[E]](n int, g func() E, opts ...Option) *Typed[E] {
+	m := New(n, func() Entity {
+		return boxed[E]{g()}
+	}, opts...)
+	if name := hidden[E](); name != "" {
+		var e E
+		m.fail("typed", fmt.Errorf("ga: %T implements %s, which is not seen through the boxes of NewTyped", e, name))
+	}
+	return &Typed[E]{m}
+}
+
+// hidden returns the name of an optional interface implemented by E, which the boxes do not forward, or "".
+func hidden[E Genome[E]]() string {
+	var e E
+	switch any(e).(type) {
+	case Releaser:
+		return "Releaser"
+	case Constrained:
+		return "Constrained"
+	case Repairer:
+		return "Repairer"
+	case Keyer:
+		return "Keyer"
+	case Hasher:
+		return "Hasher"
+	case Sized:
+		return "Sized"
+	case Describer:
+		return "Describer"
+	case Objective:
+		return "Objective"
+	case IndexedFitness:
+		return "IndexedFitness"
+	case WeightlessCrossover:
+		return "WeightlessCrossover"
+	}
+	return ""
+}
+
+// Unbox returns the entity of the type E in the box e, and false if e is not a box of E, e.g. nil.
+func Unbox[E Genome[E]](e Entity) (E, bool) {
+	b, ok := e.(boxed[E])
+	return b.e, ok
+}
+
+// Next gets the next generation, and returns the current elite and fitness.
+func (m *Typed[E]) Next() (E, float64) {
+	e, f := m.GA.Next()
+	x, _ := Unbox[E](e)
+	return x, f
+}
+
+// Evolve runs the model like GA.Evolve, and returns the elite, the fitness and whether it has converged.
+func (m *Typed[E]) Evolve(k int, max int) (E, float64, bool) {
+	e, f, ok := m.GA.Evolve(k, max)
+	x, _ := Unbox[E](e)
+	return x, f, ok
+}
+
+// Elite returns the elite, or the zero E if there is not.
+func (m *Typed[E]) Elite() E {
+	x, _ := Unbox[E](m.GA.Elite())
+	return x
+}
+
+// Population returns the entities of the current population.
+func (m *Typed[E]) Population() []E {
+	es := m.pop.entities
+	xs := make([]E, len(es))
+	for i, e := range es {
+		xs[i], _ = Unbox[E](e)
+	}
+	return xs
+}
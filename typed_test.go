@@ -0,0 +1,76 @@
+package ga_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+// Point is a typed genome of two coordinates.
+type Point struct {
+	X, Y float64
+}
+
+func (p *Point) Fitness() float64 {
+	return -sqr(p.X-1) - sqr(p.Y+2)
+}
+
+func (p *Point) Mutate() *Point {
+	return &Point{p.X + rand.NormFloat64(), p.Y + rand.NormFloat64()}
+}
+
+func (p *Point) Crossover(q *Point, w float64) *Point {
+	return &Point{w*p.X + (1-w)*q.X, w*p.Y + (1-w)*q.Y}
+}
+
+func TestTyped(t *testing.T) {
+	m := ga.NewTyped(50, func() *Point {
+		return &Point{10*rand.Float64() - 5, 10*rand.Float64() - 5}
+	})
+	e, f, _ := m.Evolve(30, 200)
+	if e == nil || f < -1e-2 || e != m.Elite() || f != e.Fitness() {
+		t.Fatal("elite:", e, f)
+	}
+	if ps := m.Population(); len(ps) != 50 || ps[0] == nil {
+		t.Fatal("population:", ps)
+	}
+	if p, ok := ga.Unbox[*Point](m.GA.Elite()); !ok || p != e {
+		t.Fatal("unbox:", p, ok)
+	}
+	if _, ok := ga.Unbox[*Point](nil); ok {
+		t.Fatal("unbox nil")
+	}
+}
+
+// Bounded is a Point with a constraint, which is not seen through the boxes.
+type Bounded struct {
+	Point
+}
+
+func (b *Bounded) Violation() float64 {
+	return b.X - 2
+}
+
+func (b *Bounded) Mutate() *Bounded {
+	return &Bounded{*b.Point.Mutate()}
+}
+
+func (b *Bounded) Crossover(c *Bounded, w float64) *Bounded {
+	return &Bounded{*b.Point.Crossover(&c.Point, w)}
+}
+
+func TestTypedHidden(t *testing.T) {
+	m := ga.NewTyped(10, func() *Bounded {
+		return &Bounded{Point{rand.Float64(), rand.Float64()}}
+	})
+	if m.Err() == nil {
+		t.Fatal("hidden Constrained not reported")
+	}
+	p := ga.NewTyped(10, func() *Point {
+		return &Point{rand.Float64(), rand.Float64()}
+	})
+	if err := p.Err(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,65 @@
+package ga
+
+// WithValidator calls validate with each new generation produced by breeding, before it is evaluated,
+// e.g. for the schema validation, the feasibility filters, or the deduplication against a database.
+// validate may replace the entities of es in place, or set them to nil to veto them.
+// The vetoed slots are bred again and passed alone to validate again, up to retries times,
+// and the slots still vetoed keep the entities of the current population.
+// The elites, e.g. by WithElitism, are passed too. It applies to the generational breeding,
+// not to WithSteadyState, WithDifferentialEvolution, WithDeterministicCrowding, WithMapElites or WithStreaming.
+func WithValidator(validate func(gen int, es []Entity), retries int) Option {
+	return func(m *GA) {
+		m.validator = &validator{validate, retries}
+	}
+}
+
+type validator struct {
+	validate func(gen int, es []Entity)
+	retries  int
+}
+
+// validate validates the offspring, and breeds the vetoed slots again.
+func (m *GA) validate() {
+	v, es := m.validator, m.tentities
+	v.validate(m.gen, es)
+	for k := 1; ; k++ {
+		var idx []int
+		for i, e := range es {
+			if e == nil {
+				idx = append(idx, i)
+			}
+		}
+		if len(idx) == 0 {
+			return
+		}
+		if k > v.retries {
+			for _, i := range idx {
+				es[i] = m.pop.entities[i]
+			}
+			return
+		}
+		m.fork()
+		m.pop.parallel(len(idx), func(c, j int) {
+			i := idx[j]
+			es[i] = m.offspring(i, m.redraw(c, i, k))
+		})
+		vs := make([]Entity, len(idx))
+		for j, i := range idx {
+			vs[j] = es[i]
+		}
+		v.validate(m.gen, vs)
+		for j, i := range idx {
+			es[i] = vs[j]
+		}
+	}
+}
+
+// redraw returns the random numbers for the k-th retry of the slot i produced by the worker c,
+// which are independent of the ones of the slot by random.
+func (m *GA) redraw(c, i, k int) func() float64 {
+	if !m.streams {
+		return m.workers[c].Float64
+	}
+	s := stream(mix(uint64(m.seed)^mix(uint64(m.gen)<<32|uint64(uint32(i)))) ^ mix(uint64(k)))
+	return s.Float64
+}
@@ -0,0 +1,45 @@
+package ga_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+func TestValidator(t *testing.T) {
+	calls, gens := 0, 0
+	m := ga.New(30, func() ga.Entity {
+		return Walk(10*rand.Float64() - 5)
+	}, ga.WithFixedMutationRate(1), ga.WithValidator(func(gen int, es []ga.Entity) {
+		calls++
+		if len(es) == 30 {
+			gens++
+			es[29] = nil
+		}
+		for i, e := range es {
+			if e == nil {
+				continue
+			}
+			if w := e.(Walk); w > 2 {
+				es[i] = nil
+			} else if w < -2 {
+				es[i] = Walk(0)
+			}
+		}
+	}, 3))
+	for i := 0; i < 20; i++ {
+		m.Next()
+		if i == 0 {
+			continue
+		}
+		for _, e := range m.Population() {
+			if w := e.(Walk); w > 2 || w < -2 {
+				t.Fatal("invalid:", i, w)
+			}
+		}
+	}
+	if gens != 20 || calls <= gens {
+		t.Fatal("calls:", gens, calls)
+	}
+}
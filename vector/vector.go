@@ -0,0 +1,246 @@
+// Package vector implements the real-valued vector genome of GA model,
+// with bounds, and the standard crossover and mutation operators.
+package vector
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/ofunc/ga"
+)
+
+// Crossover is a crossover operator of vectors, which produces a child of x and y into z with the random numbers of r,
+// where w is the weight of x, see ga.Entity.
+type Crossover func(r *rand.Rand, z, x, y, lower, upper []float64, w float64)
+
+// Mutation is a mutation operator of a gene, which returns the mutated x in [lower, upper] with the random numbers of r.
+type Mutation func(r *rand.Rand, x, lower, upper float64) float64
+
+// Space is a bounded space of vectors.
+type Space struct {
+	lower     []float64
+	upper     []float64
+	fitness   func([]float64) float64
+	crossover Crossover
+	mutation  Mutation
+	rate      float64
+}
+
+// Option is an option of Space.
+type Option func(*Space)
+
+// WithCrossover sets the crossover operator, default to Arithmetic.
+func WithCrossover(c Crossover) Option {
+	return func(s *Space) {
+		s.crossover = c
+	}
+}
+
+// WithMutation sets the mutation operator, default to Gaussian(0.1).
+func WithMutation(m Mutation) Option {
+	return func(s *Space) {
+		s.mutation = m
+	}
+}
+
+// WithGeneRate sets the probability that each gene is mutated, when the vector is mutated, default to 1/d.
+// At least one gene is mutated anyway.
+func WithGeneRate(p float64) Option {
+	return func(s *Space) {
+		s.rate = p
+	}
+}
+
+// NewSpace creates the space of vectors in [lower, upper] with the fitness function f.
+func NewSpace(lower, upper []float64, f func([]float64) float64, opts ...Option) *Space {
+	if len(lower) != len(upper) {
+		panic("vector: the bounds have different dimensions")
+	}
+	s := &Space{
+		lower:     lower,
+		upper:     upper,
+		fitness:   f,
+		crossover: Arithmetic,
+		mutation:  Gaussian(0.1),
+		rate:      1 / float64(len(lower)),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Dim returns the dimension of the space.
+func (s *Space) Dim() int {
+	return len(s.lower)
+}
+
+// Bounds returns the lower and upper bounds of the space, which must not be modified.
+func (s *Space) Bounds() (lower, upper []float64) {
+	return s.lower, s.upper
+}
+
+// New returns the vector x of the space, clamped into the bounds.
+func (s *Space) New(x []float64) *Vector {
+	v := &Vector{make([]float64, len(x)), s}
+	for i := range x {
+		v.x[i] = clamp(x[i], s.lower[i], s.upper[i])
+	}
+	return v
+}
+
+// Random returns a vector uniformly distributed in the space, which can be the generator of GA model.
+func (s *Space) Random() ga.Entity {
+	return s.RandomCtx(ga.GenCtx{})
+}
+
+// RandomCtx is Random with the random numbers of ctx, which can be the generator of ga.NewCtx,
+// so that the vectors are reproducible by ga.WithSeed.
+func (s *Space) RandomCtx(ctx ga.GenCtx) ga.Entity {
+	r := ctx.Random()
+	v := &Vector{make([]float64, s.Dim()), s}
+	for i := range v.x {
+		v.x[i] = s.lower[i] + r.Float64()*(s.upper[i]-s.lower[i])
+	}
+	return v
+}
+
+// Vector is a vector of a space, which implements ga.Entity,
+// and ga.ContextMutator and ga.ContextCrossover to draw the random numbers of the model.
+type Vector struct {
+	x []float64
+	s *Space
+}
+
+// X returns the genes of the vector, which must not be modified.
+func (v *Vector) X() []float64 {
+	return v.x
+}
+
+// Fitness returns the fitness of the vector.
+func (v *Vector) Fitness() float64 {
+	return v.s.fitness(v.x)
+}
+
+// Mutate mutates the genes of the vector, each with the gene rate.
+func (v *Vector) Mutate() ga.Entity {
+	return v.MutateCtx(ga.GenCtx{})
+}
+
+// MutateCtx is Mutate with the random numbers of ctx.
+func (v *Vector) MutateCtx(ctx ga.GenCtx) ga.Entity {
+	s, r := v.s, ctx.Random()
+	z := &Vector{append([]float64(nil), v.x...), s}
+	mutated := false
+	for i := range z.x {
+		if r.Float64() < s.rate {
+			z.x[i], mutated = s.mutation(r, z.x[i], s.lower[i], s.upper[i]), true
+		}
+	}
+	if !mutated {
+		i := r.IntN(len(z.x))
+		z.x[i] = s.mutation(r, z.x[i], s.lower[i], s.upper[i])
+	}
+	return z
+}
+
+// Crossover produces a child of v and e by the crossover operator of the space.
+func (v *Vector) Crossover(e ga.Entity, w float64) ga.Entity {
+	return v.CrossoverCtx(ga.GenCtx{}, e, w)
+}
+
+// CrossoverCtx is Crossover with the random numbers of ctx.
+func (v *Vector) CrossoverCtx(ctx ga.GenCtx, e ga.Entity, w float64) ga.Entity {
+	s := v.s
+	z := &Vector{make([]float64, len(v.x)), s}
+	s.crossover(ctx.Random(), z.x, v.x, e.(*Vector).x, s.lower, s.upper, w)
+	return z
+}
+
+// Combine returns the trial vector of differential evolution, which implements ga.Combiner.
+// Each gene is taken from the mutant a + F*(b-c) at the rate CR, and at least one is, clamped into the bounds.
+func (v *Vector) Combine(a, b, c ga.Entity, F, CR float64) ga.Entity {
+	s := v.s
+	x, y, w := a.(*Vector).x, b.(*Vector).x, c.(*Vector).x
+	z := &Vector{append([]float64(nil), v.x...), s}
+	k := rand.IntN(len(z.x))
+	for i := range z.x {
+		if i == k || rand.Float64() < CR {
+			z.x[i] = clamp(x[i]+F*(y[i]-w[i]), s.lower[i], s.upper[i])
+		}
+	}
+	return z
+}
+
+// Arithmetic is the weighted average of the parents.
+func Arithmetic(r *rand.Rand, z, x, y, lower, upper []float64, w float64) {
+	for i := range z {
+		z[i] = w*x[i] + (1-w)*y[i]
+	}
+}
+
+// BLX returns the blend crossover BLX-α, which samples each gene uniformly from the interval spanned by the parents,
+// extended by alpha of its length on both sides. The weight is ignored.
+func BLX(alpha float64) Crossover {
+	return func(r *rand.Rand, z, x, y, lower, upper []float64, w float64) {
+		for i := range z {
+			a, b := math.Min(x[i], y[i]), math.Max(x[i], y[i])
+			d := alpha * (b - a)
+			z[i] = clamp(a-d+r.Float64()*(b-a+2*d), lower[i], upper[i])
+		}
+	}
+}
+
+// SBX returns the simulated binary crossover with the distribution index eta,
+// where a larger eta produces children closer to the parents.
+// One of the two children of SBX is chosen for each gene randomly. The weight is ignored.
+func SBX(eta float64) Crossover {
+	return func(r *rand.Rand, z, x, y, lower, upper []float64, w float64) {
+		for i := range z {
+			u := r.Float64()
+			var beta float64
+			if u <= 0.5 {
+				beta = math.Pow(2*u, 1/(eta+1))
+			} else {
+				beta = math.Pow(1/(2*(1-u)), 1/(eta+1))
+			}
+			m, d := (x[i]+y[i])/2, beta*(x[i]-y[i])/2
+			if r.IntN(2) == 0 {
+				d = -d
+			}
+			z[i] = clamp(m+d, lower[i], upper[i])
+		}
+	}
+}
+
+// Gaussian returns the Gaussian mutation, which adds the normal noise with the standard deviation
+// sigma times the range of the gene.
+func Gaussian(sigma float64) Mutation {
+	return func(r *rand.Rand, x, lower, upper float64) float64 {
+		return clamp(x+r.NormFloat64()*sigma*(upper-lower), lower, upper)
+	}
+}
+
+// Polynomial returns the polynomial mutation with the distribution index eta,
+// where a larger eta produces smaller perturbations. It always stays in the bounds.
+func Polynomial(eta float64) Mutation {
+	return func(r *rand.Rand, x, lower, upper float64) float64 {
+		d := upper - lower
+		if d <= 0 {
+			return x
+		}
+		d1, d2 := (x-lower)/d, (upper-x)/d
+		u, p := r.Float64(), 1/(eta+1)
+		var q float64
+		if u < 0.5 {
+			q = math.Pow(2*u+(1-2*u)*math.Pow(1-d1, eta+1), p) - 1
+		} else {
+			q = 1 - math.Pow(2*(1-u)+2*(u-0.5)*math.Pow(1-d2, eta+1), p)
+		}
+		return clamp(x+q*d, lower, upper)
+	}
+}
+
+func clamp(x, lower, upper float64) float64 {
+	return math.Max(lower, math.Min(upper, x))
+}
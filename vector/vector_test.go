@@ -0,0 +1,62 @@
+package vector_test
+
+import (
+	"testing"
+
+	"github.com/ofunc/ga"
+	"github.com/ofunc/ga/vector"
+)
+
+func sphere(x []float64) float64 {
+	f := 0.0
+	for _, v := range x {
+		f -= (v - 1) * (v - 1)
+	}
+	return f
+}
+
+func TestOperators(t *testing.T) {
+	lower, upper := []float64{-5, -5, -5}, []float64{5, 5, 5}
+	for name, opts := range map[string][]vector.Option{
+		"default":    nil,
+		"sbx":        {vector.WithCrossover(vector.SBX(15)), vector.WithMutation(vector.Polynomial(20))},
+		"blx":        {vector.WithCrossover(vector.BLX(0.5)), vector.WithMutation(vector.Gaussian(0.05))},
+		"polynomial": {vector.WithMutation(vector.Polynomial(20)), vector.WithGeneRate(1)},
+	} {
+		s := vector.NewSpace(lower, upper, sphere, opts...)
+		m := ga.New(100, s.Random)
+		e, f, _ := m.Evolve(30, 300)
+		if f < -1e-2 {
+			t.Error(name, "fitness:", f, e.(*vector.Vector).X())
+		}
+	}
+}
+
+func TestBounds(t *testing.T) {
+	lower, upper := []float64{0, -1}, []float64{1, 0}
+	s := vector.NewSpace(lower, upper, sphere,
+		vector.WithCrossover(vector.BLX(2)), vector.WithMutation(vector.Gaussian(10)))
+	x, y := s.Random(), s.New([]float64{3, -3})
+	if v := y.X(); v[0] != 1 || v[1] != -1 {
+		t.Fatal("clamp:", v)
+	}
+	for i := 0; i < 1000; i++ {
+		for _, e := range []ga.Entity{x.Mutate(), x.Crossover(y, 0.5)} {
+			for j, v := range e.(*vector.Vector).X() {
+				if v < lower[j] || v > upper[j] {
+					t.Fatal("out of bounds:", j, v)
+				}
+			}
+		}
+	}
+}
+
+func TestDifferentialEvolution(t *testing.T) {
+	s := vector.NewSpace([]float64{-5, -5, -5}, []float64{5, 5, 5}, sphere)
+	for _, strategy := range []ga.DEStrategy{ga.DERand1, ga.DEBest1} {
+		m := ga.New(50, s.Random, ga.WithDifferentialEvolution(0.5, 0.9), ga.WithDEStrategy(strategy))
+		if _, f, _ := m.Evolve(30, 300); f < -1e-4 {
+			t.Error(strategy, "fitness:", f)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package ga_test
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+type Weighed struct {
+	X       int
+	mutex   *sync.Mutex
+	records *[][3]float64
+}
+
+func (x Weighed) Fitness() float64 {
+	return float64(x.X)
+}
+
+func (x Weighed) Mutate() ga.Entity {
+	return x
+}
+
+func (x Weighed) Crossover(e ga.Entity, w float64) ga.Entity {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+	*x.records = append(*x.records, [3]float64{x.Fitness(), e.Fitness(), w})
+	return x
+}
+
+func TestWeightConvention(t *testing.T) {
+	for _, c := range []ga.WeightConvention{ga.SelfWeight, ga.OtherWeight} {
+		var mutex sync.Mutex
+		var records [][3]float64
+		m := ga.New(20, func() ga.Entity {
+			return Weighed{rand.Intn(100), &mutex, &records}
+		}, ga.WithWeightConvention(c))
+		m.Next()
+		for _, r := range records {
+			self, other, w := r[0], r[1], r[2]
+			if c == ga.OtherWeight {
+				self, other = other, self
+			}
+			if self != other && (self > other) != (w > 0.5) {
+				t.Fatal("convention:", c, r)
+			}
+		}
+	}
+}
+
+type Weightless struct {
+	Weighed
+}
+
+func (x Weightless) Mutate() ga.Entity {
+	return x
+}
+
+func (x Weightless) Crossover(e ga.Entity, w float64) ga.Entity {
+	panic("Crossover called")
+}
+
+func (x Weightless) Crossover2(e ga.Entity) ga.Entity {
+	return Weightless{x.Weighed.Crossover(e.(Weightless).Weighed, -1).(Weighed)}
+}
+
+func TestCrossoverWeight(t *testing.T) {
+	var mutex sync.Mutex
+	var records [][3]float64
+	m := ga.New(20, func() ga.Entity {
+		return Weighed{rand.Intn(100), &mutex, &records}
+	}, ga.WithCrossoverWeight(ga.EvenWeight))
+	m.Next()
+	for _, r := range records {
+		if r[2] != 0.5 {
+			t.Fatal("weight:", r)
+		}
+	}
+	records = nil
+	m = ga.New(20, func() ga.Entity {
+		return Weightless{Weighed{rand.Intn(100), &mutex, &records}}
+	})
+	m.Next()
+	m.Next()
+	if len(records) == 0 || records[0][2] != -1 {
+		t.Fatal("weightless:", records)
+	}
+}
@@ -0,0 +1,122 @@
+package ga
+
+import (
+	"math"
+	"sync"
+)
+
+// Weighted aggregates the objectives of the entities implementing MultiObjective into their fitnesses by the weights,
+// where each objective is normalized by its range observed in the populations, so no objective dominates by its raw scale.
+// See WithWeighted.
+type Weighted struct {
+	names   []string
+	weights []float64
+	mutex   sync.Mutex
+	lo, hi  []float64
+}
+
+// Term is the contribution of an objective to the aggregated fitness, see Weighted.Breakdown.
+type Term struct {
+	// Name is the name of the objective.
+	Name string
+	// Value is the raw value of the objective.
+	Value float64
+	// Normalized is the value normalized by the range of the objective, 0 at the worst and 1 at the best.
+	Normalized float64
+	// Weighted is the normalized value times the weight of the objective.
+	Weighted float64
+}
+
+// NewWeighted creates the aggregation of the objectives of the names by the weights.
+// It panics if the names and the weights have different lengths.
+func NewWeighted(names []string, weights []float64) *Weighted {
+	if len(names) != len(weights) {
+		panic("ga: names and weights have different lengths")
+	}
+	return &Weighted{names: names, weights: weights}
+}
+
+// WithWeighted evaluates the fitnesses as the aggregations of the objectives by w.
+// The ranges of the objectives are extended by each generation evaluated as a whole,
+// and the fitness of the elite is rescaled with them, while the incremental updates, e.g. by WithSteadyState,
+// are normalized by the ranges as they are. The ranges are not shrunk as the population converges,
+// which would magnify the differences of the converged entities.
+// The objectives are maximized as in WithPareto, so it should not be combined with WithMinimize.
+// As with WithBatchEvaluator, the oracle, the fitness cache and the generation deadline are not applied.
+func WithWeighted(w *Weighted) Option {
+	return func(m *GA) {
+		m.evaluator("WithWeighted", func(es []Entity, fs []float64) {
+			os := m.objectives(es)
+			w.mutex.Lock()
+			defer w.mutex.Unlock()
+			if len(es) == len(m.pop.entities) {
+				w.rescale(os)
+				if m.elite != nil {
+					m.fitness = w.score(m.objectives([]Entity{m.elite})[0])
+					if m.penalty != nil {
+						m.fitness = m.penalize(m.elite, m.fitness)
+					}
+				}
+			}
+			for i, o := range os {
+				fs[i] = w.score(o)
+			}
+		})
+	}
+}
+
+// rescale extends the ranges of the objectives by os.
+func (w *Weighted) rescale(os [][]float64) {
+	if k := len(w.weights); len(w.lo) != k {
+		w.lo, w.hi = make([]float64, k), make([]float64, k)
+		for j := range w.lo {
+			w.lo[j], w.hi[j] = math.Inf(1), math.Inf(-1)
+		}
+	}
+	for _, o := range os {
+		for j := 0; j < len(w.lo) && j < len(o); j++ {
+			if x := o[j]; !math.IsInf(x, 0) && !math.IsNaN(x) {
+				w.lo[j], w.hi[j] = math.Min(w.lo[j], x), math.Max(w.hi[j], x)
+			}
+		}
+	}
+}
+
+// normalize returns the value x of the objective j normalized by its range, or 0 if the range is empty.
+func (w *Weighted) normalize(j int, x float64) float64 {
+	if j >= len(w.lo) || !(w.hi[j] > w.lo[j]) {
+		return 0
+	}
+	return (x - w.lo[j]) / (w.hi[j] - w.lo[j])
+}
+
+func (w *Weighted) score(o []float64) float64 {
+	s := 0.0
+	for j, x := range o {
+		if j < len(w.weights) {
+			s += w.weights[j] * w.normalize(j, x)
+		}
+	}
+	if math.IsNaN(s) {
+		return math.Inf(-1)
+	}
+	return s
+}
+
+// Breakdown returns the contributions of the objectives of e to its fitness, by the current ranges,
+// e.g. of the elite. It is safe to call concurrently with Next.
+func (w *Weighted) Breakdown(e Entity) []Term {
+	o := e.(MultiObjective).Objectives()
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	ts := make([]Term, len(w.names))
+	for j := range ts {
+		ts[j].Name = w.names[j]
+		if j < len(o) {
+			ts[j].Value = o[j]
+			ts[j].Normalized = w.normalize(j, o[j])
+			ts[j].Weighted = w.weights[j] * ts[j].Normalized
+		}
+	}
+	return ts
+}
@@ -0,0 +1,45 @@
+package ga_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/ofunc/ga"
+)
+
+type Tradeoff float64
+
+func (x Tradeoff) Fitness() float64 {
+	return 0
+}
+
+func (x Tradeoff) Objectives() []float64 {
+	return []float64{1000 * (1 - float64(x)), float64(x)}
+}
+
+func (x Tradeoff) Mutate() ga.Entity {
+	return Tradeoff(math.Max(0, math.Min(1, float64(x)+0.1*rand.NormFloat64())))
+}
+
+func (x Tradeoff) Crossover(e ga.Entity, w float64) ga.Entity {
+	return Tradeoff(w*float64(x) + (1-w)*float64(e.(Tradeoff)))
+}
+
+func TestWeighted(t *testing.T) {
+	w := ga.NewWeighted([]string{"cost", "quality"}, []float64{1, 3})
+	m := ga.New(50, func() ga.Entity {
+		return Tradeoff(rand.Float64())
+	}, ga.WithWeighted(w))
+	e, f, _ := m.Evolve(20, 200)
+	if x := e.(Tradeoff); x < 0.95 {
+		t.Fatal("elite:", x, f)
+	}
+	ts := w.Breakdown(e)
+	if len(ts) != 2 || ts[0].Name != "cost" || ts[1].Name != "quality" || ts[1].Value != float64(e.(Tradeoff)) {
+		t.Fatal("breakdown:", ts)
+	}
+	if s := ts[0].Weighted + ts[1].Weighted; math.Abs(s-f) > 1e-9 {
+		t.Fatal("fitness:", s, f)
+	}
+}
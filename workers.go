@@ -0,0 +1,145 @@
+package ga
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+var workers int64
+
+// SetMaxTotalWorkers limits the total number of worker goroutines spawned by all GA models, default to unlimited.
+// If n <= 0, the number is unlimited.
+// The workers are persistent and reused by all the parallel steps, and the workers beyond a lowered limit exit.
+// The goroutine which drives a GA model always does its share of work without a worker slot,
+// and a share without a free slot is done by it too, rather than waiting for one.
+// So nested GA models, e.g. a GA model running in the Fitness of another, never deadlock,
+// and share the bounded budget instead of spawning GOMAXPROCS × GOMAXPROCS goroutines.
+// The limit is process-wide, as the pool is shared by all the GA models and the packages using them,
+// so it should be set once by the program, e.g. in main, and WithExecutor bounds the workers of some models instead.
+func SetMaxTotalWorkers(n int) {
+	atomic.StoreInt64(&workers, int64(n))
+}
+
+func maxWorkers() int {
+	return int(atomic.LoadInt64(&workers))
+}
+
+// WithConcurrency sets the number of concurrency of the model to n, which is fixed after New.
+// If n <= 0, the default NC is used, e.g. to restore the default in Clone.
+func WithConcurrency(n int) Option {
+	return func(m *GA) {
+		m.pop.nc = n
+		if n <= 0 {
+			m.pop.nc = NC
+		}
+	}
+}
+
+// concurrency returns the number of concurrency of the population.
+func (p *Population) concurrency() int {
+	if p.nc > 0 {
+		return p.nc
+	}
+	return 1
+}
+
+// concurrency returns the default number of concurrency of the package functions without a model.
+func concurrency() int {
+	return runtime.GOMAXPROCS(0)
+}
+
+// sequential calls f(0, i) for i in [0, n) in order.
+func sequential(n int, f func(c, i int)) {
+	for i := 0; i < n; i++ {
+		f(0, i)
+	}
+}
+
+// parallel calls f(c, i) for i in [0, n) by nc workers, where c is the index of the worker.
+// The workers of the shares other than the first are taken from the pool.
+func parallel(nc, n int, f func(c, i int)) {
+	if n <= 0 {
+		return
+	}
+	if nc > n {
+		nc = n
+	}
+	run := func(c int) {
+		lo, hi := span(c, nc, n)
+		for i := lo; i < hi; i++ {
+			f(c, i)
+		}
+	}
+	var wg sync.WaitGroup
+	var inline []int
+	for c := 1; c < nc && c < n; c++ {
+		c := c
+		wg.Add(1)
+		if !pool.submit(func() {
+			defer wg.Done()
+			run(c)
+		}) {
+			wg.Done()
+			inline = append(inline, c)
+		}
+	}
+	run(0)
+	for _, c := range inline {
+		run(c)
+	}
+	wg.Wait()
+}
+
+// span returns the share [lo, hi) of [0, n) of the worker c of nc workers.
+// The shares are contiguous rather than strided, so the workers write to distinct cache lines
+// of the slices indexed by i, e.g. the fitnesses, instead of invalidating the lines of each other.
+func span(c, nc, n int) (int, int) {
+	return c * n / nc, (c + 1) * n / nc
+}
+
+// cacheLine is the size of a cache line, by which the per-worker states are padded.
+const cacheLine = 64
+
+// pool is the pool of the persistent worker goroutines shared by all GA models,
+// so a parallel share reuses an idle worker instead of spawning a goroutine.
+var pool = &workerPool{tasks: make(chan func())}
+
+type workerPool struct {
+	tasks chan func()
+	mutex sync.Mutex
+	n     int
+}
+
+// submit runs t by an idle worker, or a new worker if the limit set by SetMaxTotalWorkers allows,
+// and reports false if t is not submitted.
+func (p *workerPool) submit(t func()) bool {
+	select {
+	case p.tasks <- t:
+		return true
+	default:
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if max := maxWorkers(); max > 0 && p.n >= max {
+		return false
+	}
+	p.n++
+	go p.work(t)
+	return true
+}
+
+// work runs t, and then the submitted tasks, until the workers exceed the limit.
+func (p *workerPool) work(t func()) {
+	for {
+		t()
+		p.mutex.Lock()
+		if max := maxWorkers(); max > 0 && p.n > max {
+			p.n--
+			p.mutex.Unlock()
+			return
+		}
+		p.mutex.Unlock()
+		t = <-p.tasks
+	}
+}
@@ -0,0 +1,113 @@
+package ga_test
+
+import (
+	"math/rand"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ofunc/ga"
+)
+
+type Nested float64
+
+func (x Nested) Fitness() float64 {
+	m := ga.New(10, MIN{}.Mutate)
+	_, f, _ := m.Evolve(3, 5)
+	return f - sqr(float64(x))
+}
+
+func (x Nested) Mutate() ga.Entity {
+	return Nested(200*rand.Float64() - 100)
+}
+
+func (x Nested) Crossover(e ga.Entity, w float64) ga.Entity {
+	return Nested(w*float64(x) + (1-w)*float64(e.(Nested)))
+}
+
+func TestMaxTotalWorkers(t *testing.T) {
+	nc := ga.NC
+	ga.NC = 4
+	ga.SetMaxTotalWorkers(2)
+	defer func() {
+		ga.NC = nc
+		ga.SetMaxTotalWorkers(0)
+	}()
+
+	m := ga.New(20, Nested(0).Mutate)
+	_, f, _ := m.Evolve(5, 10)
+	if f > 0 || f < -1e4 {
+		t.Fatal("fitness:", f)
+	}
+}
+
+var active, peakActive int32
+
+// Busy is Walk, tracking the peak number of concurrent evaluations.
+type Busy float64
+
+func (x Busy) Fitness() float64 {
+	n := atomic.AddInt32(&active, 1)
+	for {
+		p := atomic.LoadInt32(&peakActive)
+		if n <= p || atomic.CompareAndSwapInt32(&peakActive, p, n) {
+			break
+		}
+	}
+	time.Sleep(time.Millisecond)
+	atomic.AddInt32(&active, -1)
+	return Walk(x).Fitness()
+}
+
+func (x Busy) Mutate() ga.Entity {
+	return Busy(Walk(x).Mutate().(Walk))
+}
+
+func (x Busy) Crossover(e ga.Entity, w float64) ga.Entity {
+	return Busy(Walk(x).Crossover(Walk(e.(Busy)), w).(Walk))
+}
+
+func TestConcurrency(t *testing.T) {
+	nc := ga.NC
+	ga.NC = 1
+	defer func() { ga.NC = nc }()
+	for _, n := range []int{1, 4} {
+		atomic.StoreInt32(&peakActive, 0)
+		m := ga.New(16, func() ga.Entity {
+			return Busy(rand.Float64())
+		}, ga.WithConcurrency(n))
+		m.EvolveTo(2)
+		if p := atomic.LoadInt32(&peakActive); (n == 1) != (p == 1) {
+			t.Fatal("concurrency:", n, p)
+		}
+	}
+}
+
+func TestConcurrencyFixed(t *testing.T) {
+	nc := ga.NC
+	ga.NC = 1
+	defer func() { ga.NC = nc }()
+	m := ga.New(16, func() ga.Entity {
+		return Busy(rand.Float64())
+	})
+	ga.NC = 4
+	atomic.StoreInt32(&peakActive, 0)
+	m.EvolveTo(2)
+	if p := atomic.LoadInt32(&peakActive); p != 1 {
+		t.Fatal("concurrency:", p)
+	}
+}
+
+func TestConcurrencyDefault(t *testing.T) {
+	nc := ga.NC
+	ga.NC = 4
+	defer func() { ga.NC = nc }()
+	m := ga.New(16, func() ga.Entity {
+		return Busy(rand.Float64())
+	}, ga.WithConcurrency(1)).Clone(ga.WithConcurrency(0))
+	atomic.StoreInt32(&peakActive, 0)
+	m.EvolveTo(2)
+	if p := atomic.LoadInt32(&peakActive); p < 2 {
+		t.Fatal("concurrency:", p)
+	}
+}